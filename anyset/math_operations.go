@@ -0,0 +1,118 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package anyset provides the set operations and predicates of package sets for any
+// implementation of the sets.ReadOnly and sets.Mutable interfaces, so that alternative
+// set representations do not need their own copies of Union, Subset and friends.
+//
+// Operations write their result into a destination set supplied by the caller,
+// which determines the representation of the result. Elements are only compared
+// through the Contains methods of the sets involved.
+package anyset
+
+import "github.com/kkhmel/sets"
+
+// Union inserts into dst all elements from all provided sets.
+//
+// Time complexity: O(N). N is the sum of all set sizes.
+func Union[E any](dst sets.Mutable[E], srcs ...sets.ReadOnly[E]) {
+	for _, s := range srcs {
+		for e := range s.All() {
+			dst.Insert(e)
+		}
+	}
+}
+
+// Intersection inserts into dst the elements that are present in all provided sets.
+// If no sets are provided, dst is left unchanged.
+//
+// Time complexity: O(min * len(srcs)). min is the size of the smallest set.
+func Intersection[E any](dst sets.Mutable[E], srcs ...sets.ReadOnly[E]) {
+	if len(srcs) == 0 {
+		return
+	}
+	smallest := 0
+	for i := 1; i < len(srcs); i++ {
+		if srcs[i].Len() < srcs[smallest].Len() {
+			smallest = i
+		}
+	}
+elementsLoop:
+	for e := range srcs[smallest].All() {
+		for i, s := range srcs {
+			if i != smallest && !s.Contains(e) {
+				continue elementsLoop
+			}
+		}
+		dst.Insert(e)
+	}
+}
+
+// Difference inserts into dst the elements of minuend that are not in any of the subtrahends.
+//
+// Time complexity: O(len(minuend) * len(subtrahends)).
+func Difference[E any](dst sets.Mutable[E], minuend sets.ReadOnly[E], subtrahends ...sets.ReadOnly[E]) {
+elementsLoop:
+	for e := range minuend.All() {
+		for _, s := range subtrahends {
+			if s.Contains(e) {
+				continue elementsLoop
+			}
+		}
+		dst.Insert(e)
+	}
+}
+
+// SymmetricDifference inserts into dst the elements that belong to
+// an odd number of the provided sets (i.e., the n-ary XOR of the sets).
+//
+// Time complexity: O(N * len(srcs)). N is the sum of all set sizes.
+func SymmetricDifference[E any](dst sets.Mutable[E], srcs ...sets.ReadOnly[E]) {
+	for i, s := range srcs {
+	elementsLoop:
+		for e := range s.All() {
+			count := 1
+			for j, o := range srcs {
+				if j == i || !o.Contains(e) {
+					continue
+				}
+				if j < i {
+					// The element was already considered when iterating over an earlier set.
+					continue elementsLoop
+				}
+				count++
+			}
+			if count%2 == 1 {
+				dst.Insert(e)
+			}
+		}
+	}
+}
+
+// CartesianProduct inserts into dst all ordered pairs (e1, e2) where e1 is from set1 and e2 is from set2.
+//
+// Time complexity: O(len(set1) * len(set2)).
+func CartesianProduct[E1, E2 comparable](dst sets.Mutable[sets.Pair[E1, E2]], set1 sets.ReadOnly[E1], set2 sets.ReadOnly[E2]) {
+	for e1 := range set1.All() {
+		for e2 := range set2.All() {
+			dst.Insert(sets.Pair[E1, E2]{First: e1, Second: e2})
+		}
+	}
+}
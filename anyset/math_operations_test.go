@@ -0,0 +1,133 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package anyset
+
+import (
+	"testing"
+
+	"github.com/kkhmel/sets"
+)
+
+func newHashSet(vals ...int) *sets.HashSet[int] {
+	return sets.NewHashSet(func(e int) uint64 { return uint64(e) }, func(a, b int) bool { return a == b }, vals...)
+}
+
+func TestUnion(t *testing.T) {
+	tests := []struct {
+		name string
+		dst  sets.Set[int]
+		sets []sets.ReadOnly[int]
+		want sets.Set[int]
+	}{
+		{name: "no sets", dst: sets.From(9), want: sets.From(9)},
+		{name: "mixed implementations", dst: sets.New[int](0), sets: []sets.ReadOnly[int]{sets.Adapt(sets.From(1, 2)), newHashSet(2, 3)}, want: sets.From(1, 2, 3)},
+		{name: "keeps dst elements", dst: sets.From(9), sets: []sets.ReadOnly[int]{newHashSet(1)}, want: sets.From(1, 9)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Union(sets.Adapt(tt.dst), tt.sets...)
+			if !sets.Equal(tt.dst, tt.want) {
+				t.Errorf("\nwant: %v\ngot : %v", tt.want, tt.dst)
+			}
+		})
+	}
+}
+
+func TestIntersection(t *testing.T) {
+	tests := []struct {
+		name string
+		sets []sets.ReadOnly[int]
+		want sets.Set[int]
+	}{
+		{name: "no sets", want: sets.New[int](0)},
+		{name: "one set", sets: []sets.ReadOnly[int]{newHashSet(1, 2)}, want: sets.From(1, 2)},
+		{name: "two sets", sets: []sets.ReadOnly[int]{sets.Adapt(sets.From(1, 2, 3)), newHashSet(2, 3, 4)}, want: sets.From(2, 3)},
+		{name: "smallest last", sets: []sets.ReadOnly[int]{newHashSet(1, 2, 3, 4), sets.Adapt(sets.From(2, 3, 4)), newHashSet(3, 4)}, want: sets.From(3, 4)},
+		{name: "disjoint", sets: []sets.ReadOnly[int]{newHashSet(1), newHashSet(2)}, want: sets.New[int](0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := sets.New[int](0)
+			Intersection(sets.Adapt(got), tt.sets...)
+			if !sets.Equal(got, tt.want) {
+				t.Errorf("\nwant: %v\ngot : %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestDifference(t *testing.T) {
+	tests := []struct {
+		name        string
+		minuend     sets.ReadOnly[int]
+		subtrahends []sets.ReadOnly[int]
+		want        sets.Set[int]
+	}{
+		{name: "no subtrahends", minuend: newHashSet(1, 2), want: sets.From(1, 2)},
+		{name: "one subtrahend", minuend: newHashSet(1, 2, 3), subtrahends: []sets.ReadOnly[int]{sets.Adapt(sets.From(2))}, want: sets.From(1, 3)},
+		{name: "two subtrahends", minuend: newHashSet(1, 2, 3), subtrahends: []sets.ReadOnly[int]{newHashSet(2), newHashSet(3, 4)}, want: sets.From(1)},
+		{name: "empty minuend", minuend: newHashSet(), subtrahends: []sets.ReadOnly[int]{newHashSet(1)}, want: sets.New[int](0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := sets.New[int](0)
+			Difference(sets.Adapt(got), tt.minuend, tt.subtrahends...)
+			if !sets.Equal(got, tt.want) {
+				t.Errorf("\nwant: %v\ngot : %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestSymmetricDifference(t *testing.T) {
+	tests := []struct {
+		name string
+		sets []sets.Set[int]
+	}{
+		{name: "no sets"},
+		{name: "one set", sets: []sets.Set[int]{sets.From(1, 2)}},
+		{name: "two sets", sets: []sets.Set[int]{sets.From(1, 2, 3), sets.From(3, 4)}},
+		{name: "three sets", sets: []sets.Set[int]{sets.From(1, 2, 3, 4), sets.From(3, 4, 5, 6), sets.From(4, 6, 7)}},
+		{name: "four sets", sets: []sets.Set[int]{sets.From(1, 2), sets.From(1, 3), sets.From(1, 2), sets.From(1)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srcs := make([]sets.ReadOnly[int], 0, len(tt.sets))
+			for _, s := range tt.sets {
+				srcs = append(srcs, newHashSet(sets.ToSlice(s)...))
+			}
+			got := sets.New[int](0)
+			SymmetricDifference(sets.Adapt(got), srcs...)
+			if want := sets.SymmetricDifference(tt.sets...); !sets.Equal(got, want) {
+				t.Errorf("\nwant: %v\ngot : %v", want, got)
+			}
+		})
+	}
+}
+
+func TestCartesianProduct(t *testing.T) {
+	got := sets.New[sets.Pair[int, string]](0)
+	CartesianProduct(sets.Adapt(got), newHashSet(1, 2), sets.NewTrieSet("a", "b"))
+	want := sets.CartesianProduct(sets.From(1, 2), sets.From("a", "b"))
+	if !sets.Equal(got, want) {
+		t.Errorf("\nwant: %v\ngot : %v", want, got)
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package anyset
+
+import "github.com/kkhmel/sets"
+
+// ContainsAny reports whether any of the given elements are present in the set.
+// If v is empty, returns false.
+//
+// Time complexity: O(len(v)).
+func ContainsAny[E any](s sets.ReadOnly[E], v ...E) bool {
+	for _, e := range v {
+		if s.Contains(e) {
+			return true
+		}
+	}
+	return false
+}
+
+// ContainsAll reports whether all of the given elements are present in the set.
+// If v is empty, returns true.
+//
+// Time complexity: O(len(v)).
+func ContainsAll[E any](s sets.ReadOnly[E], v ...E) bool {
+	for _, e := range v {
+		if !s.Contains(e) {
+			return false
+		}
+	}
+	return true
+}
+
+// Some reports whether at least one element e of s satisfies f(e).
+//
+// Time complexity: O(len(s)).
+func Some[E any](s sets.ReadOnly[E], f func(E) bool) bool {
+	for e := range s.All() {
+		if f(e) {
+			return true
+		}
+	}
+	return false
+}
+
+// Every reports whether all elements e of s satisfy f(e).
+//
+// Time complexity: O(len(s)).
+func Every[E any](s sets.ReadOnly[E], f func(E) bool) bool {
+	for e := range s.All() {
+		if !f(e) {
+			return false
+		}
+	}
+	return true
+}
+
+// Equal reports whether two sets contain the same elements.
+//
+// Time complexity: O(len(s1)).
+func Equal[E any](s1, s2 sets.ReadOnly[E]) bool {
+	return s1.Len() == s2.Len() && Every(s1, s2.Contains)
+}
+
+// Overlaps reports whether s1 and s2 have any element in common.
+//
+// Time complexity: O(min(len(s1), len(s2))).
+func Overlaps[E any](s1, s2 sets.ReadOnly[E]) bool {
+	if s1.Len() > s2.Len() {
+		s1, s2 = s2, s1
+	}
+	return Some(s1, s2.Contains)
+}
+
+// Subset reports whether all elements of subset are also in superset.
+//
+// Time complexity: O(len(subset)).
+func Subset[E any](subset, superset sets.ReadOnly[E]) bool {
+	return subset.Len() <= superset.Len() && Every(subset, superset.Contains)
+}
+
+// ProperSubset reports whether subset is a proper subset of superset,
+// i.e. all elements of subset are in superset and the sets are not equal.
+//
+// Time complexity: O(len(subset)).
+func ProperSubset[E any](subset, superset sets.ReadOnly[E]) bool {
+	return subset.Len() < superset.Len() && Every(subset, superset.Contains)
+}
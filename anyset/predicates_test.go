@@ -0,0 +1,65 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package anyset
+
+import (
+	"testing"
+
+	"github.com/kkhmel/sets"
+)
+
+func TestPredicates(t *testing.T) {
+	s := newHashSet(1, 2, 3)
+	tests := []struct {
+		name string
+		got  bool
+		want bool
+	}{
+		{name: "contains any present", got: ContainsAny[int](s, 7, 3), want: true},
+		{name: "contains any absent", got: ContainsAny[int](s, 7, 8), want: false},
+		{name: "contains any none", got: ContainsAny[int](s), want: false},
+		{name: "contains all present", got: ContainsAll[int](s, 1, 3), want: true},
+		{name: "contains all partial", got: ContainsAll[int](s, 1, 4), want: false},
+		{name: "contains all none", got: ContainsAll[int](s), want: true},
+		{name: "some", got: Some[int](s, func(e int) bool { return e > 2 }), want: true},
+		{name: "some none", got: Some[int](s, func(e int) bool { return e > 3 }), want: false},
+		{name: "every", got: Every[int](s, func(e int) bool { return e > 0 }), want: true},
+		{name: "every not", got: Every[int](s, func(e int) bool { return e > 1 }), want: false},
+		{name: "equal", got: Equal[int](s, sets.Adapt(sets.From(3, 2, 1))), want: true},
+		{name: "equal different", got: Equal[int](s, sets.Adapt(sets.From(1, 2, 4))), want: false},
+		{name: "equal different size", got: Equal[int](s, sets.Adapt(sets.From(1, 2))), want: false},
+		{name: "overlaps", got: Overlaps[int](s, sets.Adapt(sets.From(3, 4, 5, 6))), want: true},
+		{name: "overlaps smaller second", got: Overlaps[int](s, sets.Adapt(sets.From(3))), want: true},
+		{name: "overlaps disjoint", got: Overlaps[int](s, sets.Adapt(sets.From(4, 5))), want: false},
+		{name: "subset", got: Subset[int](sets.Adapt(sets.From(1, 3)), s), want: true},
+		{name: "subset not", got: Subset[int](sets.Adapt(sets.From(1, 4)), s), want: false},
+		{name: "subset larger", got: Subset[int](sets.Adapt(sets.From(1, 2, 3, 4)), s), want: false},
+		{name: "proper subset", got: ProperSubset[int](sets.Adapt(sets.From(1, 3)), s), want: true},
+		{name: "proper subset equal", got: ProperSubset[int](sets.Adapt(sets.From(1, 2, 3)), s), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("\nwant: %v\ngot : %v", tt.want, tt.got)
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package benchmark
+
+import (
+	"hash/maphash"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/kkhmel/sets"
+)
+
+// The HashSet benchmarks mirror the corresponding Set benchmarks for ints
+// to quantify the overhead of user-defined hash and equality functions.
+
+var seed = maphash.MakeSeed()
+
+func newRandHashSet(size int) *sets.HashSet[int] {
+	return sets.NewHashSet(hashInt, equalInt, NewRandSlice(size)...)
+}
+
+func hashInt(e int) uint64 { return maphash.Comparable(seed, e) }
+
+func equalInt(a, b int) bool { return a == b }
+
+func BenchmarkHashSetInsert_member(b *testing.B) {
+	s := newRandHashSet(Size1K)
+	e := rand.Int()
+	s.Insert(e)
+	for b.Loop() {
+		s.Insert(e)
+	}
+}
+
+func BenchmarkHashSetInsert_nonMember(b *testing.B) {
+	s := sets.NewHashSet(hashInt, equalInt)
+	for i := 0; b.Loop(); i++ {
+		s.Insert(i)
+	}
+}
+
+func BenchmarkHashSetContains_member(b *testing.B) {
+	s := newRandHashSet(Size1K)
+	e := rand.Int()
+	s.Insert(e)
+	for b.Loop() {
+		s.Contains(e)
+	}
+}
+
+func BenchmarkHashSetContains_nonMember(b *testing.B) {
+	s := newRandHashSet(Size1K)
+	e := rand.Int()
+	s.Delete(e)
+	for b.Loop() {
+		s.Contains(e)
+	}
+}
+
+func BenchmarkHashSetDelete_member(b *testing.B) {
+	for b.Loop() {
+		b.StopTimer()
+		s := newRandHashSet(Size1K)
+		elements := s.ToSlice()
+		b.StartTimer()
+		for _, e := range elements {
+			s.Delete(e)
+		}
+	}
+}
+
+func BenchmarkHashSetDelete_nonMember(b *testing.B) {
+	s := newRandHashSet(Size1K)
+	e := rand.Int()
+	s.Delete(e)
+	for b.Loop() {
+		s.Delete(e)
+	}
+}
+
+func BenchmarkHashSetUnion_disjoint(b *testing.B) {
+	set1 := newRandHashSet(Size1K)
+	set2 := sets.NewHashSet(hashInt, equalInt)
+	for e := range set1.All() {
+		set2.Insert(-e)
+	}
+	for b.Loop() {
+		set1.Union(set2)
+	}
+}
+
+func BenchmarkHashSetIntersection_equal(b *testing.B) {
+	set1 := newRandHashSet(Size1K)
+	set2 := set1.Clone()
+	for b.Loop() {
+		set1.Intersection(set2)
+	}
+}
+
+func BenchmarkHashSetEqual_equal(b *testing.B) {
+	set1 := newRandHashSet(Size1K)
+	set2 := set1.Clone()
+	for b.Loop() {
+		set1.Equal(set2)
+	}
+}
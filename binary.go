@@ -0,0 +1,310 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sets
+
+import (
+	"encoding"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Limits on the data decoded by UnmarshalBinary, which guard against malicious input.
+const (
+	// MaxBinaryElements is the maximum number of elements of a decoded set.
+	MaxBinaryElements = 1 << 26
+	// MaxBinaryStringBytes is the maximum total length of the strings of a decoded StringSet.
+	MaxBinaryStringBytes = 1 << 28
+)
+
+var (
+	// ErrInvalidBinary is returned when decoding data that is not a binary encoding of a set of the right kind.
+	ErrInvalidBinary = errors.New("sets: invalid binary encoding")
+	// ErrBinaryTooLarge is returned when decoding a set that exceeds MaxBinaryElements or MaxBinaryStringBytes.
+	ErrBinaryTooLarge = errors.New("sets: binary encoding exceeds decoding limits")
+)
+
+// The binary encoding starts with a header of binaryMagic, the kind of elements and binaryVersion,
+// followed by the uvarint number of elements and the elements in ascending order:
+//   - integers as the uvarint first element and the uvarint differences between consecutive elements,
+//     with the sign bit of signed integers flipped so that they sort as unsigned ones;
+//   - strings with front coding: the uvarint length of the prefix shared with the previous string,
+//     the uvarint length of the rest and the rest.
+const (
+	binaryMagic   = 's'
+	binaryVersion = 1
+	kindSigned    = 'i'
+	kindUnsigned  = 'u'
+	kindString    = 's'
+	binaryHeader  = 3
+	signBit       = 1 << 63
+)
+
+// IntSet is a set of integers with a compact binary encoding, for storing sets e.g. in caches.
+// Since it has the same underlying type as Set, a Set can be converted to an IntSet to encode it and back:
+//
+//	data, err := sets.IntSet[uint64](s).MarshalBinary()
+//
+// A set of signed integers can be decoded into a set of any integer type that holds all of its elements.
+type IntSet[E Integer] map[E]struct{}
+
+// StringSet is a set of strings with a compact binary encoding, for storing sets e.g. in caches.
+// Since it has the same underlying type as Set[string], a Set[string] can be converted to a StringSet
+// to encode it and back.
+type StringSet map[string]struct{}
+
+var (
+	_ encoding.BinaryMarshaler   = IntSet[int]{}
+	_ encoding.BinaryUnmarshaler = (*IntSet[int])(nil)
+	_ encoding.BinaryMarshaler   = StringSet{}
+	_ encoding.BinaryUnmarshaler = (*StringSet)(nil)
+)
+
+// MarshalBinary encodes s as sorted, delta-encoded varints.
+//
+// Time complexity: O(len(s) * log(len(s))). Space complexity: O(len(s)).
+func (s IntSet[E]) MarshalBinary() ([]byte, error) {
+	signed := isSigned[E]()
+	vals := make([]uint64, 0, len(s))
+	for e := range s {
+		if signed {
+			vals = append(vals, uint64(int64(e))^signBit)
+		} else {
+			vals = append(vals, uint64(e))
+		}
+	}
+	slices.Sort(vals)
+
+	kind := byte(kindUnsigned)
+	if signed {
+		kind = kindSigned
+	}
+	data := make([]byte, 0, binaryHeader+binary.MaxVarintLen64+len(vals))
+	data = append(data, binaryMagic, kind, binaryVersion)
+	data = binary.AppendUvarint(data, uint64(len(vals)))
+	prev := uint64(0)
+	for _, v := range vals {
+		data = binary.AppendUvarint(data, v-prev)
+		prev = v
+	}
+	return data, nil
+}
+
+// UnmarshalBinary replaces the elements of s with the set encoded in data by MarshalBinary.
+// It returns ErrInvalidBinary if data is not a valid encoding of an integer set or an element
+// does not fit in E, and ErrBinaryTooLarge if the set has more than MaxBinaryElements elements.
+//
+// Time complexity: O(len(data)). Space complexity: O(len(data)).
+func (s *IntSet[E]) UnmarshalBinary(data []byte) error {
+	d := binaryDecoder{data: data}
+	kind := d.header(kindSigned, kindUnsigned)
+	n := d.count()
+
+	r := make(IntSet[E], n)
+	v := uint64(0)
+	for i := range n {
+		delta := d.uvarint()
+		if d.err != nil {
+			return d.err
+		}
+		if i > 0 && delta == 0 || v+delta < v {
+			return fmt.Errorf("%w: elements are not sorted", ErrInvalidBinary)
+		}
+		v += delta
+
+		var (
+			e  E
+			ok bool
+		)
+		if kind == kindSigned {
+			e, ok = fromInt64[E](int64(v ^ signBit))
+		} else {
+			e, ok = fromUint64[E](v)
+		}
+		if !ok {
+			return fmt.Errorf("%w: element does not fit in %T", ErrInvalidBinary, e)
+		}
+		r[e] = struct{}{}
+	}
+	if err := d.end(); err != nil {
+		return err
+	}
+	*s = r
+	return nil
+}
+
+// MarshalBinary encodes s as sorted, front-coded strings.
+//
+// Time complexity: O(L * log(len(s))). Space complexity: O(L). L is the total length of the strings.
+func (s StringSet) MarshalBinary() ([]byte, error) {
+	vals := make([]string, 0, len(s))
+	size := binaryHeader + binary.MaxVarintLen64
+	for e := range s {
+		vals = append(vals, e)
+		size += 2 + len(e)
+	}
+	slices.Sort(vals)
+
+	data := make([]byte, 0, size)
+	data = append(data, binaryMagic, kindString, binaryVersion)
+	data = binary.AppendUvarint(data, uint64(len(vals)))
+	prev := ""
+	for _, v := range vals {
+		shared := 0
+		for shared < len(prev) && shared < len(v) && prev[shared] == v[shared] {
+			shared++
+		}
+		data = binary.AppendUvarint(data, uint64(shared))
+		data = binary.AppendUvarint(data, uint64(len(v)-shared))
+		data = append(data, v[shared:]...)
+		prev = v
+	}
+	return data, nil
+}
+
+// UnmarshalBinary replaces the elements of s with the set encoded in data by MarshalBinary.
+// It returns ErrInvalidBinary if data is not a valid encoding of a string set, and ErrBinaryTooLarge
+// if the set has more than MaxBinaryElements elements or MaxBinaryStringBytes bytes in total.
+//
+// Time complexity: O(L). Space complexity: O(L). L is the total length of the strings.
+func (s *StringSet) UnmarshalBinary(data []byte) error {
+	return s.decode(data, MaxBinaryStringBytes)
+}
+
+// decode implements UnmarshalBinary with a limit of maxBytes on the total length of the strings.
+func (s *StringSet) decode(data []byte, maxBytes uint64) error {
+	d := binaryDecoder{data: data}
+	d.header(kindString)
+	n := d.count()
+
+	r := make(StringSet, n)
+	var (
+		prev  string
+		total uint64
+	)
+	for i := range n {
+		shared, rest := d.uvarint(), d.uvarint()
+		if d.err != nil {
+			return d.err
+		}
+		if shared > uint64(len(prev)) || rest > uint64(len(d.data)) {
+			return fmt.Errorf("%w: invalid string length", ErrInvalidBinary)
+		}
+		if total += shared + rest; total > maxBytes {
+			return ErrBinaryTooLarge
+		}
+		var b strings.Builder
+		b.Grow(int(shared + rest))
+		b.WriteString(prev[:shared])
+		b.Write(d.data[:rest])
+		d.data = d.data[rest:]
+
+		e := b.String()
+		if i > 0 && e <= prev {
+			return fmt.Errorf("%w: elements are not sorted", ErrInvalidBinary)
+		}
+		r[e] = struct{}{}
+		prev = e
+	}
+	if err := d.end(); err != nil {
+		return err
+	}
+	*s = r
+	return nil
+}
+
+// isSigned reports whether E is a signed integer type.
+func isSigned[E Integer]() bool {
+	return ^E(0) < 0
+}
+
+// fromInt64 converts v to E and reports whether E holds v.
+func fromInt64[E Integer](v int64) (E, bool) {
+	e := E(v)
+	return e, int64(e) == v && (e < 0) == (v < 0)
+}
+
+// fromUint64 converts v to E and reports whether E holds v.
+func fromUint64[E Integer](v uint64) (E, bool) {
+	e := E(v)
+	return e, uint64(e) == v && e >= 0
+}
+
+// binaryDecoder reads the binary encoding of a set. Once reading fails, it keeps the first error
+// and all further reads return zero values.
+type binaryDecoder struct {
+	data []byte
+	err  error
+}
+
+// header reads the header and returns the kind of elements, which must be one of kinds.
+func (d *binaryDecoder) header(kinds ...byte) byte {
+	if len(d.data) < binaryHeader || d.data[0] != binaryMagic || !slices.Contains(kinds, d.data[1]) {
+		d.err = fmt.Errorf("%w: unexpected header", ErrInvalidBinary)
+		return 0
+	}
+	if d.data[2] != binaryVersion {
+		d.err = fmt.Errorf("%w: unsupported version %d", ErrInvalidBinary, d.data[2])
+		return 0
+	}
+	kind := d.data[1]
+	d.data = d.data[binaryHeader:]
+	return kind
+}
+
+// count reads the number of elements, which takes at least one byte each.
+func (d *binaryDecoder) count() int {
+	n := d.uvarint()
+	switch {
+	case d.err != nil:
+		return 0
+	case n > MaxBinaryElements:
+		d.err = ErrBinaryTooLarge
+		return 0
+	case n > uint64(len(d.data)):
+		d.err = fmt.Errorf("%w: too few elements", ErrInvalidBinary)
+		return 0
+	}
+	return int(n)
+}
+
+func (d *binaryDecoder) uvarint() uint64 {
+	if d.err != nil {
+		return 0
+	}
+	v, n := binary.Uvarint(d.data)
+	if n <= 0 {
+		d.err = fmt.Errorf("%w: invalid varint", ErrInvalidBinary)
+		return 0
+	}
+	d.data = d.data[n:]
+	return v
+}
+
+// end returns the error of the decoder, if any, or an error if there is data left.
+func (d *binaryDecoder) end() error {
+	if d.err == nil && len(d.data) > 0 {
+		d.err = fmt.Errorf("%w: unexpected data after the elements", ErrInvalidBinary)
+	}
+	return d.err
+}
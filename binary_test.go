@@ -0,0 +1,250 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sets
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestIntSetMarshalBinary(t *testing.T) {
+	tests := []struct {
+		name string
+		got  func() ([]byte, error)
+		want string
+	}{
+		{"nil", IntSet[uint64](nil).MarshalBinary, "su\x01\x00"},
+		{"unsigned", IntSet[uint64]{300: {}, 1: {}, 5: {}}.MarshalBinary, "su\x01\x03\x01\x04\xa7\x02"},
+		{"signed", IntSet[int8]{-1: {}, 0: {}, 2: {}}.MarshalBinary, "si\x01\x03\xff\xff\xff\xff\xff\xff\xff\xff\x7f\x01\x02"},
+	}
+	for _, tt := range tests {
+		got, err := tt.got()
+		if err != nil || string(got) != tt.want {
+			t.Errorf("%s\nwant: %q\ngot : %q, %v", tt.name, tt.want, got, err)
+		}
+	}
+}
+
+// binaryRoundTrip encodes s and decodes the result into a set that already has elements.
+func binaryRoundTrip[S interface {
+	~map[E]struct{}
+	MarshalBinary() ([]byte, error)
+}, P interface {
+	*S
+	UnmarshalBinary(data []byte) error
+}, E comparable](t *testing.T, s S, stale E,
+) {
+	t.Helper()
+	data, err := s.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := S{stale: {}}
+	if err := P(&got).UnmarshalBinary(data); err != nil || !Equal(got, s) {
+		t.Errorf("\nwant: %v\ngot : %v, %v", Set[E](s), Set[E](got), err)
+	}
+}
+
+func TestIntSetBinaryRoundTrip(t *testing.T) {
+	binaryRoundTrip(t, IntSet[int]{}, 1)
+	binaryRoundTrip(t, IntSet[int]{0: {}}, 1)
+	binaryRoundTrip(t, IntSet[int64]{math.MinInt64: {}, -1: {}, 0: {}, 1: {}, math.MaxInt64: {}}, 2)
+	binaryRoundTrip(t, IntSet[uint64]{0: {}, 1 << 63: {}, math.MaxUint64: {}}, 2)
+	binaryRoundTrip(t, IntSet[int8]{math.MinInt8: {}, math.MaxInt8: {}}, 0)
+	binaryRoundTrip(t, IntSet[uintptr]{7: {}}, 0)
+
+	large := IntSet[uint32]{}
+	for i := range uint32(10000) {
+		large[i*i] = struct{}{}
+	}
+	binaryRoundTrip(t, large, 2)
+}
+
+func TestIntSetBinaryConversion(t *testing.T) {
+	signed, _ := IntSet[int64]{-1: {}, 100: {}}.MarshalBinary()
+	positive, _ := IntSet[int64]{0: {}, 300: {}}.MarshalBinary()
+	unsigned, _ := IntSet[uint64]{5: {}, 1 << 63: {}}.MarshalBinary()
+	small, _ := IntSet[uint64]{5: {}, 200: {}}.MarshalBinary()
+
+	var i8 IntSet[int8]
+	if err := i8.UnmarshalBinary(signed); err != nil || !Equal(i8, IntSet[int8]{-1: {}, 100: {}}) {
+		t.Errorf("int64 to int8: %v, %v", i8, err)
+	}
+	var u16 IntSet[uint16]
+	if err := u16.UnmarshalBinary(positive); err != nil || !Equal(u16, IntSet[uint16]{0: {}, 300: {}}) {
+		t.Errorf("int64 to uint16: %v, %v", u16, err)
+	}
+	var u8 IntSet[uint8]
+	if err := u8.UnmarshalBinary(small); err != nil || !Equal(u8, IntSet[uint8]{5: {}, 200: {}}) {
+		t.Errorf("uint64 to uint8: %v, %v", u8, err)
+	}
+
+	// Elements that do not fit leave the set unchanged.
+	for name, err := range map[string]error{
+		"negative to unsigned": new(IntSet[uint64]).UnmarshalBinary(signed),
+		"int64 to int8":        new(IntSet[int8]).UnmarshalBinary(positive),
+		"uint64 to int64":      new(IntSet[int64]).UnmarshalBinary(unsigned),
+		"uint64 to int8":       i8.UnmarshalBinary(small),
+	} {
+		if !errors.Is(err, ErrInvalidBinary) {
+			t.Errorf("%s:\nwant: %v\ngot : %v", name, ErrInvalidBinary, err)
+		}
+	}
+	if !Equal(i8, IntSet[int8]{-1: {}, 100: {}}) {
+		t.Errorf("a failed UnmarshalBinary() modified the set: %v", i8)
+	}
+}
+
+func TestIntSetUnmarshalBinaryInvalid(t *testing.T) {
+	tests := map[string]struct {
+		data string
+		want error
+	}{
+		"empty":          {"", ErrInvalidBinary},
+		"short header":   {"su", ErrInvalidBinary},
+		"magic":          {"xu\x01\x00", ErrInvalidBinary},
+		"string set":     {"ss\x01\x00", ErrInvalidBinary},
+		"version":        {"su\x02\x00", ErrInvalidBinary},
+		"no count":       {"su\x01", ErrInvalidBinary},
+		"count":          {"su\x01\x03\x01\x01", ErrInvalidBinary},
+		"too many":       {string(binary.AppendUvarint([]byte("su\x01"), MaxBinaryElements+1)), ErrBinaryTooLarge},
+		"varint":         {"su\x01\x01\xff", ErrInvalidBinary},
+		"duplicate":      {"su\x01\x02\x01\x00", ErrInvalidBinary},
+		"overflow":       {"su\x01\x02\xff\xff\xff\xff\xff\xff\xff\xff\xff\x01\x01", ErrInvalidBinary},
+		"trailing bytes": {"su\x01\x01\x01\x00", ErrInvalidBinary},
+	}
+	for name, tt := range tests {
+		s := IntSet[uint64]{1: {}}
+		if err := s.UnmarshalBinary([]byte(tt.data)); !errors.Is(err, tt.want) {
+			t.Errorf("%s:\nwant: %v\ngot : %v", name, tt.want, err)
+		}
+		if !Equal(s, IntSet[uint64]{1: {}}) {
+			t.Errorf("%s: a failed UnmarshalBinary() modified the set: %v", name, s)
+		}
+	}
+}
+
+func TestStringSetMarshalBinary(t *testing.T) {
+	got, err := StringSet{"banana": {}, "apricot": {}, "apple": {}, "": {}}.MarshalBinary()
+	want := "ss\x01\x04\x00\x00\x00\x05apple\x02\x05ricot\x00\x06banana"
+	if err != nil || string(got) != want {
+		t.Errorf("\nwant: %q\ngot : %q, %v", want, got, err)
+	}
+}
+
+func TestStringSetBinaryRoundTrip(t *testing.T) {
+	binaryRoundTrip(t, StringSet{}, "a")
+	binaryRoundTrip(t, StringSet{"": {}}, "a")
+	binaryRoundTrip(t, StringSet{"a": {}, "ab": {}, "abc": {}, "b": {}, "\xff": {}, "日本": {}}, "x")
+	binaryRoundTrip(t, StringSet{strings.Repeat("x", 1000): {}, strings.Repeat("x", 999) + "y": {}}, "x")
+
+	large := StringSet{}
+	for i := range 10000 {
+		large[strings.Repeat("k", i%100)+string(rune(i))] = struct{}{}
+	}
+	binaryRoundTrip(t, large, "x")
+
+	// A Set[string] converts to a StringSet and back.
+	s := From("a", "b")
+	data, _ := StringSet(s).MarshalBinary()
+	var got StringSet
+	if err := got.UnmarshalBinary(data); err != nil || !Equal(Set[string](got), s) {
+		t.Errorf("\nwant: %v\ngot : %v, %v", s, got, err)
+	}
+}
+
+func TestStringSetUnmarshalBinaryInvalid(t *testing.T) {
+	tests := map[string]struct {
+		data string
+		want error
+	}{
+		"empty":          {"", ErrInvalidBinary},
+		"integer set":    {"su\x01\x00", ErrInvalidBinary},
+		"version":        {"ss\x00\x00", ErrInvalidBinary},
+		"count":          {"ss\x01\x02\x00\x00", ErrInvalidBinary},
+		"too many":       {string(binary.AppendUvarint([]byte("ss\x01"), MaxBinaryElements+1)), ErrBinaryTooLarge},
+		"no length":      {"ss\x01\x01\x00", ErrInvalidBinary},
+		"shared prefix":  {"ss\x01\x01\x01\x00", ErrInvalidBinary},
+		"long suffix":    {"ss\x01\x01\x00\x05abc", ErrInvalidBinary},
+		"unsorted":       {"ss\x01\x02\x00\x01b\x00\x01a", ErrInvalidBinary},
+		"duplicate":      {"ss\x01\x02\x00\x01a\x01\x00", ErrInvalidBinary},
+		"trailing bytes": {"ss\x01\x01\x00\x01a\x00", ErrInvalidBinary},
+	}
+	for name, tt := range tests {
+		s := StringSet{"x": {}}
+		if err := s.UnmarshalBinary([]byte(tt.data)); !errors.Is(err, tt.want) {
+			t.Errorf("%s:\nwant: %v\ngot : %v", name, tt.want, err)
+		}
+		if !Equal(s, StringSet{"x": {}}) {
+			t.Errorf("%s: a failed UnmarshalBinary() modified the set: %v", name, s)
+		}
+	}
+
+	// Shared prefixes count towards the limit on the total length of the strings.
+	data := "ss\x01\x03\x00\x04abcd\x04\x01e\x04\x01f"
+	var s StringSet
+	if err := s.decode([]byte(data), 14); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.decode([]byte(data), 13); !errors.Is(err, ErrBinaryTooLarge) {
+		t.Errorf("\nwant: %v\ngot : %v", ErrBinaryTooLarge, err)
+	}
+}
+
+func FuzzIntSetBinary(f *testing.F) {
+	f.Add([]byte{})
+	f.Add([]byte{0, 1, 2, 3, 255, 254, 128, 127})
+	f.Add([]byte("si\x01\x03\xff\xff\xff\xff\xff\xff\xff\xff\x7f\x01\x02"))
+	f.Add([]byte("su\x01\x03\x01\x04\xa7\x02"))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		// Pairs of bytes are elements of a set, which must survive a round trip.
+		s := IntSet[int16]{}
+		for i := 0; i+1 < len(data); i += 2 {
+			s[int16(binary.LittleEndian.Uint16(data[i:]))] = struct{}{}
+		}
+		binaryRoundTrip(t, s, 1)
+
+		// Data that decodes must encode to data that decodes to the same set.
+		var decoded IntSet[int16]
+		if decoded.UnmarshalBinary(data) == nil {
+			binaryRoundTrip(t, decoded, 1)
+		}
+	})
+}
+
+func FuzzStringSetBinary(f *testing.F) {
+	f.Add([]byte{})
+	f.Add([]byte("apple\x00apricot\x00banana\x00\x00"))
+	f.Add([]byte("ss\x01\x04\x00\x00\x00\x05apple\x02\x05ricot\x00\x06banana"))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		// Tokens separated by zero bytes are elements of a set, which must survive a round trip.
+		binaryRoundTrip(t, StringSet(FromSlice(strings.Split(string(data), "\x00"))), "x")
+
+		// Data that decodes must encode to data that decodes to the same set.
+		var decoded StringSet
+		if decoded.UnmarshalBinary(data) == nil {
+			binaryRoundTrip(t, decoded, "x")
+		}
+	})
+}
@@ -0,0 +1,355 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sets
+
+import (
+	"container/heap"
+	"container/list"
+	"iter"
+	"sync"
+)
+
+// EvictionPolicy decides which element a Bounded set evicts when it is full.
+// Bounded serializes all calls, so implementations need not be safe for concurrent use.
+type EvictionPolicy[E comparable] interface {
+	// Insert records that e was added to the set.
+	Insert(e E)
+	// Touch records that e, which is present in the set, was accessed.
+	Touch(e E)
+	// Delete records that e was removed from the set.
+	Delete(e E)
+	// Victim returns the element to evict next. It is only called when the set is not empty.
+	Victim() E
+}
+
+// BoundedStats holds lookup and eviction counters of a Bounded set.
+type BoundedStats struct {
+	Hits      uint64 // Number of Contains calls that found the element.
+	Misses    uint64 // Number of Contains calls that did not find the element.
+	Evictions uint64 // Number of elements evicted to respect the capacity.
+}
+
+// Bounded is a set holding at most a fixed number of elements.
+// Inserting a new element into a full set evicts the element chosen by its EvictionPolicy.
+// Bounded is safe for concurrent use.
+type Bounded[E comparable] struct {
+	mu       sync.Mutex
+	s        Set[E]
+	capacity int
+	policy   EvictionPolicy[E]
+	onEvict  func(E)
+	stats    BoundedStats
+}
+
+// NewBounded creates a new empty Bounded set holding at most capacity elements.
+// If policy is nil, the least recently used element is evicted (see NewLRU).
+// The policy must be empty and must not be shared with other sets.
+// NewBounded panics if capacity is less than 1.
+//
+// Time complexity: O(1). Space complexity: O(1).
+func NewBounded[E comparable](capacity int, policy EvictionPolicy[E]) *Bounded[E] {
+	if capacity < 1 {
+		panic("cannot be less than 1")
+	}
+	if policy == nil {
+		policy = NewLRU[E]()
+	}
+	return &Bounded[E]{
+		s:        New[E](0),
+		capacity: capacity,
+		policy:   policy,
+	}
+}
+
+// OnEvict registers f to be called with every element evicted to respect the capacity.
+// Elements removed by Delete are not reported. f replaces any previously registered callback;
+// it is invoked without holding internal locks, so it may access the set.
+//
+// Time complexity: O(1). Space complexity: O(1).
+func (b *Bounded[E]) OnEvict(f func(E)) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.onEvict = f
+}
+
+// Insert inserts the given elements into the set, evicting other elements if the capacity is exceeded.
+// Inserting an element that is already present counts as an access.
+// Victims are chosen among the elements present before the call; only if v holds more new elements
+// than the capacity are some of them evicted as well.
+//
+// Time complexity: O(len(v)) policy operations. Space complexity: O(len(v)).
+func (b *Bounded[E]) Insert(v ...E) {
+	b.mu.Lock()
+	var added []E
+	seen := New[E](0)
+	for _, e := range v {
+		if _, ok := b.s[e]; ok {
+			b.policy.Touch(e)
+		} else if !Contains(seen, e) {
+			seen[e] = struct{}{}
+			added = append(added, e)
+		}
+	}
+	// Make room before adding, so that the new elements, which a policy such as LFU
+	// ranks lowest, are not chosen as victims.
+	evicted := b.shrink(max(b.capacity-len(added), 0), nil)
+	for _, e := range added {
+		b.s[e] = struct{}{}
+		b.policy.Insert(e)
+	}
+	b.unlock(b.shrink(b.capacity, evicted))
+}
+
+// Delete deletes the specified elements from the set.
+// Elements not present in the set are ignored.
+//
+// Time complexity: O(len(v)) policy operations. Space complexity: O(1).
+func (b *Bounded[E]) Delete(v ...E) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, e := range v {
+		if _, ok := b.s[e]; ok {
+			delete(b.s, e)
+			b.policy.Delete(e)
+		}
+	}
+}
+
+// Contains reports whether v is present in the set.
+// A successful lookup counts as an access. Both outcomes are recorded in the statistics.
+//
+// Time complexity: O(1) policy operations. Space complexity: O(1).
+func (b *Bounded[E]) Contains(v E) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.s[v]; !ok {
+		b.stats.Misses++
+		return false
+	}
+	b.stats.Hits++
+	b.policy.Touch(v)
+	return true
+}
+
+// Len returns the number of elements in the set.
+//
+// Time complexity: O(1). Space complexity: O(1).
+func (b *Bounded[E]) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.s)
+}
+
+// Cap returns the maximum number of elements the set can hold.
+//
+// Time complexity: O(1). Space complexity: O(1).
+func (b *Bounded[E]) Cap() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.capacity
+}
+
+// Resize changes the capacity of the set, evicting elements if the set holds more than capacity elements.
+// Resize panics if capacity is less than 1.
+//
+// Time complexity: O(k) policy operations. Space complexity: O(k). k is the number of evicted elements.
+func (b *Bounded[E]) Resize(capacity int) {
+	if capacity < 1 {
+		panic("cannot be less than 1")
+	}
+	b.mu.Lock()
+	b.capacity = capacity
+	b.unlock(b.shrink(capacity, nil))
+}
+
+// Stats returns the lookup and eviction counters accumulated since the set was created.
+//
+// Time complexity: O(1). Space complexity: O(1).
+func (b *Bounded[E]) Stats() BoundedStats {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.stats
+}
+
+// Clone returns a snapshot of the current elements as a new Set.
+// It does not count as an access.
+//
+// Time complexity: O(len(b)). Space complexity: O(len(b)).
+func (b *Bounded[E]) Clone() Set[E] {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return Union(b.s)
+}
+
+// All returns an iterator over a snapshot of the elements of the set.
+// The iteration order is not specified. Iteration does not count as an access.
+//
+// Creation: O(1) time, O(1) space.
+// Iteration: O(len(b)) time, O(len(b)) space.
+func (b *Bounded[E]) All() iter.Seq[E] {
+	return func(yield func(E) bool) {
+		b.mu.Lock()
+		elements := ToSlice(b.s)
+		b.mu.Unlock()
+		for _, e := range elements {
+			if !yield(e) {
+				return
+			}
+		}
+	}
+}
+
+// shrink evicts the elements chosen by the policy until the set holds at most n elements,
+// and returns evicted with them appended. It must be called with b.mu held.
+func (b *Bounded[E]) shrink(n int, evicted []E) []E {
+	for len(b.s) > n {
+		e := b.policy.Victim()
+		delete(b.s, e)
+		b.policy.Delete(e)
+		b.stats.Evictions++
+		evicted = append(evicted, e)
+	}
+	return evicted
+}
+
+// unlock releases b.mu and then reports the evicted elements to the eviction callback.
+func (b *Bounded[E]) unlock(evicted []E) {
+	onEvict := b.onEvict
+	b.mu.Unlock()
+
+	if onEvict != nil {
+		for _, e := range evicted {
+			onEvict(e)
+		}
+	}
+}
+
+// lru evicts the least recently inserted or accessed element.
+type lru[E comparable] struct {
+	order    *list.List // front is the most recently used element
+	elements map[E]*list.Element
+}
+
+// NewLRU returns an EvictionPolicy that evicts the least recently used element.
+//
+// Time complexity: O(1) for every operation.
+func NewLRU[E comparable]() EvictionPolicy[E] {
+	return &lru[E]{
+		order:    list.New(),
+		elements: make(map[E]*list.Element),
+	}
+}
+
+func (p *lru[E]) Insert(e E) {
+	p.elements[e] = p.order.PushFront(e)
+}
+
+func (p *lru[E]) Touch(e E) {
+	p.order.MoveToFront(p.elements[e])
+}
+
+func (p *lru[E]) Delete(e E) {
+	p.order.Remove(p.elements[e])
+	delete(p.elements, e)
+}
+
+func (p *lru[E]) Victim() E {
+	return p.order.Back().Value.(E)
+}
+
+// lfu evicts the least frequently accessed element, breaking ties by recency.
+type lfu[E comparable] struct {
+	queue    lfuQueue[E]
+	elements map[E]*lfuEntry[E]
+	clock    uint64
+}
+
+type lfuEntry[E comparable] struct {
+	e     E
+	freq  uint64
+	last  uint64 // logical time of the last access
+	index int    // position in the heap
+}
+
+// NewLFU returns an EvictionPolicy that evicts the least frequently used element.
+// Among elements with equal frequency, the least recently used one is evicted.
+//
+// Time complexity: O(log(n)) for every operation. n is the number of elements.
+func NewLFU[E comparable]() EvictionPolicy[E] {
+	return &lfu[E]{elements: make(map[E]*lfuEntry[E])}
+}
+
+func (p *lfu[E]) Insert(e E) {
+	p.clock++
+	entry := &lfuEntry[E]{e: e, freq: 1, last: p.clock}
+	p.elements[e] = entry
+	heap.Push(&p.queue, entry)
+}
+
+func (p *lfu[E]) Touch(e E) {
+	p.clock++
+	entry := p.elements[e]
+	entry.freq++
+	entry.last = p.clock
+	heap.Fix(&p.queue, entry.index)
+}
+
+func (p *lfu[E]) Delete(e E) {
+	heap.Remove(&p.queue, p.elements[e].index)
+	delete(p.elements, e)
+}
+
+func (p *lfu[E]) Victim() E {
+	return p.queue[0].e
+}
+
+// lfuQueue is a min-heap of entries ordered by frequency and then by recency.
+type lfuQueue[E comparable] []*lfuEntry[E]
+
+func (q lfuQueue[E]) Len() int { return len(q) }
+
+func (q lfuQueue[E]) Less(i, j int) bool {
+	if q[i].freq != q[j].freq {
+		return q[i].freq < q[j].freq
+	}
+	return q[i].last < q[j].last
+}
+
+func (q lfuQueue[E]) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *lfuQueue[E]) Push(x any) {
+	entry := x.(*lfuEntry[E])
+	entry.index = len(*q)
+	*q = append(*q, entry)
+}
+
+func (q *lfuQueue[E]) Pop() any {
+	old := *q
+	n := len(old)
+	entry := old[n-1]
+	old[n-1] = nil
+	*q = old[:n-1]
+	return entry
+}
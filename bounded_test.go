@@ -0,0 +1,286 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sets
+
+import (
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestBoundedEviction(t *testing.T) {
+	tests := []struct {
+		name        string
+		policy      func() EvictionPolicy[int]
+		ops         func(b *Bounded[int])
+		want        Set[int]
+		wantEvicted []int
+	}{
+		{
+			name:   "lru evicts oldest",
+			policy: NewLRU[int],
+			ops: func(b *Bounded[int]) {
+				b.Insert(1, 2, 3)
+				b.Insert(4)
+			},
+			want:        From(2, 3, 4),
+			wantEvicted: []int{1},
+		},
+		{
+			name:   "lru contains refreshes recency",
+			policy: NewLRU[int],
+			ops: func(b *Bounded[int]) {
+				b.Insert(1, 2, 3)
+				b.Contains(1)
+				b.Insert(4, 5)
+			},
+			want:        From(1, 4, 5),
+			wantEvicted: []int{2, 3},
+		},
+		{
+			name:   "lru reinsert refreshes recency",
+			policy: NewLRU[int],
+			ops: func(b *Bounded[int]) {
+				b.Insert(1, 2, 3)
+				b.Insert(1)
+				b.Insert(4)
+			},
+			want:        From(1, 3, 4),
+			wantEvicted: []int{2},
+		},
+		{
+			name:   "lru delete frees space",
+			policy: NewLRU[int],
+			ops: func(b *Bounded[int]) {
+				b.Insert(1, 2, 3)
+				b.Delete(2, 7)
+				b.Insert(4)
+			},
+			want:        From(1, 3, 4),
+			wantEvicted: nil,
+		},
+		{
+			name:   "lfu evicts least frequent",
+			policy: NewLFU[int],
+			ops: func(b *Bounded[int]) {
+				b.Insert(1, 2, 3)
+				b.Contains(1)
+				b.Contains(1)
+				b.Contains(3)
+				b.Insert(4)
+			},
+			want:        From(1, 3, 4),
+			wantEvicted: []int{2},
+		},
+		{
+			name:   "lfu breaks ties by recency",
+			policy: NewLFU[int],
+			ops: func(b *Bounded[int]) {
+				b.Insert(1, 2, 3)
+				b.Contains(2)
+				b.Contains(1)
+				b.Insert(4)
+				b.Insert(5)
+			},
+			want:        From(1, 2, 5),
+			wantEvicted: []int{3, 4},
+		},
+		{
+			name:   "lfu delete frees space",
+			policy: NewLFU[int],
+			ops: func(b *Bounded[int]) {
+				b.Insert(1, 2, 3)
+				b.Delete(1)
+				b.Insert(4, 4)
+			},
+			want:        From(2, 3, 4),
+			wantEvicted: nil,
+		},
+		{
+			name:   "lfu full and warm keeps new element",
+			policy: NewLFU[int],
+			ops: func(b *Bounded[int]) {
+				b.Insert(1, 2, 3)
+				b.Contains(1)
+				b.Contains(2)
+				b.Contains(3)
+				b.Insert(4)
+				b.Contains(4)
+				b.Insert(5, 6)
+			},
+			want:        From(4, 5, 6),
+			wantEvicted: []int{1, 2, 3},
+		},
+		{
+			name:   "lfu batch larger than capacity",
+			policy: NewLFU[int],
+			ops: func(b *Bounded[int]) {
+				b.Insert(1, 2, 3)
+				b.Contains(1)
+				b.Insert(4, 5, 6, 7, 4)
+			},
+			want:        From(5, 6, 7),
+			wantEvicted: []int{2, 3, 1, 4},
+		},
+		{
+			name:   "default policy is lru",
+			policy: func() EvictionPolicy[int] { return nil },
+			ops: func(b *Bounded[int]) {
+				b.Insert(1, 2, 3, 4, 5)
+			},
+			want:        From(3, 4, 5),
+			wantEvicted: []int{1, 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewBounded(3, tt.policy())
+			var evicted []int
+			b.OnEvict(func(e int) { evicted = append(evicted, e) })
+			tt.ops(b)
+
+			if got := b.Clone(); !Equal(got, tt.want) {
+				t.Errorf("\nwant: %v\ngot : %v", tt.want, got)
+			}
+			if !slices.Equal(evicted, tt.wantEvicted) {
+				t.Errorf("\nwant evicted: %v\ngot evicted : %v", tt.wantEvicted, evicted)
+			}
+		})
+	}
+}
+
+func TestBoundedStats(t *testing.T) {
+	b := NewBounded[string](2, nil)
+	b.Insert("a", "b", "c")
+	b.Contains("a")
+	b.Contains("b")
+	b.Contains("c")
+	b.Contains("d")
+
+	want := BoundedStats{Hits: 2, Misses: 2, Evictions: 1}
+	if got := b.Stats(); got != want {
+		t.Errorf("\nwant: %+v\ngot : %+v", want, got)
+	}
+}
+
+func TestBoundedResize(t *testing.T) {
+	b := NewBounded(4, NewLRU[int]())
+	b.Insert(1, 2, 3, 4)
+	var evicted []int
+	b.OnEvict(func(e int) { evicted = append(evicted, e) })
+
+	b.Resize(2)
+	if got := b.Cap(); got != 2 {
+		t.Errorf("\nwant: %v\ngot : %v", 2, got)
+	}
+	if got := b.Len(); got != 2 {
+		t.Errorf("\nwant: %v\ngot : %v", 2, got)
+	}
+	if want := []int{1, 2}; !slices.Equal(evicted, want) {
+		t.Errorf("\nwant: %v\ngot : %v", want, evicted)
+	}
+
+	b.Resize(3)
+	b.Insert(5)
+	if got, want := b.Clone(), From(3, 4, 5); !Equal(got, want) {
+		t.Errorf("\nwant: %v\ngot : %v", want, got)
+	}
+}
+
+func TestBoundedAll(t *testing.T) {
+	b := NewBounded[int](3, nil)
+	b.Insert(1, 2, 3)
+
+	got := slices.Sorted(b.All())
+	if want := []int{1, 2, 3}; !slices.Equal(got, want) {
+		t.Errorf("\nwant: %v\ngot : %v", want, got)
+	}
+
+	count := 0
+	for range b.All() {
+		count++
+		break
+	}
+	if count != 1 {
+		t.Errorf("expected to iterate 1 time, got %d", count)
+	}
+}
+
+func TestBoundedCallbackMayAccessSet(t *testing.T) {
+	b := NewBounded[int](1, nil)
+	var present bool
+	b.OnEvict(func(e int) { present = b.Contains(e) })
+	b.Insert(1, 2)
+	if present {
+		t.Errorf("evicted element is still present")
+	}
+}
+
+func TestBoundedConcurrent(t *testing.T) {
+	for _, policy := range []func() EvictionPolicy[int]{NewLRU[int], NewLFU[int]} {
+		b := NewBounded(16, policy())
+		var wg sync.WaitGroup
+		for w := range 4 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := range 500 {
+					e := (w*31 + i) % 40
+					b.Insert(e)
+					b.Contains(e / 2)
+					if i%7 == 0 {
+						b.Delete(e)
+					}
+				}
+			}()
+		}
+		wg.Wait()
+		if got := b.Len(); got > 16 {
+			t.Errorf("set holds %d elements, capacity is 16", got)
+		}
+	}
+}
+
+func TestBoundedPanics(t *testing.T) {
+	tests := []struct {
+		name string
+		f    func()
+	}{
+		{
+			name: "zero capacity",
+			f:    func() { NewBounded[int](0, nil) },
+		},
+		{
+			name: "zero resize",
+			f:    func() { NewBounded[int](1, nil).Resize(0) },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("expected panic, but did not panic")
+				}
+			}()
+			tt.f()
+		})
+	}
+}
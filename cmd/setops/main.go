@@ -0,0 +1,275 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Setops performs set operations on line-oriented files, treating every line as an element.
+// Unlike sort | comm, the inputs do not need to be sorted and any number of them can be combined.
+//
+// Usage:
+//
+//	setops [flags] command [file ...]
+//
+// The commands are:
+//
+//	union      elements present in any input
+//	intersect  elements present in every input
+//	diff       elements of the first input that are not in any of the others
+//	symdiff    elements present in an odd number of inputs
+//	subset     check that the first input is a subset of every other input
+//	equal      check that all inputs contain the same elements
+//
+// With no files, or when a file is "-", standard input is read; it is read once even if "-" is
+// given several times. Trailing carriage returns are removed and empty lines are ignored.
+// Flags must precede the command: arguments after it that start with "-", other than "-",
+// are rejected rather than read as files, so name such files e.g. ./-file.
+//
+// The flags are:
+//
+//	-c     print the number of elements instead of the elements
+//	-json  print the result as JSON
+//	-order sorted|input
+//	       print elements sorted (default) or in order of first appearance in the inputs
+//
+// The exit status is 0 on success or if a check holds, 1 if a check does not hold, and 2 on error.
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/kkhmel/sets"
+)
+
+// Exit codes.
+const (
+	exitOK    = 0
+	exitFalse = 1
+	exitError = 2
+)
+
+// exit is replaced in tests.
+var exit = os.Exit
+
+func main() {
+	exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// input is the content of one input file.
+type input struct {
+	set   sets.Set[string]
+	order []string // distinct elements in order of first appearance
+}
+
+var (
+	operations = map[string]func(inputs []input) sets.Set[string]{
+		"union": func(inputs []input) sets.Set[string] {
+			return sets.Union(setsOf(inputs)...)
+		},
+		"intersect": func(inputs []input) sets.Set[string] {
+			return sets.Intersection(setsOf(inputs)...)
+		},
+		"diff": func(inputs []input) sets.Set[string] {
+			return sets.Difference(inputs[0].set, setsOf(inputs[1:])...)
+		},
+		"symdiff": func(inputs []input) sets.Set[string] {
+			return sets.SymmetricDifference(setsOf(inputs)...)
+		},
+	}
+	checks = map[string]func(inputs []input) bool{
+		"subset": func(inputs []input) bool {
+			for _, in := range inputs[1:] {
+				if !sets.Subset(inputs[0].set, in.set) {
+					return false
+				}
+			}
+			return true
+		},
+		"equal": func(inputs []input) bool {
+			for _, in := range inputs[1:] {
+				if !sets.Equal(inputs[0].set, in.set) {
+					return false
+				}
+			}
+			return true
+		},
+	}
+)
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("setops", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "usage: setops [flags] union|intersect|diff|symdiff|subset|equal [file ...]")
+		fs.PrintDefaults()
+	}
+	count := fs.Bool("c", false, "print the number of elements instead of the elements")
+	asJSON := fs.Bool("json", false, "print the result as JSON")
+	order := fs.String("order", "sorted", "element `order`: sorted or input")
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitError
+	}
+	if *order != "sorted" && *order != "input" {
+		fmt.Fprintf(stderr, "setops: invalid order %q\n", *order)
+		return exitError
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return exitError
+	}
+
+	command, files := fs.Arg(0), fs.Args()[1:]
+	operation, check := operations[command], checks[command]
+	if operation == nil && check == nil {
+		fmt.Fprintf(stderr, "setops: unknown command %q\n", command)
+		return exitError
+	}
+	if len(files) == 0 {
+		files = []string{"-"}
+	}
+	for _, name := range files {
+		if name != "-" && strings.HasPrefix(name, "-") {
+			fmt.Fprintf(stderr, "setops: %q after the command: flags must precede it, and files starting with - need a path such as ./%s\n", name, name)
+			return exitError
+		}
+	}
+	inputs := make([]input, 0, len(files))
+	var stdinInput *input
+	for _, name := range files {
+		if name == "-" && stdinInput != nil {
+			inputs = append(inputs, *stdinInput)
+			continue
+		}
+		in, err := readFile(name, stdin)
+		if err != nil {
+			fmt.Fprintf(stderr, "setops: %v\n", err)
+			return exitError
+		}
+		if name == "-" {
+			stdinInput = &in
+		}
+		inputs = append(inputs, in)
+	}
+
+	// Output is buffered, and the first write error is reported by Flush.
+	w := bufio.NewWriter(stdout)
+	status := exitOK
+	if check != nil {
+		ok := check(inputs)
+		if *asJSON {
+			printJSON(w, map[string]bool{"result": ok})
+		}
+		if !ok {
+			status = exitFalse
+		}
+	} else {
+		result := operation(inputs)
+		switch {
+		case *count && *asJSON:
+			printJSON(w, map[string]int{"count": len(result)})
+		case *count:
+			fmt.Fprintln(w, len(result))
+		case *asJSON:
+			printJSON(w, arrange(result, inputs, *order == "input"))
+		default:
+			for _, e := range arrange(result, inputs, *order == "input") {
+				fmt.Fprintln(w, e)
+			}
+		}
+	}
+	if err := w.Flush(); err != nil {
+		fmt.Fprintf(stderr, "setops: %v\n", err)
+		return exitError
+	}
+	return status
+}
+
+// readFile reads the input named name, where "-" denotes stdin.
+func readFile(name string, stdin io.Reader) (input, error) {
+	if name == "-" {
+		return read(stdin)
+	}
+	f, err := os.Open(name)
+	if err != nil {
+		return input{}, err
+	}
+	defer func() { _ = f.Close() }() // nothing was written, so closing cannot lose data
+	in, err := read(f)
+	if err != nil {
+		return input{}, fmt.Errorf("%s: %w", name, err)
+	}
+	return in, nil
+}
+
+func read(r io.Reader) (input, error) {
+	in := input{set: sets.New[string](0)}
+	sc := bufio.NewScanner(r)
+	sc.Buffer(nil, 16<<20)
+	for sc.Scan() {
+		line := sc.Text() // without the line ending; ScanLines also drops a carriage return before it
+		if line == "" || sets.Contains(in.set, line) {
+			continue
+		}
+		sets.Insert(in.set, line)
+		in.order = append(in.order, line)
+	}
+	return in, sc.Err()
+}
+
+// arrange returns the elements of result sorted or in order of their first appearance in inputs.
+func arrange(result sets.Set[string], inputs []input, inputOrder bool) []string {
+	if !inputOrder {
+		elements := sets.ToSlice(result)
+		slices.Sort(elements)
+		return elements
+	}
+	elements := make([]string, 0, len(result))
+	seen := sets.New[string](len(result))
+	for _, in := range inputs {
+		for _, e := range in.order {
+			if sets.Contains(result, e) && !sets.Contains(seen, e) {
+				sets.Insert(seen, e)
+				elements = append(elements, e)
+			}
+		}
+	}
+	return elements
+}
+
+func setsOf(inputs []input) []sets.Set[string] {
+	r := make([]sets.Set[string], len(inputs))
+	for i, in := range inputs {
+		r[i] = in.set
+	}
+	return r
+}
+
+func printJSON(w *bufio.Writer, v any) {
+	// Encoding strings, booleans and numbers cannot fail, and write errors are reported by w.Flush.
+	_ = json.NewEncoder(w).Encode(v)
+}
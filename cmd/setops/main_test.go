@@ -0,0 +1,160 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeFiles writes each content to a file in a temporary directory and returns the file names.
+func writeFiles(t *testing.T, contents ...string) []string {
+	t.Helper()
+	dir := t.TempDir()
+	names := make([]string, len(contents))
+	for i, c := range contents {
+		names[i] = filepath.Join(dir, string(rune('a'+i))+".txt")
+		if err := os.WriteFile(names[i], []byte(c), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return names
+}
+
+func TestRun(t *testing.T) {
+	files := writeFiles(t,
+		"web2\nweb1\ndb1\nweb1\n",
+		"db1\r\ncache1\r\n\r\nweb2\r\n",
+		"web2\nmail1\n",
+	)
+	a, b, c := files[0], files[1], files[2]
+	tests := []struct {
+		name       string
+		args       []string
+		stdin      string
+		wantOut    string
+		wantStatus int
+	}{
+		{name: "union", args: []string{"union", a, b, c}, wantOut: "cache1\ndb1\nmail1\nweb1\nweb2\n"},
+		{name: "union in input order", args: []string{"-order", "input", "union", a, b, c}, wantOut: "web2\nweb1\ndb1\ncache1\nmail1\n"},
+		{name: "intersect", args: []string{"intersect", a, b, c}, wantOut: "web2\n"},
+		{name: "diff", args: []string{"diff", a, b}, wantOut: "web1\n"},
+		{name: "diff of many", args: []string{"diff", b, a, c}, wantOut: "cache1\n"},
+		{name: "symdiff", args: []string{"symdiff", a, b, c}, wantOut: "cache1\nmail1\nweb1\nweb2\n"},
+		{name: "single input", args: []string{"union", a}, wantOut: "db1\nweb1\nweb2\n"},
+		{name: "stdin", args: []string{"-order", "input", "union"}, stdin: "x\ny\nx\n", wantOut: "x\ny\n"},
+		{name: "stdin among files", args: []string{"intersect", a, "-"}, stdin: "web1\nzzz\n", wantOut: "web1\n"},
+		{name: "count", args: []string{"-c", "union", a, b}, wantOut: "4\n"},
+		{name: "count as JSON", args: []string{"-c", "-json", "intersect", a, b}, wantOut: "{\"count\":2}\n"},
+		{name: "JSON", args: []string{"-json", "diff", a, b}, wantOut: "[\"web1\"]\n"},
+		{name: "empty JSON", args: []string{"-json", "diff", a, a}, wantOut: "[]\n"},
+		{name: "subset", args: []string{"subset", "-", a, b}, stdin: "web2\n", wantStatus: exitOK},
+		{name: "not subset", args: []string{"subset", c, a}, wantStatus: exitFalse},
+		{name: "subset as JSON", args: []string{"-json", "subset", c, a}, wantOut: "{\"result\":false}\n", wantStatus: exitFalse},
+		{name: "equal", args: []string{"-json", "equal", a, "-"}, stdin: "db1\nweb1\nweb2\n", wantOut: "{\"result\":true}\n"},
+		{name: "not equal", args: []string{"equal", a, a, b}, wantStatus: exitFalse},
+		{name: "stdin twice", args: []string{"-json", "equal", "-", "-"}, stdin: "x\n", wantOut: "{\"result\":true}\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			status := run(tt.args, strings.NewReader(tt.stdin), &stdout, &stderr)
+			if status != tt.wantStatus || stdout.String() != tt.wantOut || stderr.Len() > 0 {
+				t.Errorf("\nwant: %d %q\ngot : %d %q (stderr %q)", tt.wantStatus, tt.wantOut, status, stdout.String(), stderr.String())
+			}
+		})
+	}
+}
+
+type errReader struct{}
+
+func (errReader) Read([]byte) (int, error) {
+	return 0, errors.New("broken pipe")
+}
+
+func TestRunErrors(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name       string
+		args       []string
+		wantErr    string
+		wantStatus int
+	}{
+		{name: "help", args: []string{"-h"}, wantErr: "usage: setops", wantStatus: exitOK},
+		{name: "unknown flag", args: []string{"-x", "union"}, wantErr: "flag provided but not defined", wantStatus: exitError},
+		{name: "invalid order", args: []string{"-order", "random", "union"}, wantErr: `invalid order "random"`, wantStatus: exitError},
+		{name: "no command", args: nil, wantErr: "usage: setops", wantStatus: exitError},
+		{name: "unknown command", args: []string{"join"}, wantErr: `unknown command "join"`, wantStatus: exitError},
+		{name: "missing file", args: []string{"union", filepath.Join(dir, "missing")}, wantErr: "no such file", wantStatus: exitError},
+		{name: "unreadable file", args: []string{"union", dir}, wantErr: dir + ": read", wantStatus: exitError},
+		{name: "unreadable stdin", args: []string{"union", "-"}, wantErr: "broken pipe", wantStatus: exitError},
+		{name: "flag after command", args: []string{"union", "-", "-c"}, wantErr: `"-c" after the command`, wantStatus: exitError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			status := run(tt.args, errReader{}, &stdout, &stderr)
+			if status != tt.wantStatus || !strings.Contains(stderr.String(), tt.wantErr) || stdout.Len() > 0 {
+				t.Errorf("\nwant: %d %q\ngot : %d %q", tt.wantStatus, tt.wantErr, status, stderr.String())
+			}
+		})
+	}
+}
+
+type errWriter struct{}
+
+func (errWriter) Write([]byte) (int, error) {
+	return 0, errors.New("no space left on device")
+}
+
+func TestRunWriteErrors(t *testing.T) {
+	files := writeFiles(t, "a\n", "b\n")
+	for _, args := range [][]string{
+		{"union", files[0], files[1]},
+		{"-json", "union", files[0]},
+		{"-c", "union", files[0]},
+		{"-json", "equal", files[0], files[1]},
+	} {
+		var stderr bytes.Buffer
+		status := run(args, strings.NewReader(""), errWriter{}, &stderr)
+		if status != exitError || !strings.Contains(stderr.String(), "no space left on device") {
+			t.Errorf("%v\nwant: %d %q\ngot : %d %q", args, exitError, "no space left on device", status, stderr.String())
+		}
+	}
+}
+
+func TestMainExitStatus(t *testing.T) {
+	files := writeFiles(t, "a\n", "a\nb\n")
+	args := os.Args
+	defer func() { os.Args, exit = args, os.Exit }()
+
+	status := -1
+	exit = func(code int) { status = code }
+	os.Args = []string{"setops", "subset", files[0], files[1]}
+	main()
+	if status != exitOK {
+		t.Errorf("\nwant: %v\ngot : %v", exitOK, status)
+	}
+}
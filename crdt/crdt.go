@@ -0,0 +1,70 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package crdt provides conflict-free replicated set types (CRDTs).
+// Replicas of a set are modified independently, e.g. while offline,
+// and converge to the same value once they have merged each other's states,
+// regardless of the order, grouping and repetition of merges.
+//
+// The types differ in how they resolve concurrent additions and removals:
+//
+//   - GSet only grows: elements cannot be removed.
+//   - TwoPSet allows removing an element, after which it can never be added again.
+//   - LWWSet keeps the most recent of the additions and removals of an element by timestamp.
+//   - ORSet lets an addition win over a concurrent removal of the same element.
+//
+// Besides full states, every type accumulates delta states: Delta returns a small value
+// holding only the local changes since the previous call, which can be sent and merged
+// instead of the full state. Deltas are read-only: Add and Remove panic on them,
+// and their own Delta is empty, but they can be merged, cloned and encoded. All types support JSON and binary (gob) encoding for replication.
+// Elements must be encodable with encoding/json and encoding/gob respectively.
+//
+// Values of these types are not safe for concurrent use.
+package crdt
+
+import (
+	"bytes"
+	"encoding/gob"
+
+	"github.com/kkhmel/sets"
+)
+
+// elements returns the elements of s as a non-nil slice, which encodes as an empty JSON array for an empty set.
+func elements[E comparable](s sets.Set[E]) []E {
+	r := make([]E, 0, len(s))
+	for e := range s {
+		r = append(r, e)
+	}
+	return r
+}
+
+// marshalBinary encodes v with encoding/gob.
+func marshalBinary(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// unmarshalBinary decodes data encoded by marshalBinary into v.
+func unmarshalBinary(data []byte, v any) error {
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(v)
+}
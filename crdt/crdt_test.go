@@ -0,0 +1,163 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package crdt
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/kkhmel/sets"
+)
+
+// replicated describes one of the CRDT types for the property tests.
+type replicated[S any] struct {
+	name   string
+	new    func(replica int) S
+	add    func(s S, v int)
+	remove func(s S, v int) // nil if elements cannot be removed
+	merge  func(dst, src S)
+	delta  func(s S) S
+	clone  func(s S) S
+	value  func(s S) sets.Set[int]
+}
+
+// modifiesDelta checks that each of the functions panics because it modifies a delta.
+func modifiesDelta(t *testing.T, funcs map[string]func()) {
+	t.Helper()
+	for name, f := range funcs {
+		func() {
+			defer func() {
+				if r := recover(); r != "cannot modify a delta" {
+					t.Errorf("%s() on a delta: want panic %q, got %v", name, "cannot modify a delta", r)
+				}
+			}()
+			f()
+		}()
+	}
+}
+
+// fakeClock returns timestamps from a small range in random order,
+// so that replicas issue equal timestamps and clocks go backwards.
+func fakeClock(rng *rand.Rand) func() time.Time {
+	return func() time.Time { return time.Unix(0, rng.Int64N(8)) }
+}
+
+func TestConvergence(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	testConvergence(t, replicated[*GSet[int]]{
+		name:  "GSet",
+		new:   func(int) *GSet[int] { return NewGSet[int]() },
+		add:   func(s *GSet[int], v int) { s.Add(v) },
+		merge: (*GSet[int]).Merge,
+		delta: (*GSet[int]).Delta,
+		clone: (*GSet[int]).Clone,
+		value: (*GSet[int]).Value,
+	})
+	testConvergence(t, replicated[*TwoPSet[int]]{
+		name:   "TwoPSet",
+		new:    func(int) *TwoPSet[int] { return NewTwoPSet[int]() },
+		add:    func(s *TwoPSet[int], v int) { s.Add(v) },
+		remove: func(s *TwoPSet[int], v int) { s.Remove(v) },
+		merge:  (*TwoPSet[int]).Merge,
+		delta:  (*TwoPSet[int]).Delta,
+		clone:  (*TwoPSet[int]).Clone,
+		value:  (*TwoPSet[int]).Value,
+	})
+	testConvergence(t, replicated[*LWWSet[int]]{
+		name:   "LWWSet",
+		new:    func(int) *LWWSet[int] { return NewLWWSet[int](fakeClock(rng)) },
+		add:    func(s *LWWSet[int], v int) { s.Add(v) },
+		remove: func(s *LWWSet[int], v int) { s.Remove(v) },
+		merge:  (*LWWSet[int]).Merge,
+		delta:  (*LWWSet[int]).Delta,
+		clone:  (*LWWSet[int]).Clone,
+		value:  (*LWWSet[int]).Value,
+	})
+	testConvergence(t, replicated[*ORSet[int]]{
+		name:   "ORSet",
+		new:    func(replica int) *ORSet[int] { return NewORSet[int](fmt.Sprint("r", replica)) },
+		add:    func(s *ORSet[int], v int) { s.Add(v) },
+		remove: func(s *ORSet[int], v int) { s.Remove(v) },
+		merge:  (*ORSet[int]).Merge,
+		delta:  (*ORSet[int]).Delta,
+		clone:  (*ORSet[int]).Clone,
+		value:  (*ORSet[int]).Value,
+	})
+}
+
+// testConvergence applies random operations and merges to three replicas and checks that
+// merging is commutative, associative and idempotent, that the replicas converge, and that
+// merging only the deltas gives the same value as merging the full states.
+func testConvergence[S any](t *testing.T, r replicated[S]) {
+	t.Helper()
+	t.Run(r.name, func(t *testing.T) {
+		for seed := range uint64(200) {
+			rng := rand.New(rand.NewPCG(seed, 0))
+			replicas := []S{r.new(0), r.new(1), r.new(2)}
+			var deltas []S
+			for range rng.IntN(30) {
+				i := rng.IntN(len(replicas))
+				switch op := rng.IntN(10); {
+				case op < 5:
+					r.add(replicas[i], rng.IntN(6))
+				case op < 8 && r.remove != nil:
+					r.remove(replicas[i], rng.IntN(6))
+				default:
+					r.merge(replicas[i], r.clone(replicas[rng.IntN(len(replicas))]))
+				}
+				deltas = append(deltas, r.delta(replicas[i]))
+			}
+			a, b, c := replicas[0], replicas[1], replicas[2]
+
+			merged := func(states ...S) S {
+				s := r.clone(states[0])
+				for _, o := range states[1:] {
+					r.merge(s, o)
+				}
+				return s
+			}
+			equal := func(law string, x, y S) {
+				t.Helper()
+				if vx, vy := r.value(x), r.value(y); !sets.Equal(vx, vy) {
+					t.Errorf("seed %d: %s\nwant: %v\ngot : %v", seed, law, vx, vy)
+				}
+			}
+			equal("commutativity", merged(a, b), merged(b, a))
+			equal("associativity", merged(merged(a, b), c), merged(a, merged(b, c)))
+			equal("idempotence", a, merged(a, a))
+			equal("idempotence of repeated merges", merged(a, b), merged(a, b, b, a))
+
+			all := merged(a, b, c)
+			for _, x := range replicas {
+				equal("convergence", all, merged(x, c, a, b))
+			}
+
+			fromDeltas := r.new(3)
+			rng.Shuffle(len(deltas), func(i, j int) { deltas[i], deltas[j] = deltas[j], deltas[i] })
+			for _, d := range deltas {
+				r.merge(fromDeltas, d)
+			}
+			equal("deltas", all, fromDeltas)
+		}
+	})
+}
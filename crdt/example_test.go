@@ -0,0 +1,44 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package crdt_test
+
+import (
+	"fmt"
+
+	"github.com/kkhmel/sets/crdt"
+)
+
+func ExampleORSet() {
+	phone, laptop := crdt.NewORSet[string]("phone"), crdt.NewORSet[string]("laptop")
+	phone.Add("milk", "eggs")
+	laptop.Merge(phone.Delta())
+
+	// Concurrently, the laptop removes milk and the phone adds it again.
+	laptop.Remove("milk")
+	phone.Add("milk")
+
+	phone.Merge(laptop.Delta())
+	laptop.Merge(phone.Delta())
+	fmt.Println(phone.Value(), laptop.Value())
+
+	// Output:
+	// {eggs, milk} {eggs, milk}
+}
@@ -0,0 +1,171 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package crdt
+
+import (
+	"encoding/json"
+
+	"github.com/kkhmel/sets"
+)
+
+// GSet is a grow-only set: elements can be added but never removed.
+// Merging takes the union of the states.
+// The zero value is an empty set ready to use.
+type GSet[E comparable] struct {
+	added sets.Set[E]
+	delta sets.Set[E] // additions since the last call to Delta
+}
+
+// gSetState is the encoded form of a GSet.
+type gSetState[E comparable] struct {
+	Added []E `json:"added"`
+}
+
+// NewGSet creates a new GSet containing vals.
+//
+// Time complexity: O(len(vals)). Space complexity: O(len(vals)).
+func NewGSet[E comparable](vals ...E) *GSet[E] {
+	s := &GSet[E]{}
+	s.Add(vals...)
+	return s
+}
+
+// Add adds the given elements to the set.
+// Add panics if s was returned by Delta.
+//
+// Time complexity: O(len(v)). Space complexity: O(len(v)).
+func (s *GSet[E]) Add(v ...E) {
+	s.mustBeMutable()
+	s.init()
+	for _, e := range v {
+		if !sets.Contains(s.added, e) {
+			sets.Insert(s.added, e)
+			sets.Insert(s.delta, e)
+		}
+	}
+}
+
+// Contains reports whether v is present in the set.
+//
+// Time complexity: O(1). Space complexity: O(1).
+func (s *GSet[E]) Contains(v E) bool {
+	return sets.Contains(s.added, v)
+}
+
+// Len returns the number of elements in the set.
+//
+// Time complexity: O(1). Space complexity: O(1).
+func (s *GSet[E]) Len() int {
+	return len(s.added)
+}
+
+// Value returns a new Set containing the elements of the set.
+//
+// Time complexity: O(len(s)). Space complexity: O(len(s)).
+func (s *GSet[E]) Value() sets.Set[E] {
+	return sets.Union(s.added)
+}
+
+// Merge merges the state of other, which may be a full state or a delta, into s.
+//
+// Time complexity: O(len(other)). Space complexity: O(len(other)).
+func (s *GSet[E]) Merge(other *GSet[E]) {
+	s.init()
+	sets.Copy(s.added, other.added)
+}
+
+// Delta returns the elements added since the previous call to Delta as a GSet
+// that can be merged into other replicas instead of the full state.
+// Elements received through Merge are not included. The result is read-only.
+//
+// Time complexity: O(1). Space complexity: O(1).
+func (s *GSet[E]) Delta() *GSet[E] {
+	if s.isDelta() {
+		return &GSet[E]{added: sets.New[E](0)}
+	}
+	s.init()
+	d := &GSet[E]{added: s.delta}
+	s.delta = sets.New[E](0)
+	return d
+}
+
+// Clone returns a copy of s with an empty delta.
+//
+// Time complexity: O(len(s)). Space complexity: O(len(s)).
+func (s *GSet[E]) Clone() *GSet[E] {
+	return &GSet[E]{added: sets.Union(s.added), delta: sets.New[E](0)}
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s *GSet[E]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.state())
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It replaces the state of s.
+func (s *GSet[E]) UnmarshalJSON(data []byte) error {
+	var st gSetState[E]
+	if err := json.Unmarshal(data, &st); err != nil {
+		return err
+	}
+	s.setState(st)
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (s *GSet[E]) MarshalBinary() ([]byte, error) {
+	return marshalBinary(s.state())
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. It replaces the state of s.
+func (s *GSet[E]) UnmarshalBinary(data []byte) error {
+	var st gSetState[E]
+	if err := unmarshalBinary(data, &st); err != nil {
+		return err
+	}
+	s.setState(st)
+	return nil
+}
+
+func (s *GSet[E]) state() gSetState[E] {
+	return gSetState[E]{Added: elements(s.added)}
+}
+
+func (s *GSet[E]) setState(st gSetState[E]) {
+	*s = GSet[E]{added: sets.FromSlice(st.Added), delta: sets.New[E](0)}
+}
+
+// isDelta reports whether s was returned by Delta, which leaves its delta nil.
+func (s *GSet[E]) isDelta() bool {
+	return s.added != nil && s.delta == nil
+}
+
+// mustBeMutable panics if s is a delta.
+func (s *GSet[E]) mustBeMutable() {
+	if s.isDelta() {
+		panic("cannot modify a delta")
+	}
+}
+
+func (s *GSet[E]) init() {
+	if s.added == nil {
+		s.added, s.delta = sets.New[E](0), sets.New[E](0)
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package crdt
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/kkhmel/sets"
+)
+
+func TestGSet(t *testing.T) {
+	var s GSet[string] // the zero value is ready to use
+	s.Add("a", "b", "a")
+	if got, want := s.Value(), sets.From("a", "b"); !sets.Equal(got, want) {
+		t.Errorf("\nwant: %v\ngot : %v", want, got)
+	}
+	if !s.Contains("a") || s.Contains("c") {
+		t.Errorf("Contains() = %v, %v", s.Contains("a"), s.Contains("c"))
+	}
+	if got := s.Len(); got != 2 {
+		t.Errorf("\nwant: %v\ngot : %v", 2, got)
+	}
+
+	var empty GSet[string]
+	if got := empty.Value(); got == nil || len(got) != 0 {
+		t.Errorf("Value() of the zero value = %#v", got)
+	}
+}
+
+func TestGSetMergeDelta(t *testing.T) {
+	a, b := NewGSet("a"), NewGSet("b")
+	if got, want := a.Delta().Value(), sets.From("a"); !sets.Equal(got, want) {
+		t.Errorf("\nwant: %v\ngot : %v", want, got)
+	}
+	a.Merge(b)
+	a.Add("a", "c")
+	if got, want := a.Delta().Value(), sets.From("c"); !sets.Equal(got, want) {
+		t.Errorf("Delta() must only hold new local additions\nwant: %v\ngot : %v", want, got)
+	}
+	if got := a.Delta().Len(); got != 0 {
+		t.Errorf("Delta() was not reset: %v", got)
+	}
+
+	var zero GSet[string]
+	zero.Merge(a)
+	if got, want := zero.Value(), sets.From("a", "b", "c"); !sets.Equal(got, want) {
+		t.Errorf("\nwant: %v\ngot : %v", want, got)
+	}
+	if got := zero.Delta().Len(); got != 0 {
+		t.Errorf("Delta() holds merged elements: %v", got)
+	}
+}
+
+func TestGSetDeltaIsReadOnly(t *testing.T) {
+	s := NewGSet("a")
+	d := s.Delta()
+	if dd := d.Delta(); dd.Len() != 0 {
+		t.Errorf("Delta() of a delta is not empty: %v", dd.Value())
+	}
+	modifiesDelta(t, map[string]func(){
+		"Add": func() { d.Add("b") },
+	})
+	c := d.Clone()
+	c.Add("b")
+	var r GSet[string]
+	r.Merge(d)
+	if !r.Contains("a") || !c.Contains("b") {
+		t.Errorf("delta cannot be merged or cloned")
+	}
+}
+
+func TestGSetClone(t *testing.T) {
+	s := NewGSet(1, 2)
+	c := s.Clone()
+	c.Add(3)
+	if s.Contains(3) {
+		t.Errorf("Clone() shares state with the original")
+	}
+	if got, want := c.Delta().Value(), sets.From(3); !sets.Equal(got, want) {
+		t.Errorf("\nwant: %v\ngot : %v", want, got)
+	}
+}
+
+func TestGSetEncoding(t *testing.T) {
+	s := NewGSet("a", "b")
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fromJSON GSet[string]
+	if err := json.Unmarshal(data, &fromJSON); err != nil {
+		t.Fatal(err)
+	}
+	if !sets.Equal(fromJSON.Value(), s.Value()) {
+		t.Errorf("\nwant: %v\ngot : %v", s.Value(), fromJSON.Value())
+	}
+	fromJSON.Add("c")
+	if got, want := fromJSON.Delta().Value(), sets.From("c"); !sets.Equal(got, want) {
+		t.Errorf("\nwant: %v\ngot : %v", want, got)
+	}
+
+	data, err = s.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fromBinary GSet[string]
+	if err := fromBinary.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if !sets.Equal(fromBinary.Value(), s.Value()) {
+		t.Errorf("\nwant: %v\ngot : %v", s.Value(), fromBinary.Value())
+	}
+
+	if data, _ := json.Marshal(&GSet[int]{}); string(data) != `{"added":[]}` {
+		t.Errorf("empty set encoded as %s", data)
+	}
+	if err := fromJSON.UnmarshalJSON([]byte(`{"added":1}`)); err == nil {
+		t.Errorf("UnmarshalJSON() accepted invalid input")
+	}
+	if err := fromBinary.UnmarshalBinary([]byte("invalid")); err == nil {
+		t.Errorf("UnmarshalBinary() accepted invalid input")
+	}
+	if _, err := NewGSet(make(chan int)).MarshalBinary(); err == nil {
+		t.Errorf("MarshalBinary() encoded a channel")
+	}
+}
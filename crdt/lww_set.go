@@ -0,0 +1,267 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package crdt
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/kkhmel/sets"
+)
+
+// LWWSet is a last-writer-wins element set: every addition and removal of an element is
+// timestamped, and the element is present if its latest addition is not older than its latest
+// removal, so an addition wins over a removal with the same timestamp.
+// Merging keeps the latest timestamps of both states.
+//
+// Timestamps are Unix times in nanoseconds taken from a clock. To keep the changes of a replica
+// ordered even if the clock goes backwards, every timestamp is greater than the previous one and
+// than all timestamps received through Merge. Replicas should still have roughly synchronized clocks,
+// since a replica whose clock runs ahead wins over concurrent changes of the others.
+//
+// The zero value is an empty set ready to use that takes timestamps from time.Now.
+type LWWSet[E comparable] struct {
+	now     func() time.Time
+	last    int64 // latest timestamp issued or received
+	adds    map[E]int64
+	removes map[E]int64
+	delta   *LWWSet[E] // changes since the last call to Delta
+}
+
+// lwwSetState is the encoded form of an LWWSet.
+type lwwSetState[E comparable] struct {
+	Adds    []lwwEntry[E] `json:"adds"`
+	Removes []lwwEntry[E] `json:"removes"`
+}
+
+// lwwEntry is the latest timestamp of an addition or removal of an element.
+type lwwEntry[E comparable] struct {
+	Elem E     `json:"elem"`
+	Time int64 `json:"time"`
+}
+
+// NewLWWSet creates a new empty LWWSet that takes timestamps from now.
+// If now is nil, time.Now is used.
+//
+// Time complexity: O(1). Space complexity: O(1).
+func NewLWWSet[E comparable](now func() time.Time) *LWWSet[E] {
+	s := &LWWSet[E]{now: now}
+	s.init()
+	return s
+}
+
+// Add adds the given elements to the set with the current timestamp.
+// Add panics if s was returned by Delta.
+//
+// Time complexity: O(len(v)). Space complexity: O(len(v)).
+func (s *LWWSet[E]) Add(v ...E) {
+	s.mustBeMutable()
+	s.init()
+	ts := s.tick()
+	for _, e := range v {
+		s.adds[e] = ts
+		s.delta.adds[e] = ts
+	}
+}
+
+// Remove removes the given elements from the set with the current timestamp.
+// A removal is recorded even if the element is not present, so that it wins over
+// older additions received later. Remove panics if s was returned by Delta.
+//
+// Time complexity: O(len(v)). Space complexity: O(len(v)).
+func (s *LWWSet[E]) Remove(v ...E) {
+	s.mustBeMutable()
+	s.init()
+	ts := s.tick()
+	for _, e := range v {
+		s.removes[e] = ts
+		s.delta.removes[e] = ts
+	}
+}
+
+// Contains reports whether the latest addition of v is not older than its latest removal.
+//
+// Time complexity: O(1). Space complexity: O(1).
+func (s *LWWSet[E]) Contains(v E) bool {
+	added, ok := s.adds[v]
+	return ok && added >= s.removes[v]
+}
+
+// Len returns the number of elements in the set.
+//
+// Time complexity: O(len(s)). Space complexity: O(1).
+func (s *LWWSet[E]) Len() int {
+	n := 0
+	for e := range s.adds {
+		if s.Contains(e) {
+			n++
+		}
+	}
+	return n
+}
+
+// Value returns a new Set containing the elements of the set.
+//
+// Time complexity: O(len(s)). Space complexity: O(len(s)).
+func (s *LWWSet[E]) Value() sets.Set[E] {
+	r := sets.New[E](0)
+	for e := range s.adds {
+		if s.Contains(e) {
+			sets.Insert(r, e)
+		}
+	}
+	return r
+}
+
+// Merge merges the state of other, which may be a full state or a delta, into s.
+//
+// Time complexity: O(len(other)). Space complexity: O(len(other)).
+func (s *LWWSet[E]) Merge(other *LWWSet[E]) {
+	s.init()
+	s.merge(s.adds, other.adds)
+	s.merge(s.removes, other.removes)
+}
+
+// Delta returns the additions and removals since the previous call to Delta as an LWWSet
+// that can be merged into other replicas instead of the full state.
+// Changes received through Merge are not included. The result is read-only.
+//
+// Time complexity: O(1). Space complexity: O(1).
+func (s *LWWSet[E]) Delta() *LWWSet[E] {
+	if s.isDelta() {
+		return newLWWDelta[E]()
+	}
+	s.init()
+	d := s.delta
+	d.now, d.last = s.now, s.last
+	s.delta = newLWWDelta[E]()
+	return d
+}
+
+// Clone returns a copy of s with the same clock and an empty delta.
+//
+// Time complexity: O(len(s)). Space complexity: O(len(s)).
+func (s *LWWSet[E]) Clone() *LWWSet[E] {
+	r := &LWWSet[E]{now: s.now, last: s.last}
+	r.init()
+	r.Merge(s)
+	return r
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s *LWWSet[E]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.state())
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It replaces the state of s and keeps its clock.
+func (s *LWWSet[E]) UnmarshalJSON(data []byte) error {
+	var st lwwSetState[E]
+	if err := json.Unmarshal(data, &st); err != nil {
+		return err
+	}
+	s.setState(st)
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (s *LWWSet[E]) MarshalBinary() ([]byte, error) {
+	return marshalBinary(s.state())
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. It replaces the state of s and keeps its clock.
+func (s *LWWSet[E]) UnmarshalBinary(data []byte) error {
+	var st lwwSetState[E]
+	if err := unmarshalBinary(data, &st); err != nil {
+		return err
+	}
+	s.setState(st)
+	return nil
+}
+
+func (s *LWWSet[E]) state() lwwSetState[E] {
+	return lwwSetState[E]{Adds: lwwEntries(s.adds), Removes: lwwEntries(s.removes)}
+}
+
+func (s *LWWSet[E]) setState(st lwwSetState[E]) {
+	*s = LWWSet[E]{now: s.now}
+	s.init()
+	for _, en := range st.Adds {
+		s.observe(s.adds, en.Elem, en.Time)
+	}
+	for _, en := range st.Removes {
+		s.observe(s.removes, en.Elem, en.Time)
+	}
+}
+
+func (s *LWWSet[E]) init() {
+	if s.adds == nil {
+		s.adds, s.removes, s.delta = make(map[E]int64), make(map[E]int64), newLWWDelta[E]()
+	}
+}
+
+// isDelta reports whether s was returned by Delta, which leaves its delta nil.
+func (s *LWWSet[E]) isDelta() bool {
+	return s.adds != nil && s.delta == nil
+}
+
+// mustBeMutable panics if s is a delta.
+func (s *LWWSet[E]) mustBeMutable() {
+	if s.isDelta() {
+		panic("cannot modify a delta")
+	}
+}
+
+// tick returns a new timestamp greater than all timestamps seen before.
+func (s *LWWSet[E]) tick() int64 {
+	now := time.Now
+	if s.now != nil {
+		now = s.now
+	}
+	s.last = max(now().UnixNano(), s.last+1)
+	return s.last
+}
+
+// merge keeps the later of the timestamps in dst and src for each element of src.
+func (s *LWWSet[E]) merge(dst, src map[E]int64) {
+	for e, ts := range src {
+		s.observe(dst, e, ts)
+	}
+}
+
+// observe records a change of e at ts in dst unless dst holds a later change.
+func (s *LWWSet[E]) observe(dst map[E]int64, e E, ts int64) {
+	if cur, ok := dst[e]; !ok || ts > cur {
+		dst[e] = ts
+	}
+	s.last = max(s.last, ts)
+}
+
+func newLWWDelta[E comparable]() *LWWSet[E] {
+	return &LWWSet[E]{adds: make(map[E]int64), removes: make(map[E]int64)}
+}
+
+func lwwEntries[E comparable](m map[E]int64) []lwwEntry[E] {
+	r := make([]lwwEntry[E], 0, len(m))
+	for e, ts := range m {
+		r = append(r, lwwEntry[E]{Elem: e, Time: ts})
+	}
+	return r
+}
@@ -0,0 +1,179 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package crdt
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/kkhmel/sets"
+)
+
+// manualClock returns the time it is set to.
+type manualClock struct{ ns int64 }
+
+func (c *manualClock) now() time.Time { return time.Unix(0, c.ns) }
+
+func TestLWWSet(t *testing.T) {
+	clock := &manualClock{ns: 100}
+	s := NewLWWSet[string](clock.now)
+	s.Add("a", "b")
+	s.Remove("b", "c")
+	if got, want := s.Value(), sets.From("a"); !sets.Equal(got, want) {
+		t.Errorf("\nwant: %v\ngot : %v", want, got)
+	}
+
+	// Timestamps increase even if the clock goes backwards.
+	clock.ns = 0
+	s.Add("b", "c")
+	if got, want := s.Value(), sets.From("a", "b", "c"); !sets.Equal(got, want) {
+		t.Errorf("\nwant: %v\ngot : %v", want, got)
+	}
+	if got := s.Len(); got != 3 {
+		t.Errorf("\nwant: %v\ngot : %v", 3, got)
+	}
+	if s.Contains("z") {
+		t.Errorf("Contains() reported an element never added")
+	}
+
+	var zero LWWSet[int] // the zero value uses time.Now
+	zero.Add(1)
+	if ts := zero.adds[1]; time.Since(time.Unix(0, ts)) > time.Hour {
+		t.Errorf("timestamp %v was not taken from time.Now", ts)
+	}
+}
+
+func TestLWWSetMerge(t *testing.T) {
+	ca, cb := &manualClock{ns: 10}, &manualClock{ns: 10}
+	a, b := NewLWWSet[string](ca.now), NewLWWSet[string](cb.now)
+	a.Add("x", "y")
+	b.Add("x")
+	b.Remove("x") // at 11, later than the additions
+
+	var zero LWWSet[string]
+	zero.Merge(a.Delta())
+	zero.Merge(b.Delta())
+	if got, want := zero.Value(), sets.From("y"); !sets.Equal(got, want) {
+		t.Errorf("\nwant: %v\ngot : %v", want, got)
+	}
+
+	// An addition wins over a removal with the same timestamp.
+	a.Merge(b)
+	cb.ns = 12
+	b.Remove("y")
+	ca.ns = 0
+	a.Add("y") // at 12 because a has seen 11
+	a.Merge(b)
+	if !a.Contains("y") {
+		t.Errorf("a removal won over an addition with the same timestamp")
+	}
+	if d := a.Delta(); len(d.adds) != 1 || len(d.removes) != 0 {
+		t.Errorf("Delta() holds merged changes: %v, %v", d.adds, d.removes)
+	}
+
+	// Older changes are ignored.
+	c := NewLWWSet[string](nil)
+	c.Merge(a)
+	old := NewLWWSet[string](func() time.Time { return time.Unix(0, 1) })
+	old.Remove("y")
+	c.Merge(old)
+	if !c.Contains("y") {
+		t.Errorf("an older removal won")
+	}
+}
+
+func TestLWWSetClone(t *testing.T) {
+	clock := &manualClock{ns: 5}
+	s := NewLWWSet[int](clock.now)
+	s.Add(1)
+	c := s.Clone()
+	c.Remove(1)
+	if !s.Contains(1) || c.Contains(1) {
+		t.Errorf("Clone() shares state with the original")
+	}
+	if d := c.Delta(); len(d.adds) != 0 || len(d.removes) != 1 {
+		t.Errorf("Clone() did not start with an empty delta: %v, %v", d.adds, d.removes)
+	}
+}
+
+func TestLWWSetDeltaIsReadOnly(t *testing.T) {
+	clock := &manualClock{ns: 5}
+	s := NewLWWSet[int](clock.now)
+	s.Add(1)
+	d := s.Delta()
+	if dd := d.Delta(); len(dd.adds) != 0 || len(dd.removes) != 0 {
+		t.Errorf("Delta() of a delta is not empty: %v, %v", dd.adds, dd.removes)
+	}
+	modifiesDelta(t, map[string]func(){
+		"Add":    func() { d.Add(2) },
+		"Remove": func() { d.Remove(1) },
+	})
+	// It can still be merged and cloned into a regular set with the clock of its origin.
+	c := d.Clone()
+	c.Remove(1)
+	var r LWWSet[int]
+	r.Merge(d)
+	if !r.Contains(1) || c.Contains(1) {
+		t.Errorf("delta cannot be merged or cloned")
+	}
+}
+
+func TestLWWSetEncoding(t *testing.T) {
+	clock := &manualClock{ns: 5}
+	s := NewLWWSet[string](clock.now)
+	s.Add("a", "b")
+	s.Remove("b")
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromJSON := NewLWWSet[string](clock.now)
+	if err := json.Unmarshal(data, fromJSON); err != nil {
+		t.Fatal(err)
+	}
+	data, err = s.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromBinary := NewLWWSet[string](clock.now)
+	if err := fromBinary.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	for _, got := range []*LWWSet[string]{fromJSON, fromBinary} {
+		if want := sets.From("a"); !sets.Equal(got.Value(), want) {
+			t.Errorf("\nwant: %v\ngot : %v", want, got.Value())
+		}
+		// The decoded set keeps its clock and has seen the decoded timestamps.
+		got.Add("b")
+		if !got.Contains("b") || got.adds["b"] != 7 {
+			t.Errorf("Add() after decoding used timestamp %v", got.adds["b"])
+		}
+	}
+
+	if err := fromJSON.UnmarshalJSON([]byte(`{"adds":{}}`)); err == nil {
+		t.Errorf("UnmarshalJSON() accepted invalid input")
+	}
+	if err := fromBinary.UnmarshalBinary([]byte{1}); err == nil {
+		t.Errorf("UnmarshalBinary() accepted invalid input")
+	}
+}
@@ -0,0 +1,295 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package crdt
+
+import (
+	"encoding/json"
+
+	"github.com/kkhmel/sets"
+)
+
+// Tag uniquely identifies an addition of an element to an ORSet.
+type Tag struct {
+	Replica string `json:"replica"` // ID of the replica that made the addition
+	Seq     uint64 `json:"seq"`     // sequence number of the addition at that replica
+}
+
+// ORSet is an observed-remove set: every addition of an element is identified by a unique tag,
+// and a removal only deletes the tags it has observed. An element is present while it has
+// a tag that has not been removed, so an addition wins over a concurrent removal, and an element
+// can be added again after being removed. Merging takes the union of the tags and of the
+// removed tags, which are kept as tombstones.
+//
+// Each replica must have a unique ID, which is part of the tags of its additions.
+// Use NewORSet to create an ORSet; the zero value is not ready to use.
+type ORSet[E comparable] struct {
+	replica string
+	seq     uint64              // sequence number of the latest addition of this replica
+	adds    map[E]sets.Set[Tag] // tags of each present element that have not been removed
+	removed map[E]sets.Set[Tag] // removed tags of each element
+	delta   *ORSet[E]           // changes since the last call to Delta
+}
+
+// orSetState is the encoded form of an ORSet.
+type orSetState[E comparable] struct {
+	Adds    []orEntry[E] `json:"adds"`
+	Removed []orEntry[E] `json:"removed"`
+}
+
+// orEntry holds the tags of an element.
+type orEntry[E comparable] struct {
+	Elem E     `json:"elem"`
+	Tags []Tag `json:"tags"`
+}
+
+// NewORSet creates a new empty ORSet for the replica with the given ID.
+// If replica is empty, NewORSet panics.
+//
+// Time complexity: O(1). Space complexity: O(1).
+func NewORSet[E comparable](replica string) *ORSet[E] {
+	if replica == "" {
+		panic("cannot be empty")
+	}
+	return &ORSet[E]{
+		replica: replica,
+		adds:    make(map[E]sets.Set[Tag]),
+		removed: make(map[E]sets.Set[Tag]),
+		delta:   newORDelta[E](),
+	}
+}
+
+// Replica returns the ID of the replica.
+//
+// Time complexity: O(1). Space complexity: O(1).
+func (s *ORSet[E]) Replica() string {
+	return s.replica
+}
+
+// Add adds the given elements to the set, each with a new tag.
+// Add panics if s was returned by Delta.
+//
+// Time complexity: O(len(v)). Space complexity: O(len(v)).
+func (s *ORSet[E]) Add(v ...E) {
+	s.mustBeMutable()
+	for _, e := range v {
+		s.seq++
+		t := Tag{Replica: s.replica, Seq: s.seq}
+		addTag(s.adds, e, t)
+		addTag(s.delta.adds, e, t)
+	}
+}
+
+// Remove removes the given elements from the set by removing all of their tags
+// observed by this replica. Elements that are not present in the set are ignored.
+// Remove panics if s was returned by Delta.
+//
+// Time complexity: O(N). Space complexity: O(N). N is the number of tags of the elements.
+func (s *ORSet[E]) Remove(v ...E) {
+	s.mustBeMutable()
+	for _, e := range v {
+		for t := range s.adds[e] {
+			addTag(s.removed, e, t)
+			addTag(s.delta.removed, e, t)
+		}
+		delete(s.adds, e)
+	}
+}
+
+// Contains reports whether v is present in the set.
+//
+// Time complexity: O(1). Space complexity: O(1).
+func (s *ORSet[E]) Contains(v E) bool {
+	_, ok := s.adds[v]
+	return ok
+}
+
+// Len returns the number of elements in the set.
+//
+// Time complexity: O(1). Space complexity: O(1).
+func (s *ORSet[E]) Len() int {
+	return len(s.adds)
+}
+
+// Value returns a new Set containing the elements of the set.
+//
+// Time complexity: O(len(s)). Space complexity: O(len(s)).
+func (s *ORSet[E]) Value() sets.Set[E] {
+	r := sets.New[E](len(s.adds))
+	for e := range s.adds {
+		sets.Insert(r, e)
+	}
+	return r
+}
+
+// Merge merges the state of other, which may be a full state or a delta, into s.
+// Tags of s's own replica in other, e.g. from a state saved before a restart,
+// advance its sequence number so that new tags stay unique.
+//
+// Time complexity: O(N). Space complexity: O(N). N is the number of tags in other.
+func (s *ORSet[E]) Merge(other *ORSet[E]) {
+	for e, tags := range other.removed {
+		for t := range tags {
+			s.remove(e, t)
+		}
+	}
+	for e, tags := range other.adds {
+		for t := range tags {
+			s.add(e, t)
+		}
+	}
+}
+
+// Delta returns the additions and removals since the previous call to Delta as an ORSet
+// that can be merged into other replicas instead of the full state.
+// Changes received through Merge are not included.
+//
+// The result is read-only: it carries the replica ID of s, so adding to it would create tags
+// that collide with those of s.
+//
+// Time complexity: O(1). Space complexity: O(1).
+func (s *ORSet[E]) Delta() *ORSet[E] {
+	if s.delta == nil {
+		d := newORDelta[E]()
+		d.replica, d.seq = s.replica, s.seq
+		return d
+	}
+	d := s.delta
+	d.replica, d.seq = s.replica, s.seq
+	s.delta = newORDelta[E]()
+	return d
+}
+
+// Clone returns a copy of s for the same replica with an empty delta.
+// To create a new replica from the state of s, merge s into a new ORSet instead.
+//
+// Time complexity: O(N). Space complexity: O(N). N is the number of tags in s.
+func (s *ORSet[E]) Clone() *ORSet[E] {
+	r := NewORSet[E](s.replica)
+	r.seq = s.seq
+	r.Merge(s)
+	return r
+}
+
+// MarshalJSON implements json.Marshaler. The replica ID is not encoded.
+func (s *ORSet[E]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.state())
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It replaces the state of s and keeps its replica ID.
+func (s *ORSet[E]) UnmarshalJSON(data []byte) error {
+	var st orSetState[E]
+	if err := json.Unmarshal(data, &st); err != nil {
+		return err
+	}
+	s.setState(st)
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler. The replica ID is not encoded.
+func (s *ORSet[E]) MarshalBinary() ([]byte, error) {
+	return marshalBinary(s.state())
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. It replaces the state of s and keeps its replica ID.
+func (s *ORSet[E]) UnmarshalBinary(data []byte) error {
+	var st orSetState[E]
+	if err := unmarshalBinary(data, &st); err != nil {
+		return err
+	}
+	s.setState(st)
+	return nil
+}
+
+func (s *ORSet[E]) state() orSetState[E] {
+	return orSetState[E]{Adds: orEntries(s.adds), Removed: orEntries(s.removed)}
+}
+
+func (s *ORSet[E]) setState(st orSetState[E]) {
+	*s = ORSet[E]{
+		replica: s.replica,
+		adds:    make(map[E]sets.Set[Tag]),
+		removed: make(map[E]sets.Set[Tag]),
+		delta:   newORDelta[E](),
+	}
+	for _, en := range st.Removed {
+		for _, t := range en.Tags {
+			s.remove(en.Elem, t)
+		}
+	}
+	for _, en := range st.Adds {
+		for _, t := range en.Tags {
+			s.add(en.Elem, t)
+		}
+	}
+}
+
+// add records the tag t of e unless it has been removed.
+func (s *ORSet[E]) add(e E, t Tag) {
+	s.observe(t)
+	if !sets.Contains(s.removed[e], t) {
+		addTag(s.adds, e, t)
+	}
+}
+
+// remove records the removal of the tag t of e.
+func (s *ORSet[E]) remove(e E, t Tag) {
+	s.observe(t)
+	addTag(s.removed, e, t)
+	if tags, ok := s.adds[e]; ok {
+		sets.Delete(tags, t)
+		if len(tags) == 0 {
+			delete(s.adds, e)
+		}
+	}
+}
+
+// observe advances the sequence number past t if t is a tag of this replica.
+func (s *ORSet[E]) observe(t Tag) {
+	if t.Replica == s.replica {
+		s.seq = max(s.seq, t.Seq)
+	}
+}
+
+// mustBeMutable panics if s is a delta, which has no delta of its own.
+func (s *ORSet[E]) mustBeMutable() {
+	if s.delta == nil {
+		panic("cannot modify a delta")
+	}
+}
+
+func newORDelta[E comparable]() *ORSet[E] {
+	return &ORSet[E]{adds: make(map[E]sets.Set[Tag]), removed: make(map[E]sets.Set[Tag])}
+}
+
+func addTag[E comparable](m map[E]sets.Set[Tag], e E, t Tag) {
+	if m[e] == nil {
+		m[e] = sets.New[Tag](1)
+	}
+	sets.Insert(m[e], t)
+}
+
+func orEntries[E comparable](m map[E]sets.Set[Tag]) []orEntry[E] {
+	r := make([]orEntry[E], 0, len(m))
+	for e, tags := range m {
+		r = append(r, orEntry[E]{Elem: e, Tags: elements(tags)})
+	}
+	return r
+}
@@ -0,0 +1,169 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package crdt
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/kkhmel/sets"
+)
+
+func TestORSet(t *testing.T) {
+	s := NewORSet[string]("r1")
+	if got := s.Replica(); got != "r1" {
+		t.Errorf("\nwant: %v\ngot : %v", "r1", got)
+	}
+	s.Add("a", "b", "a")
+	s.Remove("b", "z")
+	if got, want := s.Value(), sets.From("a"); !sets.Equal(got, want) {
+		t.Errorf("\nwant: %v\ngot : %v", want, got)
+	}
+	s.Add("b") // elements can be added again
+	if !s.Contains("b") || s.Contains("z") || s.Len() != 2 {
+		t.Errorf("Contains() = %v, %v; Len() = %v", s.Contains("b"), s.Contains("z"), s.Len())
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("NewORSet() did not panic for an empty replica")
+		}
+	}()
+	NewORSet[string]("")
+}
+
+func TestORSetMerge(t *testing.T) {
+	a, b := NewORSet[string]("a"), NewORSet[string]("b")
+	a.Add("x")
+	b.Merge(a)
+
+	// A removal only deletes the observed additions, so a concurrent addition wins.
+	b.Remove("x")
+	a.Add("x")
+	a.Merge(b)
+	b.Merge(a)
+	if !a.Contains("x") || !b.Contains("x") {
+		t.Errorf("a concurrent addition did not win over a removal")
+	}
+
+	// A removal received before the addition it observed still applies.
+	c := NewORSet[string]("c")
+	a.Delta()
+	a.Add("y")
+	add := a.Delta()
+	a.Remove("y")
+	c.Merge(a.Delta())
+	c.Merge(add)
+	if c.Contains("y") {
+		t.Errorf("a removed addition was applied")
+	}
+	if got, want := c.Value(), sets.New[string](0); !sets.Equal(got, want) {
+		t.Errorf("\nwant: %v\ngot : %v", want, got)
+	}
+	c.Merge(a)
+	if got, want := c.Value(), sets.From("x"); !sets.Equal(got, want) {
+		t.Errorf("\nwant: %v\ngot : %v", want, got)
+	}
+	if d := c.Delta(); len(d.adds) != 0 || len(d.removed) != 0 {
+		t.Errorf("Delta() holds merged changes: %v, %v", d.adds, d.removed)
+	}
+}
+
+func TestORSetRestart(t *testing.T) {
+	// A replica restored from an old state must not reuse the tags it issued since.
+	s := NewORSet[string]("r")
+	s.Add("a")
+	saved := s.Clone()
+	s.Add("b")
+	s.Remove("b")
+
+	restored := NewORSet[string]("r")
+	restored.Merge(saved)
+	restored.Merge(s.Delta())
+	restored.Add("b")
+	if !restored.Contains("b") {
+		t.Errorf("a reused tag made a new addition disappear")
+	}
+}
+
+func TestORSetDeltaIsReadOnly(t *testing.T) {
+	s := NewORSet[string]("r1")
+	s.Add("a")
+	d := s.Delta()
+	if dd := d.Delta(); dd.Len() != 0 || dd.Replica() != "r1" {
+		t.Errorf("Delta() of a delta is not empty: %v", dd.Value())
+	}
+	modifiesDelta(t, map[string]func(){
+		"Add":    func() { d.Add("b") },
+		"Remove": func() { d.Remove("a") },
+	})
+	// It can still be merged, cloned and decoded into a regular set.
+	c := d.Clone()
+	c.Add("b")
+	r := NewORSet[string]("r2")
+	r.Merge(d)
+	if !r.Contains("a") || !c.Contains("b") {
+		t.Errorf("delta cannot be merged or cloned")
+	}
+}
+
+func TestORSetEncoding(t *testing.T) {
+	s := NewORSet[string]("r1")
+	s.Add("a", "b", "c")
+	s.Remove("b")
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromJSON := NewORSet[string]("r1")
+	if err := json.Unmarshal(data, fromJSON); err != nil {
+		t.Fatal(err)
+	}
+	data, err = s.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromBinary := NewORSet[string]("r2")
+	if err := fromBinary.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	for _, got := range []*ORSet[string]{fromJSON, fromBinary} {
+		if want := sets.From("a", "c"); !sets.Equal(got.Value(), want) {
+			t.Errorf("\nwant: %v\ngot : %v", want, got.Value())
+		}
+		got.Add("b")
+		got.Merge(s)
+		if !got.Contains("b") {
+			t.Errorf("Add() after decoding reused a removed tag")
+		}
+	}
+	if got := fromBinary.Replica(); got != "r2" {
+		t.Errorf("UnmarshalBinary() changed the replica to %v", got)
+	}
+
+	if err := fromJSON.UnmarshalJSON([]byte(`{"adds":[{"tags":{}}]}`)); err == nil {
+		t.Errorf("UnmarshalJSON() accepted invalid input")
+	}
+	if err := fromBinary.UnmarshalBinary([]byte("x")); err == nil {
+		t.Errorf("UnmarshalBinary() accepted invalid input")
+	}
+}
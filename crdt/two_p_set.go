@@ -0,0 +1,200 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package crdt
+
+import (
+	"encoding/json"
+
+	"github.com/kkhmel/sets"
+)
+
+// TwoPSet is a two-phase set: an element can be added and then removed, after which
+// it can never be added again. Removals are kept as tombstones, and a removal wins over
+// a concurrent addition. Merging takes the union of the additions and of the tombstones.
+// The zero value is an empty set ready to use.
+type TwoPSet[E comparable] struct {
+	added, removed sets.Set[E]
+	delta          *TwoPSet[E] // changes since the last call to Delta
+}
+
+// twoPSetState is the encoded form of a TwoPSet.
+type twoPSetState[E comparable] struct {
+	Added   []E `json:"added"`
+	Removed []E `json:"removed"`
+}
+
+// NewTwoPSet creates a new TwoPSet containing vals.
+//
+// Time complexity: O(len(vals)). Space complexity: O(len(vals)).
+func NewTwoPSet[E comparable](vals ...E) *TwoPSet[E] {
+	s := &TwoPSet[E]{}
+	s.Add(vals...)
+	return s
+}
+
+// Add adds the given elements to the set. Elements that have been removed are ignored.
+// Add panics if s was returned by Delta.
+//
+// Time complexity: O(len(v)). Space complexity: O(len(v)).
+func (s *TwoPSet[E]) Add(v ...E) {
+	s.mustBeMutable()
+	s.init()
+	for _, e := range v {
+		if !sets.Contains(s.added, e) && !sets.Contains(s.removed, e) {
+			sets.Insert(s.added, e)
+			sets.Insert(s.delta.added, e)
+		}
+	}
+}
+
+// Remove removes the given elements from the set for good.
+// Elements that are not present in the set are ignored.
+// Remove panics if s was returned by Delta.
+//
+// Time complexity: O(len(v)). Space complexity: O(len(v)).
+func (s *TwoPSet[E]) Remove(v ...E) {
+	s.mustBeMutable()
+	s.init()
+	for _, e := range v {
+		if s.Contains(e) {
+			sets.Insert(s.removed, e)
+			sets.Insert(s.delta.removed, e)
+		}
+	}
+}
+
+// Contains reports whether v has been added and not removed.
+//
+// Time complexity: O(1). Space complexity: O(1).
+func (s *TwoPSet[E]) Contains(v E) bool {
+	return sets.Contains(s.added, v) && !sets.Contains(s.removed, v)
+}
+
+// Len returns the number of elements in the set.
+//
+// Time complexity: O(len(s)). Space complexity: O(1).
+func (s *TwoPSet[E]) Len() int {
+	n := 0
+	for e := range s.added {
+		if !sets.Contains(s.removed, e) {
+			n++
+		}
+	}
+	return n
+}
+
+// Value returns a new Set containing the elements of the set.
+//
+// Time complexity: O(len(s)). Space complexity: O(len(s)).
+func (s *TwoPSet[E]) Value() sets.Set[E] {
+	return sets.Difference(s.added, s.removed)
+}
+
+// Merge merges the state of other, which may be a full state or a delta, into s.
+//
+// Time complexity: O(len(other)). Space complexity: O(len(other)).
+func (s *TwoPSet[E]) Merge(other *TwoPSet[E]) {
+	s.init()
+	sets.Copy(s.added, other.added)
+	sets.Copy(s.removed, other.removed)
+}
+
+// Delta returns the additions and removals since the previous call to Delta as a TwoPSet
+// that can be merged into other replicas instead of the full state.
+// Changes received through Merge are not included. The result is read-only.
+//
+// Time complexity: O(1). Space complexity: O(1).
+func (s *TwoPSet[E]) Delta() *TwoPSet[E] {
+	if s.isDelta() {
+		return newTwoPDelta[E]()
+	}
+	s.init()
+	d := s.delta
+	s.delta = newTwoPDelta[E]()
+	return d
+}
+
+// Clone returns a copy of s with an empty delta.
+//
+// Time complexity: O(len(s)). Space complexity: O(len(s)).
+func (s *TwoPSet[E]) Clone() *TwoPSet[E] {
+	return &TwoPSet[E]{added: sets.Union(s.added), removed: sets.Union(s.removed), delta: newTwoPDelta[E]()}
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s *TwoPSet[E]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.state())
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It replaces the state of s.
+func (s *TwoPSet[E]) UnmarshalJSON(data []byte) error {
+	var st twoPSetState[E]
+	if err := json.Unmarshal(data, &st); err != nil {
+		return err
+	}
+	s.setState(st)
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (s *TwoPSet[E]) MarshalBinary() ([]byte, error) {
+	return marshalBinary(s.state())
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. It replaces the state of s.
+func (s *TwoPSet[E]) UnmarshalBinary(data []byte) error {
+	var st twoPSetState[E]
+	if err := unmarshalBinary(data, &st); err != nil {
+		return err
+	}
+	s.setState(st)
+	return nil
+}
+
+func (s *TwoPSet[E]) state() twoPSetState[E] {
+	return twoPSetState[E]{Added: elements(s.added), Removed: elements(s.removed)}
+}
+
+func (s *TwoPSet[E]) setState(st twoPSetState[E]) {
+	*s = TwoPSet[E]{added: sets.FromSlice(st.Added), removed: sets.FromSlice(st.Removed), delta: newTwoPDelta[E]()}
+}
+
+// isDelta reports whether s was returned by Delta, which leaves its delta nil.
+func (s *TwoPSet[E]) isDelta() bool {
+	return s.added != nil && s.delta == nil
+}
+
+// mustBeMutable panics if s is a delta.
+func (s *TwoPSet[E]) mustBeMutable() {
+	if s.isDelta() {
+		panic("cannot modify a delta")
+	}
+}
+
+func (s *TwoPSet[E]) init() {
+	if s.added == nil {
+		s.added, s.removed, s.delta = sets.New[E](0), sets.New[E](0), newTwoPDelta[E]()
+	}
+}
+
+func newTwoPDelta[E comparable]() *TwoPSet[E] {
+	return &TwoPSet[E]{added: sets.New[E](0), removed: sets.New[E](0)}
+}
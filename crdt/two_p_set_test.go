@@ -0,0 +1,127 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package crdt
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/kkhmel/sets"
+)
+
+func TestTwoPSet(t *testing.T) {
+	var s TwoPSet[string] // the zero value is ready to use
+	s.Add("a", "b", "c")
+	s.Remove("b", "z")
+	s.Add("b", "z")
+	if got, want := s.Value(), sets.From("a", "c", "z"); !sets.Equal(got, want) {
+		t.Errorf("removed elements must not be added again\nwant: %v\ngot : %v", want, got)
+	}
+	if s.Contains("b") || !s.Contains("a") {
+		t.Errorf("Contains() = %v, %v", s.Contains("b"), s.Contains("a"))
+	}
+	if got := s.Len(); got != 3 {
+		t.Errorf("\nwant: %v\ngot : %v", 3, got)
+	}
+}
+
+func TestTwoPSetMergeDelta(t *testing.T) {
+	a := NewTwoPSet("x", "y")
+	b := a.Clone()
+	a.Delta()
+	b.Remove("x")
+	a.Add("z")
+
+	var zero TwoPSet[string]
+	zero.Merge(b.Delta())
+	zero.Merge(a.Delta())
+	if got, want := zero.Value(), sets.From("z"); !sets.Equal(got, want) {
+		t.Errorf("\nwant: %v\ngot : %v", want, got)
+	}
+
+	a.Merge(b)
+	if got, want := a.Value(), sets.From("y", "z"); !sets.Equal(got, want) {
+		t.Errorf("\nwant: %v\ngot : %v", want, got)
+	}
+	if d := a.Delta(); d.Len() != 0 || len(d.removed) != 0 {
+		t.Errorf("Delta() holds merged changes: %v, %v", d.added, d.removed)
+	}
+}
+
+func TestTwoPSetDeltaIsReadOnly(t *testing.T) {
+	s := NewTwoPSet("a", "b")
+	s.Delta()
+	s.Remove("a")
+	d := s.Delta()
+	if dd := d.Delta(); dd == nil || len(dd.added) != 0 || len(dd.removed) != 0 {
+		t.Errorf("Delta() of a delta is not empty: %v", dd)
+	}
+	modifiesDelta(t, map[string]func(){
+		"Add":    func() { d.Add("c") },
+		"Remove": func() { d.Remove("b") },
+	})
+	c := d.Clone()
+	c.Add("c")
+	r := NewTwoPSet("a")
+	r.Merge(d)
+	r.Merge(d.Delta())
+	if r.Contains("a") || !c.Contains("c") {
+		t.Errorf("delta cannot be merged or cloned")
+	}
+}
+
+func TestTwoPSetEncoding(t *testing.T) {
+	s := NewTwoPSet(1, 2, 3)
+	s.Remove(2)
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fromJSON TwoPSet[int]
+	if err := json.Unmarshal(data, &fromJSON); err != nil {
+		t.Fatal(err)
+	}
+	data, err = s.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fromBinary TwoPSet[int]
+	if err := fromBinary.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	for _, got := range []*TwoPSet[int]{&fromJSON, &fromBinary} {
+		got.Add(2)
+		if want := sets.From(1, 3); !sets.Equal(got.Value(), want) {
+			t.Errorf("\nwant: %v\ngot : %v", want, got.Value())
+		}
+		if got.Delta().Len() != 0 {
+			t.Errorf("decoded set has a delta")
+		}
+	}
+
+	if err := fromJSON.UnmarshalJSON([]byte(`[]`)); err == nil {
+		t.Errorf("UnmarshalJSON() accepted invalid input")
+	}
+	if err := fromBinary.UnmarshalBinary(nil); err == nil {
+		t.Errorf("UnmarshalBinary() accepted invalid input")
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package diskset provides a read-only sorted set of strings stored in a file, for large static sets
+// such as deny-lists that would take too much memory as a Set[string].
+//
+// A Writer builds the file from keys added in increasing order. A Reader serves Len, Contains and
+// iteration over key ranges with positioned reads of the file, keeping only a few numbers in memory,
+// so that a file of any size can be queried right after opening it. Intersection and Difference
+// combine a file with an in-memory set.
+//
+// The file holds the keys in blocks of consecutive keys, in which every key after the first stores
+// only the bytes that differ from the previous key (front coding), followed by the offsets of the
+// blocks and a footer with the number of keys:
+//
+//	magic | block ... | block offsets (uint64) ... | count (uint64) | blocks (uint64) | index offset (uint64) | magic
+//
+// All integers in the index and the footer are little-endian.
+package diskset
+
+import "errors"
+
+const (
+	magic      = "SETDSK\x00\x01"
+	footerSize = 4 * 8 // count, blocks, index offset and magic
+
+	// blockKeys is the number of keys in each block but the last.
+	blockKeys = 64
+)
+
+// MaxKeyLen is the maximum length of a key in bytes.
+const MaxKeyLen = 1 << 20
+
+var (
+	// ErrUnsorted is returned by Writer.Add for a key that is not greater than the previous key.
+	ErrUnsorted = errors.New("diskset: keys must be added in strictly increasing order")
+	// ErrKeyTooLong is returned by Writer.Add for a key longer than MaxKeyLen.
+	ErrKeyTooLong = errors.New("diskset: key is too long")
+	// ErrClosed is returned by Writer.Add after Writer.Close.
+	ErrClosed = errors.New("diskset: writer is closed")
+	// ErrCorrupt is returned when reading a file that is not a valid set.
+	ErrCorrupt = errors.New("diskset: corrupt file")
+)
@@ -0,0 +1,58 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package diskset_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/kkhmel/sets"
+	"github.com/kkhmel/sets/diskset"
+)
+
+func Example() {
+	name := filepath.Join(os.TempDir(), "denylist.set")
+	defer os.Remove(name)
+
+	f, _ := os.Create(name)
+	_ = diskset.Write(f, sets.From("spam.example", "ads.example", "tracker.example"))
+	_ = f.Close()
+
+	deny, _ := diskset.Open(name)
+	defer deny.Close()
+
+	ok, _ := deny.Contains("ads.example")
+	fmt.Println(deny.Len(), ok)
+
+	allowed, _ := diskset.Difference(sets.From("ads.example", "news.example"), deny)
+	fmt.Println(allowed)
+
+	for key := range deny.Range("a", "t") {
+		fmt.Println(key)
+	}
+
+	// Output:
+	// 3 true
+	// {news.example}
+	// ads.example
+	// spam.example
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package diskset
+
+import (
+	"slices"
+
+	"github.com/kkhmel/sets"
+)
+
+// Intersection returns a new set containing the elements of s that are present in r.
+//
+// Time complexity: O(len(s) * log(len(r))) for small s, O(len(s) * log(len(s)) + size of r) otherwise.
+// Space complexity: O(len(s)).
+func Intersection[E ~string](r *Reader, s sets.Set[E]) (sets.Set[E], error) {
+	result := sets.New[E](0)
+	err := lookup(r, s, func(e E, found bool) {
+		if found {
+			sets.Insert(result, e)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Difference returns a new set containing the elements of s that are not present in r,
+// e.g. the requested keys that are not on a deny-list.
+//
+// Time complexity: O(len(s) * log(len(r))) for small s, O(len(s) * log(len(s)) + size of r) otherwise.
+// Space complexity: O(len(s)).
+func Difference[E ~string](s sets.Set[E], r *Reader) (sets.Set[E], error) {
+	result := sets.New[E](0)
+	err := lookup(r, s, func(e E, found bool) {
+		if !found {
+			sets.Insert(result, e)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// lookup calls f with every element of s and whether it is present in r. It looks up the
+// elements one by one if there are fewer of them than blocks in r, and otherwise merges
+// the sorted elements with a scan of r.
+func lookup[E ~string](r *Reader, s sets.Set[E], f func(e E, found bool)) error {
+	if len(s) < r.blocks {
+		for e := range s {
+			found, err := r.Contains(string(e))
+			if err != nil {
+				return err
+			}
+			f(e, found)
+		}
+		return nil
+	}
+	elements := slices.Sorted(sets.All(s))
+	i := 0
+	err := r.scan(0, r.blocks, func(k string) bool {
+		for ; i < len(elements) && string(elements[i]) <= k; i++ {
+			f(elements[i], string(elements[i]) == k)
+		}
+		return i < len(elements)
+	})
+	if err != nil {
+		return err
+	}
+	for ; i < len(elements); i++ {
+		f(elements[i], false)
+	}
+	return nil
+}
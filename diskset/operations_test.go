@@ -0,0 +1,80 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package diskset
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/kkhmel/sets"
+)
+
+func TestIntersectionDifference(t *testing.T) {
+	keys := domains(1000)
+	r := newReader(t, build(t, keys))
+	stored := sets.FromSlice(keys)
+
+	// Small sets are looked up key by key, large ones are merged with a scan of the file.
+	for _, n := range []int{0, 3, r.blocks - 1, r.blocks, 400, 3000} {
+		t.Run(fmt.Sprint(n), func(t *testing.T) {
+			s := sets.New[string](n)
+			for i := range n {
+				// Half of the keys are stored, including keys before and after all stored ones.
+				sets.Insert(s, fmt.Sprintf("host-%05d.example.com", i*(2000+1)/max(n-1, 1)))
+			}
+			sets.Insert(s, "a", "zzz")
+
+			inter, err := Intersection(r, s)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := sets.Intersection(s, stored); !sets.Equal(inter, want) {
+				t.Errorf("Intersection()\nwant: %v\ngot : %v", want, inter)
+			}
+			diff, err := Difference(s, r)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := sets.Difference(s, stored); !sets.Equal(diff, want) {
+				t.Errorf("Difference()\nwant: %v\ngot : %v", want, diff)
+			}
+		})
+	}
+
+	type domain string
+	got, err := Difference(sets.From[domain]("host-00002.example.com", "host-00003.example.com"), r)
+	if want := sets.From[domain]("host-00003.example.com"); err != nil || !sets.Equal(got, want) {
+		t.Errorf("\nwant: %v\ngot : %v, %v", want, got, err)
+	}
+}
+
+func TestOperationsCorrupt(t *testing.T) {
+	data := patch(build(t, domains(1000)), len(magic), 0xff)
+	r := newReader(t, data)
+	large := sets.FromSlice(domains(100))
+	if _, err := Intersection(r, large); !errors.Is(err, ErrCorrupt) {
+		t.Errorf("\nwant: %v\ngot : %v", ErrCorrupt, err)
+	}
+	if _, err := Difference(large, r); !errors.Is(err, ErrCorrupt) {
+		t.Errorf("\nwant: %v\ngot : %v", ErrCorrupt, err)
+	}
+}
@@ -0,0 +1,281 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package diskset
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"iter"
+	"os"
+	"sort"
+)
+
+// firstKeyRead is the number of bytes read to find the first key of a block,
+// which is enough for keys of typical lengths.
+const firstKeyRead = 128
+
+// Reader reads a set file. It is safe for concurrent use if the underlying io.ReaderAt is.
+type Reader struct {
+	r      io.ReaderAt
+	n      int
+	blocks int
+	index  int64 // offset of the block offsets, which is the end of the last block
+	closer io.Closer
+}
+
+// NewReader returns a Reader for the set file of the given size read from r.
+// If the file is not a valid set file, the error is ErrCorrupt.
+//
+// Time complexity: O(1). Space complexity: O(1).
+func NewReader(r io.ReaderAt, size int64) (*Reader, error) {
+	if size < int64(len(magic)+footerSize) {
+		return nil, ErrCorrupt
+	}
+	rd := &Reader{r: r}
+	head, err := rd.read(0, len(magic))
+	if err != nil {
+		return nil, err
+	}
+	footer, err := rd.read(size-footerSize, footerSize)
+	if err != nil {
+		return nil, err
+	}
+	n := binary.LittleEndian.Uint64(footer)
+	blocks := binary.LittleEndian.Uint64(footer[8:])
+	index := binary.LittleEndian.Uint64(footer[16:])
+	// Every key takes at least one byte, and the block offsets fill the space
+	// between the index offset and the footer.
+	if string(head) != magic || string(footer[24:]) != magic || n > uint64(size) || blocks != (n+blockKeys-1)/blockKeys ||
+		index < uint64(len(magic)) || index > uint64(size) || index+blocks*8 != uint64(size-footerSize) {
+		return nil, ErrCorrupt
+	}
+	rd.n, rd.blocks, rd.index = int(n), int(blocks), int64(index)
+	return rd, nil
+}
+
+// Open opens the named set file. The Reader must be closed with Close.
+//
+// Time complexity: O(1). Space complexity: O(1).
+func Open(name string) (*Reader, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	info, err := f.Stat()
+	if err == nil {
+		var r *Reader
+		if r, err = NewReader(f, info.Size()); err == nil {
+			r.closer = f
+			return r, nil
+		}
+	}
+	_ = f.Close()
+	return nil, err
+}
+
+// Close closes the file opened by Open. For a Reader returned by NewReader, it does nothing.
+func (r *Reader) Close() error {
+	if r.closer == nil {
+		return nil
+	}
+	return r.closer.Close()
+}
+
+// Len returns the number of keys in the set.
+//
+// Time complexity: O(1). Space complexity: O(1).
+func (r *Reader) Len() int {
+	return r.n
+}
+
+// Contains reports whether key is present in the set.
+//
+// Time complexity: O(log(len(r)) + len(key)), with O(log(len(r))) reads.
+// Space complexity: O(B). B is the size of a block.
+func (r *Reader) Contains(key string) (bool, error) {
+	b, err := r.findBlock(key)
+	if err != nil || b < 0 {
+		return false, err
+	}
+	found := false
+	err = r.scan(b, b+1, func(k string) bool {
+		found = k == key
+		return k < key
+	})
+	return found, err
+}
+
+// All returns an iterator over the keys of the set in increasing order.
+// If reading fails, the iterator yields the error and stops.
+//
+// Creation: O(1) time, O(1) space.
+// Iteration: O(size of the file) time, O(B) space. B is the size of a block.
+func (r *Reader) All() iter.Seq2[string, error] {
+	return func(yield func(string, error) bool) {
+		stopped := false
+		err := r.scan(0, r.blocks, func(k string) bool {
+			stopped = !yield(k, nil)
+			return !stopped
+		})
+		if err != nil && !stopped {
+			yield("", err)
+		}
+	}
+}
+
+// Range returns an iterator over the keys k with lo <= k < hi in increasing order.
+// If reading fails, the iterator yields the error and stops.
+//
+// Creation: O(1) time, O(1) space.
+// Iteration: O(log(len(r)) + N) time, O(B) space. N is the size of the keys in the range, B is the size of a block.
+func (r *Reader) Range(lo, hi string) iter.Seq2[string, error] {
+	return func(yield func(string, error) bool) {
+		if lo >= hi {
+			return
+		}
+		b, err := r.findBlock(lo)
+		stopped := false
+		if err == nil {
+			err = r.scan(max(b, 0), r.blocks, func(k string) bool {
+				if k < lo {
+					return true
+				}
+				stopped = k >= hi || !yield(k, nil)
+				return !stopped
+			})
+		}
+		if err != nil && !stopped {
+			yield("", err)
+		}
+	}
+}
+
+// findBlock returns the last block whose first key is not greater than key, or -1 if there is none.
+func (r *Reader) findBlock(key string) (int, error) {
+	var err error
+	i := sort.Search(r.blocks, func(b int) bool {
+		if err != nil {
+			return true
+		}
+		var first string
+		first, err = r.firstKey(b)
+		return first > key
+	})
+	return i - 1, err
+}
+
+// firstKey returns the first key of block b.
+func (r *Reader) firstKey(b int) (string, error) {
+	start, end, err := r.block(b)
+	if err != nil {
+		return "", err
+	}
+	data, err := r.read(start, int(min(end-start, firstKeyRead)))
+	if err != nil {
+		return "", err
+	}
+	n, k := binary.Uvarint(data)
+	if k <= 0 || n > MaxKeyLen || int64(k)+int64(n) > end-start {
+		return "", ErrCorrupt
+	}
+	if k+int(n) <= len(data) {
+		return string(data[k : k+int(n)]), nil
+	}
+	data, err = r.read(start+int64(k), int(n))
+	return string(data), err
+}
+
+// scan calls f with the keys of blocks from to to-1 in order until f returns false.
+func (r *Reader) scan(from, to int, f func(string) bool) error {
+	var key []byte
+	for b := from; b < to; b++ {
+		start, end, err := r.block(b)
+		if err != nil {
+			return err
+		}
+		data, err := r.read(start, int(end-start))
+		if err != nil {
+			return err
+		}
+		count := blockKeys
+		if b == r.blocks-1 {
+			count = r.n - b*blockKeys
+		}
+		for i := range count {
+			shared := uint64(0)
+			if i > 0 {
+				var k int
+				shared, k = binary.Uvarint(data)
+				if k <= 0 || shared > uint64(len(key)) {
+					return ErrCorrupt
+				}
+				data = data[k:]
+			}
+			n, k := binary.Uvarint(data)
+			if k <= 0 || n > uint64(len(data)-k) {
+				return ErrCorrupt
+			}
+			key = append(key[:shared], data[k:k+int(n)]...)
+			data = data[k+int(n):]
+			if !f(string(key)) {
+				return nil
+			}
+		}
+		if len(data) != 0 {
+			return ErrCorrupt
+		}
+	}
+	return nil
+}
+
+// block returns the start and end offsets of block b.
+func (r *Reader) block(b int) (start, end int64, err error) {
+	n := 16
+	if b == r.blocks-1 {
+		n = 8
+	}
+	data, err := r.read(r.index+int64(b)*8, n)
+	if err != nil {
+		return 0, 0, err
+	}
+	start, end = int64(binary.LittleEndian.Uint64(data)), r.index
+	if n == 16 {
+		end = int64(binary.LittleEndian.Uint64(data[8:]))
+	}
+	if start < int64(len(magic)) || start >= end || end > r.index {
+		return 0, 0, ErrCorrupt
+	}
+	return start, end, nil
+}
+
+// read reads n bytes at off.
+func (r *Reader) read(off int64, n int) ([]byte, error) {
+	buf := make([]byte, n)
+	m, err := r.r.ReadAt(buf, off)
+	if m == n {
+		return buf, nil
+	}
+	if errors.Is(err, io.EOF) {
+		err = ErrCorrupt
+	}
+	return nil, err
+}
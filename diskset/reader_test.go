@@ -0,0 +1,337 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package diskset
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/kkhmel/sets"
+)
+
+// build returns the set file of keys, which must be sorted.
+func build(t *testing.T, keys []string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	for _, k := range keys {
+		if err := w.Add(k); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func newReader(t *testing.T, data []byte) *Reader {
+	t.Helper()
+	r, err := NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+// domains returns n sorted keys with shared prefixes.
+func domains(n int) []string {
+	keys := make([]string, n)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("host-%05d.example.com", i*2)
+	}
+	return keys
+}
+
+func collect(t *testing.T, seq func(func(string, error) bool)) []string {
+	t.Helper()
+	var keys []string
+	for k, err := range seq {
+		if err != nil {
+			t.Fatal(err)
+		}
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+func TestReader(t *testing.T) {
+	for _, n := range []int{0, 1, blockKeys - 1, blockKeys, blockKeys + 1, 1000} {
+		t.Run(fmt.Sprint(n), func(t *testing.T) {
+			keys := domains(n)
+			r := newReader(t, build(t, keys))
+			if got := r.Len(); got != n {
+				t.Errorf("\nwant: %v\ngot : %v", n, got)
+			}
+			if got := collect(t, r.All()); !slices.Equal(got, keys) {
+				t.Errorf("All() yielded %d keys, want %d", len(got), n)
+			}
+			for i, k := range keys {
+				if ok, err := r.Contains(k); !ok || err != nil {
+					t.Errorf("Contains(%q) = %v, %v", k, ok, err)
+				}
+				// Keys between the stored ones, before the first and after the last.
+				for _, absent := range []string{fmt.Sprintf("host-%05d.example.com", i*2+1), k + "x", k[:len(k)-1]} {
+					if ok, err := r.Contains(absent); ok || err != nil {
+						t.Errorf("Contains(%q) = %v, %v", absent, ok, err)
+					}
+				}
+			}
+			if ok, _ := r.Contains(""); ok {
+				t.Errorf("Contains(\"\") = true")
+			}
+		})
+	}
+}
+
+func TestReaderRange(t *testing.T) {
+	keys := domains(500)
+	r := newReader(t, build(t, keys))
+	tests := []struct{ lo, hi string }{
+		{"", "zzz"},
+		{"host-00100", "host-00200"},
+		{"host-00100.example.com", "host-00200.example.com"},
+		{"host-00999", "host-01000"},
+		{"a", "b"},
+		{"host-00300", "host-00300"},
+		{"host-00300", "host-00100"},
+		{"host-00990", "zzz"},
+	}
+	for _, tt := range tests {
+		var want []string
+		for _, k := range keys {
+			if tt.lo <= k && k < tt.hi {
+				want = append(want, k)
+			}
+		}
+		if got := collect(t, r.Range(tt.lo, tt.hi)); !slices.Equal(got, want) {
+			t.Errorf("Range(%q, %q)\nwant: %v\ngot : %v", tt.lo, tt.hi, want, got)
+		}
+	}
+
+	for range r.Range("", "zzz") {
+		break
+	}
+	for range r.All() {
+		break
+	}
+}
+
+func TestReaderLongKeys(t *testing.T) {
+	keys := []string{strings.Repeat("a", 1000), strings.Repeat("b", 300)}
+	for i := range 2 * blockKeys {
+		keys = append(keys, fmt.Sprint("c", strings.Repeat("x", 200), i+1000))
+	}
+	r := newReader(t, build(t, keys))
+	if got := collect(t, r.All()); !slices.Equal(got, keys) {
+		t.Errorf("All() did not return the keys")
+	}
+	for _, k := range keys {
+		if ok, err := r.Contains(k); !ok || err != nil {
+			t.Errorf("Contains(%.10q...) = %v, %v", k, ok, err)
+		}
+	}
+}
+
+func TestOpen(t *testing.T) {
+	dir := t.TempDir()
+	name := filepath.Join(dir, "deny.set")
+	f, err := os.Create(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Write(f, sets.From("b", "a", "c")); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	r, err := Open(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, _ := r.Contains("b"); !ok || r.Len() != 3 {
+		t.Errorf("Contains() = %v; Len() = %v", ok, r.Len())
+	}
+	if err := r.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := newReader(t, build(t, nil)).Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Open(filepath.Join(dir, "missing")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("\nwant: %v\ngot : %v", os.ErrNotExist, err)
+	}
+	_ = os.WriteFile(name, []byte("not a set file, but long enough for a footer"), 0o644)
+	if _, err := Open(name); !errors.Is(err, ErrCorrupt) {
+		t.Errorf("\nwant: %v\ngot : %v", ErrCorrupt, err)
+	}
+	if err := Write(&failingWriter{}, sets.From("a")); !errors.Is(err, errWrite) {
+		t.Errorf("\nwant: %v\ngot : %v", errWrite, err)
+	}
+}
+
+// patch returns a copy of data with b written at off, where a negative off counts from the end.
+func patch(data []byte, off int, b ...byte) []byte {
+	data = bytes.Clone(data)
+	if off < 0 {
+		off += len(data)
+	}
+	copy(data[off:], b)
+	return data
+}
+
+func TestNewReaderCorrupt(t *testing.T) {
+	valid := build(t, domains(100))
+	footer := len(valid) - footerSize
+	u64 := func(v uint64) []byte { return binary.LittleEndian.AppendUint64(nil, v) }
+	tests := map[string][]byte{
+		"too short":    valid[:footerSize],
+		"header":       patch(valid, 0, 'X'),
+		"footer magic": patch(valid, -1, 'X'),
+		"count":        patch(valid, footer, u64(200)...),
+		"huge count":   patch(valid, footer, u64(1<<62)...),
+		"blocks":       patch(valid, footer+8, u64(3)...),
+		"index":        patch(valid, footer+16, u64(4)...),
+		"huge index":   patch(valid, footer+16, u64(1<<63)...),
+		"index length": patch(valid, footer+16, u64(100)...),
+	}
+	for name, data := range tests {
+		if _, err := NewReader(bytes.NewReader(data), int64(len(data))); !errors.Is(err, ErrCorrupt) {
+			t.Errorf("%s:\nwant: %v\ngot : %v", name, ErrCorrupt, err)
+		}
+	}
+	// A size larger than the data makes the reads fail.
+	if _, err := NewReader(bytes.NewReader(valid), int64(len(valid)+1)); !errors.Is(err, ErrCorrupt) {
+		t.Errorf("\nwant: %v\ngot : %v", ErrCorrupt, err)
+	}
+}
+
+func TestReaderCorruptBlocks(t *testing.T) {
+	keys := []string{"alpha", "beta", "gamma"}
+	valid := build(t, keys)
+	index := len(valid) - footerSize - 8
+	first := len(magic)
+	tests := map[string][]byte{
+		"block offset":        patch(valid, index, 2),
+		"first key length":    patch(valid, first, 0xff),
+		"unterminated varint": patch(valid, first, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff),
+		"shared prefix":       patch(valid, first+6, 9),
+		"suffix length":       patch(valid, first+7, 50),
+		"trailing data":       patch(valid, first+len("\x05alpha\x00\x04beta\x00"), 4),
+	}
+	for name, data := range tests {
+		r, err := NewReader(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		_, err = r.Contains("beta")
+		for _, err2 := range r.All() {
+			if err2 != nil {
+				err = errors.Join(err, err2)
+			}
+		}
+		if !errors.Is(err, ErrCorrupt) {
+			t.Errorf("%s:\nwant: %v\ngot : %v", name, ErrCorrupt, err)
+		}
+	}
+}
+
+// faultyReaderAt fails all reads after the first n.
+type faultyReaderAt struct {
+	data []byte
+	n    int
+}
+
+var errRead = errors.New("read failed")
+
+func (r *faultyReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	if r.n == 0 {
+		return 0, errRead
+	}
+	r.n--
+	return bytes.NewReader(r.data).ReadAt(p, off)
+}
+
+func TestReaderReadErrors(t *testing.T) {
+	data := build(t, domains(1000))
+	keys := sets.FromSlice(domains(200))
+	ops := map[string]func(r *Reader) error{
+		"Contains": func(r *Reader) error {
+			_, err := r.Contains("host-00500.example.com")
+			return err
+		},
+		"All": func(r *Reader) error {
+			for _, err := range r.All() {
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		"Range": func(r *Reader) error {
+			for _, err := range r.Range("host-00500", "host-01500") {
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		"Intersection": func(r *Reader) error {
+			_, err := Intersection(r, sets.From("host-00002.example.com", "x"))
+			return err
+		},
+		"Difference": func(r *Reader) error {
+			_, err := Difference(keys, r)
+			return err
+		},
+	}
+	for name, op := range ops {
+		// Fail every read in turn, until the operation completes.
+		for n := 0; ; n++ {
+			fr := &faultyReaderAt{data: data, n: 2}
+			r, err := NewReader(fr, int64(len(data)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			fr.n = n
+			err = op(r)
+			if err == nil {
+				break
+			}
+			if !errors.Is(err, errRead) {
+				t.Errorf("%s with %d reads:\nwant: %v\ngot : %v", name, n, errRead, err)
+			}
+		}
+	}
+	for n := range 2 {
+		if _, err := NewReader(&faultyReaderAt{data: data, n: n}, int64(len(data))); !errors.Is(err, errRead) {
+			t.Errorf("\nwant: %v\ngot : %v", errRead, err)
+		}
+	}
+}
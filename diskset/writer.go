@@ -0,0 +1,147 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package diskset
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"slices"
+
+	"github.com/kkhmel/sets"
+)
+
+// Writer writes a set file to an underlying writer.
+type Writer struct {
+	w       *bufio.Writer
+	off     uint64   // number of bytes written
+	n       int      // number of keys added
+	last    string   // previous key
+	offsets []uint64 // offsets of the blocks
+	buf     []byte
+	err     error // sticky write error
+	closed  bool
+}
+
+// NewWriter returns a Writer that writes a set file to w.
+//
+// Time complexity: O(1). Space complexity: O(1).
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: bufio.NewWriter(w)}
+}
+
+// Add adds key to the set. Keys must be added in strictly increasing order;
+// otherwise, Add returns ErrUnsorted. A key longer than MaxKeyLen is rejected with ErrKeyTooLong.
+// Rejected keys are not written, and the Writer remains usable. After an error writing
+// to the underlying writer, the Writer is unusable and all further calls return that error.
+// After Close, Add returns ErrClosed.
+//
+// Time complexity: O(len(key)). Space complexity: O(1).
+func (w *Writer) Add(key string) error {
+	if w.closed {
+		return ErrClosed
+	}
+	if w.err != nil {
+		return w.err
+	}
+	if w.n > 0 && key <= w.last {
+		return ErrUnsorted
+	}
+	if len(key) > MaxKeyLen {
+		return ErrKeyTooLong
+	}
+	if w.n == 0 {
+		w.write([]byte(magic))
+	}
+	buf := w.buf[:0]
+	if w.n%blockKeys == 0 {
+		w.offsets = append(w.offsets, w.off)
+		buf = binary.AppendUvarint(buf, uint64(len(key)))
+		buf = append(buf, key...)
+	} else {
+		shared := commonPrefix(w.last, key)
+		buf = binary.AppendUvarint(buf, uint64(shared))
+		buf = binary.AppendUvarint(buf, uint64(len(key)-shared))
+		buf = append(buf, key[shared:]...)
+	}
+	w.buf = buf
+	w.write(buf)
+	w.n++
+	w.last = key
+	return w.err
+}
+
+// Close writes the index and the footer and flushes the file. It does not close the underlying writer.
+// Calling Close again writes nothing and returns the result of the first call.
+//
+// Time complexity: O(N). Space complexity: O(N). N is the number of blocks.
+func (w *Writer) Close() error {
+	if w.closed || w.err != nil {
+		w.closed = true
+		return w.err
+	}
+	w.closed = true
+	if w.n == 0 {
+		w.write([]byte(magic))
+	}
+	index := w.off
+	buf := make([]byte, 0, len(w.offsets)*8+footerSize)
+	for _, off := range w.offsets {
+		buf = binary.LittleEndian.AppendUint64(buf, off)
+	}
+	buf = binary.LittleEndian.AppendUint64(buf, uint64(w.n))
+	buf = binary.LittleEndian.AppendUint64(buf, uint64(len(w.offsets)))
+	buf = binary.LittleEndian.AppendUint64(buf, index)
+	buf = append(buf, magic...)
+	w.write(buf)
+	if w.err == nil {
+		w.err = w.w.Flush()
+	}
+	return w.err
+}
+
+func (w *Writer) write(p []byte) {
+	if w.err == nil {
+		_, w.err = w.w.Write(p)
+		w.off += uint64(len(p))
+	}
+}
+
+// Write writes the elements of s to w as a set file.
+//
+// Time complexity: O(len(s) * log(len(s))). Space complexity: O(len(s)).
+func Write[E ~string](w io.Writer, s sets.Set[E]) error {
+	sw := NewWriter(w)
+	for _, e := range slices.Sorted(sets.All(s)) {
+		if err := sw.Add(string(e)); err != nil {
+			return err
+		}
+	}
+	return sw.Close()
+}
+
+func commonPrefix(a, b string) int {
+	n := 0
+	for n < len(a) && n < len(b) && a[n] == b[n] {
+		n++
+	}
+	return n
+}
@@ -0,0 +1,139 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package diskset
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/kkhmel/sets"
+)
+
+// failingWriter fails once more than n bytes have been written.
+type failingWriter struct{ n int }
+
+var errWrite = errors.New("write failed")
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if len(p) > w.n {
+		return 0, errWrite
+	}
+	w.n -= len(p)
+	return len(p), nil
+}
+
+func TestWriterFormat(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	for _, k := range []string{"", "apple", "applet", "banana"} {
+		if err := w.Add(k); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	want := magic +
+		"\x00" + // ""
+		"\x00\x05apple" + // shares nothing with ""
+		"\x05\x01t" + // applet shares "apple"
+		"\x00\x06banana" +
+		"\x08\x00\x00\x00\x00\x00\x00\x00" + // block offset
+		"\x04\x00\x00\x00\x00\x00\x00\x00" + // count
+		"\x01\x00\x00\x00\x00\x00\x00\x00" + // blocks
+		"\x1b\x00\x00\x00\x00\x00\x00\x00" + // index offset
+		magic
+	if got := buf.String(); got != want {
+		t.Errorf("\nwant: %q\ngot : %q", want, got)
+	}
+}
+
+func TestWriterErrors(t *testing.T) {
+	w := NewWriter(io.Discard)
+	_ = w.Add("b")
+	if err := w.Add("b"); !errors.Is(err, ErrUnsorted) {
+		t.Errorf("\nwant: %v\ngot : %v", ErrUnsorted, err)
+	}
+	if err := w.Add("a"); !errors.Is(err, ErrUnsorted) {
+		t.Errorf("\nwant: %v\ngot : %v", ErrUnsorted, err)
+	}
+	if err := w.Add(strings.Repeat("z", MaxKeyLen+1)); !errors.Is(err, ErrKeyTooLong) {
+		t.Errorf("\nwant: %v\ngot : %v", ErrKeyTooLong, err)
+	}
+	// Rejected keys leave the Writer usable.
+	if err := w.Add("c"); err != nil {
+		t.Errorf("\nwant: %v\ngot : %v", nil, err)
+	}
+
+	// Write errors are sticky.
+	w = NewWriter(&failingWriter{n: 10})
+	var err error
+	for i := 0; err == nil; i++ {
+		err = w.Add(strings.Repeat("k", 1000+i))
+	}
+	if !errors.Is(err, errWrite) || !errors.Is(w.Add("zzz"), errWrite) || !errors.Is(w.Close(), errWrite) {
+		t.Errorf("\nwant: %v\ngot : %v", errWrite, err)
+	}
+	if err := NewWriter(&failingWriter{}).Close(); !errors.Is(err, errWrite) {
+		t.Errorf("\nwant: %v\ngot : %v", errWrite, err)
+	}
+}
+
+func TestWriterClose(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	_ = w.Add("a")
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	want := buf.String()
+	if err := w.Close(); err != nil {
+		t.Errorf("\nwant: %v\ngot : %v", nil, err)
+	}
+	if err := w.Add("b"); !errors.Is(err, ErrClosed) {
+		t.Errorf("\nwant: %v\ngot : %v", ErrClosed, err)
+	}
+	if got := buf.String(); got != want {
+		t.Errorf("\nwant: %q\ngot : %q", want, got)
+	}
+
+	// A failed Close keeps failing.
+	w = NewWriter(&failingWriter{})
+	if err := w.Close(); !errors.Is(err, errWrite) {
+		t.Errorf("\nwant: %v\ngot : %v", errWrite, err)
+	}
+	if err := w.Close(); !errors.Is(err, errWrite) {
+		t.Errorf("\nwant: %v\ngot : %v", errWrite, err)
+	}
+	if err := w.Add("a"); !errors.Is(err, ErrClosed) {
+		t.Errorf("\nwant: %v\ngot : %v", ErrClosed, err)
+	}
+}
+
+func TestWriteTooLong(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, sets.From(strings.Repeat("x", MaxKeyLen+1))); !errors.Is(err, ErrKeyTooLong) {
+		t.Errorf("\nwant: %v\ngot : %v", ErrKeyTooLong, err)
+	}
+}
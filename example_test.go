@@ -21,9 +21,15 @@
 package sets_test
 
 import (
+	"bytes"
 	"fmt"
+	"hash/maphash"
+	"net/netip"
+	"strings"
+	"time"
 
 	"github.com/kkhmel/sets"
+	"github.com/kkhmel/sets/anyset"
 )
 
 func Example() {
@@ -149,3 +155,220 @@ func ExampleChunk() {
 	// Chunk 2 size: 3
 	// Chunk 3 size: 1
 }
+
+func ExampleObservable() {
+	tags := sets.NewObservable[string](0)
+	unsubscribe := tags.Subscribe(func(c sets.Change[string]) {
+		fmt.Println("added:", c.Added, "removed:", c.Removed)
+	})
+	defer unsubscribe()
+
+	tags.Insert("go", "sets")
+	tags.Replace("sets", "maps")
+	tags.Delete("rust") // no change, no event
+
+	// Output:
+	// added: {go, sets} removed: {}
+	// added: {maps} removed: {sets}
+}
+
+func ExampleExpiring() {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	seen := sets.NewExpiring[string](func() time.Time { return now })
+	seen.OnExpire(func(id string) { fmt.Println("expired:", id) })
+
+	seen.Insert("req-1", time.Minute)
+	seen.Insert("req-2", time.Hour)
+
+	now = now.Add(30 * time.Minute)
+	fmt.Println(seen.Contains("req-1"))
+	fmt.Println(seen.Snapshot())
+
+	// Output:
+	// expired: req-1
+	// false
+	// {req-2}
+}
+
+func ExampleBounded() {
+	cache := sets.NewBounded(2, sets.NewLRU[string]())
+	cache.OnEvict(func(e string) { fmt.Println("evicted:", e) })
+
+	cache.Insert("a", "b")
+	cache.Contains("a") // "a" becomes the most recently used element
+	cache.Insert("c")
+
+	fmt.Println(cache.Clone())
+	fmt.Printf("%+v\n", cache.Stats())
+
+	// Output:
+	// evicted: b
+	// {a, c}
+	// {Hits:1 Misses:0 Evictions:1}
+}
+
+func ExampleIntervalSet() {
+	ports := sets.NewIntervalSet(sets.Interval[int]{Lo: 8000, Hi: 8100})
+	ports.AddRange(443, 444)
+	ports.AddRange(8100, 8200) // adjacent ranges are coalesced
+	ports.RemoveRange(8080, 8081)
+
+	fmt.Println(ports)
+	fmt.Println(ports.Contains(8080), ports.Contains(8150))
+	fmt.Println(ports.Complement(0, 1024))
+
+	// Output:
+	// {[443, 444), [8000, 8080), [8081, 8200)}
+	// false true
+	// {[0, 443), [444, 1024)}
+}
+
+func ExampleIPSet() {
+	allowed := sets.NewIPSet(
+		netip.MustParsePrefix("10.0.0.0/25"),
+		netip.MustParsePrefix("10.0.0.128/25"), // merged with the adjacent prefix
+		netip.MustParsePrefix("2001:db8::/32"),
+	)
+	allowed.RemoveAddr(netip.MustParseAddr("10.0.0.1"))
+
+	fmt.Println(allowed)
+	fmt.Println(allowed.Contains(netip.MustParseAddr("10.0.0.200")))
+
+	// Output:
+	// {10.0.0.0/32, 10.0.0.2/31, 10.0.0.4/30, 10.0.0.8/29, 10.0.0.16/28, 10.0.0.32/27, 10.0.0.64/26, 10.0.0.128/25, 2001:db8::/32}
+	// true
+}
+
+func ExampleTrieSet() {
+	routes := sets.NewTrieSet("/api/v1/users", "/api/v1/orders", "/api/v2/users", "/static")
+
+	for route := range routes.WithPrefix("/api/v1/") {
+		fmt.Println(route)
+	}
+	fmt.Println(routes.LongestPrefixOf("/static/css/site.css"))
+
+	// Output:
+	// /api/v1/orders
+	// /api/v1/users
+	// /static true
+}
+
+func ExampleNormalized() {
+	emails := sets.NewNormalized(sets.ChainNormalizers(strings.TrimSpace, sets.FoldCase))
+	emails.Insert("Alice@Example.com", "alice@example.com ", "bob@example.com")
+
+	fmt.Println(emails)
+	fmt.Println(emails.Contains("ALICE@EXAMPLE.COM"))
+	fmt.Printf("%q\n", emails.Collisions())
+
+	// Output:
+	// {Alice@Example.com, bob@example.com}
+	// true
+	// map["Alice@Example.com":["alice@example.com "]]
+}
+
+func ExampleKeyed() {
+	type user struct {
+		ID    int
+		Roles []string
+	}
+	users := sets.NewKeyed(func(u user) int { return u.ID },
+		user{ID: 1, Roles: []string{"admin"}},
+		user{ID: 2, Roles: []string{"dev"}},
+		user{ID: 1, Roles: []string{"ignored"}},
+	)
+	admins := sets.NewKeyed(func(u user) int { return u.ID }, user{ID: 1})
+
+	fmt.Println(users)
+	u, _ := users.Get(1)
+	fmt.Println(u.Roles)
+	fmt.Println(users.Difference(admins).Keys())
+
+	// Output:
+	// {1, 2}
+	// [admin]
+	// {2}
+}
+
+func ExampleHashSet() {
+	seed := maphash.MakeSeed()
+	s := sets.NewHashSet(
+		func(b []byte) uint64 { return maphash.Bytes(seed, b) },
+		bytes.Equal,
+		[]byte("go"), []byte("go"), []byte("rust"),
+	)
+
+	fmt.Println(s.Len())
+	fmt.Println(s.Contains([]byte("rust")))
+
+	// Output:
+	// 2
+	// true
+}
+
+func ExampleAdapt() {
+	names := sets.From("go", "rust")
+	prefixed := sets.NewTrieSet("go", "gopher", "zig")
+
+	common := sets.New[string](0)
+	anyset.Intersection(sets.Adapt(common), sets.Adapt(names), prefixed)
+	fmt.Println(common)
+
+	// Output:
+	// {go}
+}
+
+func ExampleTransactional() {
+	seats := sets.NewTransactional("1A", "1B", "2A")
+
+	book := func(want ...string) error {
+		tx := seats.Begin()
+		defer tx.Rollback() //nolint:errcheck // returns ErrTxDone after Commit
+		for _, seat := range want {
+			if !tx.Contains(seat) {
+				return fmt.Errorf("seat %s is taken", seat)
+			}
+			tx.Delete(seat)
+		}
+		return tx.Commit()
+	}
+
+	fmt.Println(book("1A", "1B"))
+	fmt.Println(book("2A", "1B"))
+	fmt.Println(seats.Len())
+
+	// Output:
+	// <nil>
+	// seat 1B is taken
+	// 1
+}
+
+func ExampleVersioned() {
+	admins := sets.NewVersioned(0, "alice")
+	admins.Insert("bob")
+	admins.Insert("carol")
+	admins.Delete("alice")
+
+	before, _ := admins.AsOf(1)
+	c, _ := admins.Changes(1, admins.Version())
+	fmt.Println(before, admins.Version())
+	fmt.Println(c.Added, c.Removed)
+
+	// Output:
+	// {alice, bob} 3
+	// {carol} {alice}
+}
+
+func ExampleIntSet() {
+	ids := sets.From[uint64](1000, 1001, 1003, 1002)
+	data, _ := sets.IntSet[uint64](ids).MarshalBinary()
+	fmt.Println(len(data))
+
+	var decoded sets.IntSet[uint64]
+	err := decoded.UnmarshalBinary(data)
+	fmt.Println(sets.Set[uint64](decoded), err)
+
+	// Output:
+	// 9
+	// {1000, 1001, 1002, 1003} <nil>
+}
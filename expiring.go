@@ -0,0 +1,245 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sets
+
+import (
+	"container/heap"
+	"context"
+	"sync"
+	"time"
+)
+
+// Expiring is a set whose elements disappear once their time to live has elapsed.
+//
+// Expired elements are removed lazily, when they are looked up, and in bulk by Expire,
+// which can be called periodically in the background by Run.
+// Expiring is safe for concurrent use.
+//
+// Use NewExpiring to create an Expiring set; the zero value is not ready to use.
+type Expiring[E comparable] struct {
+	mu        sync.Mutex
+	now       func() time.Time
+	deadlines map[E]time.Time
+	queue     expiryQueue[E]
+	onExpire  func(E)
+}
+
+// NewExpiring creates a new empty Expiring set that reads the current time from now.
+// If now is nil, time.Now is used. Passing a custom clock makes expiry deterministic in tests.
+//
+// Time complexity: O(1). Space complexity: O(1).
+func NewExpiring[E comparable](now func() time.Time) *Expiring[E] {
+	if now == nil {
+		now = time.Now
+	}
+	return &Expiring[E]{
+		now:       now,
+		deadlines: make(map[E]time.Time),
+	}
+}
+
+// OnExpire registers f to be called with every element removed because its time to live has elapsed.
+// Elements removed by Delete are not reported. f replaces any previously registered callback;
+// it is invoked without holding internal locks, so it may access the set.
+//
+// Time complexity: O(1). Space complexity: O(1).
+func (s *Expiring[E]) OnExpire(f func(E)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onExpire = f
+}
+
+// Insert inserts e into the set for the duration of ttl.
+// If e is already present, its time to live is reset to ttl.
+// Insert panics if ttl is not positive.
+//
+// Time complexity: O(log(n)). Space complexity: O(1). n is the number of elements.
+func (s *Expiring[E]) Insert(e E, ttl time.Duration) {
+	if ttl <= 0 {
+		panic("must be positive")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	deadline := s.now().Add(ttl)
+	s.deadlines[e] = deadline
+	heap.Push(&s.queue, expiryEntry[E]{e: e, deadline: deadline})
+	s.compact()
+}
+
+// Delete deletes the specified elements from the set.
+// Elements not present in the set are ignored.
+//
+// Time complexity: O(len(v)). Space complexity: O(1).
+func (s *Expiring[E]) Delete(v ...E) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, e := range v {
+		delete(s.deadlines, e)
+	}
+	s.compact()
+}
+
+// Contains reports whether e is present in the set and has not expired.
+// If e has expired, it is removed and reported to the OnExpire callback.
+//
+// Time complexity: O(1). Space complexity: O(1).
+func (s *Expiring[E]) Contains(e E) bool {
+	s.mu.Lock()
+	deadline, ok := s.deadlines[e]
+	if !ok {
+		s.mu.Unlock()
+		return false
+	}
+	if s.now().Before(deadline) {
+		s.mu.Unlock()
+		return true
+	}
+	delete(s.deadlines, e)
+	onExpire := s.onExpire
+	s.mu.Unlock()
+
+	if onExpire != nil {
+		onExpire(e)
+	}
+	return false
+}
+
+// Deadline returns the time at which e expires.
+// The boolean is false if e is not present or has already expired.
+//
+// Time complexity: O(1). Space complexity: O(1).
+func (s *Expiring[E]) Deadline(e E) (time.Time, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	deadline, ok := s.deadlines[e]
+	if !ok || !s.now().Before(deadline) {
+		return time.Time{}, false
+	}
+	return deadline, true
+}
+
+// Len returns the number of elements that have not expired.
+// Expired elements are removed and reported to the OnExpire callback.
+//
+// Time complexity: O(k*log(n)). Space complexity: O(k). k is the number of expired elements.
+func (s *Expiring[E]) Len() int {
+	s.Expire()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.deadlines)
+}
+
+// Snapshot returns a new Set containing the elements that have not expired.
+// Expired elements are removed and reported to the OnExpire callback.
+//
+// Time complexity: O(n + k*log(n)). Space complexity: O(n). n is the number of elements.
+// k is the number of expired elements.
+func (s *Expiring[E]) Snapshot() Set[E] {
+	s.Expire()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r := New[E](len(s.deadlines))
+	for e := range s.deadlines {
+		r[e] = struct{}{}
+	}
+	return r
+}
+
+// Expire removes all expired elements, reports them to the OnExpire callback
+// in the order of their deadlines and returns their number.
+//
+// Time complexity: O(k*log(n)). Space complexity: O(k). k is the number of expired elements.
+func (s *Expiring[E]) Expire() int {
+	s.mu.Lock()
+	now := s.now()
+	var expired []E
+	for len(s.queue) > 0 && !now.Before(s.queue[0].deadline) {
+		entry := heap.Pop(&s.queue).(expiryEntry[E])
+		// Skip entries superseded by a later Insert or invalidated by Delete.
+		if deadline, ok := s.deadlines[entry.e]; ok && deadline.Equal(entry.deadline) {
+			delete(s.deadlines, entry.e)
+			expired = append(expired, entry.e)
+		}
+	}
+	onExpire := s.onExpire
+	s.mu.Unlock()
+
+	if onExpire != nil {
+		for _, e := range expired {
+			onExpire(e)
+		}
+	}
+	return len(expired)
+}
+
+// Run calls Expire every interval until ctx is done. It is intended to be started
+// in its own goroutine. Run panics if interval is not positive.
+func (s *Expiring[E]) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		panic("must be positive")
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.Expire()
+		}
+	}
+}
+
+// compact rebuilds the queue when stale entries outnumber live ones,
+// which bounds memory use for workloads that frequently refresh or delete elements.
+func (s *Expiring[E]) compact() {
+	if len(s.queue) <= 2*len(s.deadlines)+16 {
+		return
+	}
+	s.queue = s.queue[:0]
+	for e, deadline := range s.deadlines {
+		s.queue = append(s.queue, expiryEntry[E]{e: e, deadline: deadline})
+	}
+	heap.Init(&s.queue)
+}
+
+type expiryEntry[E comparable] struct {
+	e        E
+	deadline time.Time
+}
+
+// expiryQueue is a min-heap of entries ordered by deadline.
+type expiryQueue[E comparable] []expiryEntry[E]
+
+func (q expiryQueue[E]) Len() int           { return len(q) }
+func (q expiryQueue[E]) Less(i, j int) bool { return q[i].deadline.Before(q[j].deadline) }
+func (q expiryQueue[E]) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+func (q *expiryQueue[E]) Push(x any)        { *q = append(*q, x.(expiryEntry[E])) }
+
+func (q *expiryQueue[E]) Pop() any {
+	old := *q
+	n := len(old)
+	x := old[n-1]
+	old[n-1] = expiryEntry[E]{}
+	*q = old[:n-1]
+	return x
+}
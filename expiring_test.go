@@ -0,0 +1,294 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sets
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock is a manually advanced clock for deterministic expiry tests.
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func TestExpiringContains(t *testing.T) {
+	clock := newFakeClock()
+	s := NewExpiring[string](clock.Now)
+	var expired []string
+	s.OnExpire(func(e string) { expired = append(expired, e) })
+
+	s.Insert("a", time.Second)
+	s.Insert("b", 2*time.Second)
+
+	if !s.Contains("a") || !s.Contains("b") || s.Contains("c") {
+		t.Fatalf("Contains() returned wrong result before expiry")
+	}
+
+	clock.Advance(time.Second)
+	if s.Contains("a") {
+		t.Errorf("Contains() reported an expired element")
+	}
+	if !s.Contains("b") {
+		t.Errorf("Contains() did not report a live element")
+	}
+	if want := []string{"a"}; !slices.Equal(expired, want) {
+		t.Errorf("\nwant: %v\ngot : %v", want, expired)
+	}
+}
+
+func TestExpiringInsertRefreshesTTL(t *testing.T) {
+	clock := newFakeClock()
+	s := NewExpiring[int](clock.Now)
+
+	s.Insert(1, time.Second)
+	clock.Advance(500 * time.Millisecond)
+	s.Insert(1, time.Second)
+	clock.Advance(700 * time.Millisecond)
+
+	if n := s.Expire(); n != 0 {
+		t.Errorf("Expire() removed %d elements, want 0", n)
+	}
+	if !s.Contains(1) {
+		t.Errorf("refreshed element expired too early")
+	}
+
+	clock.Advance(300 * time.Millisecond)
+	if n := s.Expire(); n != 1 {
+		t.Errorf("Expire() removed %d elements, want 1", n)
+	}
+}
+
+func TestExpiringExpireOrder(t *testing.T) {
+	clock := newFakeClock()
+	s := NewExpiring[int](clock.Now)
+	var expired []int
+	s.OnExpire(func(e int) { expired = append(expired, e) })
+
+	s.Insert(3, 3*time.Second)
+	s.Insert(1, time.Second)
+	s.Insert(2, 2*time.Second)
+	s.Insert(4, 10*time.Second)
+	s.Delete(2)
+
+	clock.Advance(5 * time.Second)
+	if n := s.Expire(); n != 2 {
+		t.Errorf("Expire() removed %d elements, want 2", n)
+	}
+	if want := []int{1, 3}; !slices.Equal(expired, want) {
+		t.Errorf("\nwant: %v\ngot : %v", want, expired)
+	}
+	if got, want := s.Snapshot(), From(4); !Equal(got, want) {
+		t.Errorf("\nwant: %v\ngot : %v", want, got)
+	}
+}
+
+func TestExpiringLenAndSnapshot(t *testing.T) {
+	clock := newFakeClock()
+	s := NewExpiring[int](clock.Now)
+	for i := range 10 {
+		s.Insert(i, time.Duration(i+1)*time.Second)
+	}
+
+	clock.Advance(4 * time.Second)
+	if got := s.Len(); got != 6 {
+		t.Errorf("\nwant: %v\ngot : %v", 6, got)
+	}
+	if got, want := s.Snapshot(), From(4, 5, 6, 7, 8, 9); !Equal(got, want) {
+		t.Errorf("\nwant: %v\ngot : %v", want, got)
+	}
+}
+
+func TestExpiringDeadline(t *testing.T) {
+	clock := newFakeClock()
+	s := NewExpiring[int](clock.Now)
+	s.Insert(1, time.Second)
+
+	got, ok := s.Deadline(1)
+	if want := clock.Now().Add(time.Second); !ok || !got.Equal(want) {
+		t.Errorf("\nwant: %v\ngot : %v", want, got)
+	}
+	if _, ok := s.Deadline(2); ok {
+		t.Errorf("Deadline() reported an absent element")
+	}
+	clock.Advance(time.Second)
+	if _, ok := s.Deadline(1); ok {
+		t.Errorf("Deadline() reported an expired element")
+	}
+}
+
+func TestExpiringWithoutCallback(t *testing.T) {
+	clock := newFakeClock()
+	s := NewExpiring[int](clock.Now)
+	s.Insert(1, time.Second)
+	s.Insert(2, time.Second)
+	clock.Advance(time.Second)
+
+	if s.Contains(1) {
+		t.Errorf("Contains() reported an expired element")
+	}
+	if n := s.Expire(); n != 1 {
+		t.Errorf("Expire() removed %d elements, want 1", n)
+	}
+}
+
+func TestExpiringCallbackMayAccessSet(t *testing.T) {
+	clock := newFakeClock()
+	s := NewExpiring[int](clock.Now)
+	s.OnExpire(func(e int) {
+		if e < 3 {
+			s.Insert(e+10, time.Hour)
+		}
+	})
+	s.Insert(1, time.Second)
+	s.Insert(2, time.Second)
+	clock.Advance(time.Second)
+
+	if got, want := s.Snapshot(), From(11, 12); !Equal(got, want) {
+		t.Errorf("\nwant: %v\ngot : %v", want, got)
+	}
+}
+
+func TestExpiringCompact(t *testing.T) {
+	clock := newFakeClock()
+	s := NewExpiring[int](clock.Now)
+	for range 100 {
+		s.Insert(1, time.Second)
+	}
+	if len(s.queue) > 2*len(s.deadlines)+16 {
+		t.Errorf("queue was not compacted: %d entries", len(s.queue))
+	}
+	s.Insert(2, time.Minute)
+	clock.Advance(time.Second)
+	if got, want := s.Snapshot(), From(2); !Equal(got, want) {
+		t.Errorf("\nwant: %v\ngot : %v", want, got)
+	}
+}
+
+func TestExpiringDefaultClock(t *testing.T) {
+	s := NewExpiring[int](nil)
+	s.Insert(1, time.Hour)
+	if !s.Contains(1) {
+		t.Errorf("Contains() did not report a live element")
+	}
+}
+
+func TestExpiringRun(t *testing.T) {
+	clock := newFakeClock()
+	s := NewExpiring[int](clock.Now)
+	expired := make(chan int, 1)
+	s.OnExpire(func(e int) { expired <- e })
+	s.Insert(1, time.Second)
+	clock.Advance(time.Second)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		s.Run(ctx, time.Millisecond)
+		close(done)
+	}()
+
+	select {
+	case e := <-expired:
+		if e != 1 {
+			t.Errorf("\nwant: %v\ngot : %v", 1, e)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("background expiry did not run")
+	}
+	cancel()
+	<-done
+}
+
+func TestExpiringConcurrent(t *testing.T) {
+	clock := newFakeClock()
+	s := NewExpiring[int](clock.Now)
+	var wg sync.WaitGroup
+	for w := range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 100 {
+				s.Insert(w*100+i, time.Duration(i+1)*time.Millisecond)
+				s.Contains(i)
+				if i%10 == 0 {
+					clock.Advance(time.Millisecond)
+					s.Expire()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	clock.Advance(time.Second)
+	if got := s.Len(); got != 0 {
+		t.Errorf("\nwant: %v\ngot : %v", 0, got)
+	}
+}
+
+func TestExpiringPanics(t *testing.T) {
+	tests := []struct {
+		name string
+		f    func(s *Expiring[int])
+	}{
+		{
+			name: "zero ttl",
+			f:    func(s *Expiring[int]) { s.Insert(1, 0) },
+		},
+		{
+			name: "negative ttl",
+			f:    func(s *Expiring[int]) { s.Insert(1, -time.Second) },
+		},
+		{
+			name: "zero interval",
+			f:    func(s *Expiring[int]) { s.Run(context.Background(), 0) },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("expected panic, but did not panic")
+				}
+			}()
+			tt.f(NewExpiring[int](nil))
+		})
+	}
+}
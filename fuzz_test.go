@@ -0,0 +1,199 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sets
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// The fuzz targets below decode fuzz input into three sets and check every set operation
+// against an oracle that keeps elements in slices. Interesting inputs are committed to
+// testdata/fuzz, so they run as regular tests with "go test".
+
+func FuzzIntOperations(f *testing.F) {
+	f.Add([]byte{})
+	f.Add([]byte{0, 1, 2, 3, 4, 5})
+	f.Add([]byte{0, 3, 6, 1, 4, 2})
+	f.Add([]byte{255, 128, 127, 0, 1, 254})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		// Byte b goes to set b%3 as a small value that may be negative, so that sets overlap often.
+		var in [3][]int
+		for _, b := range data {
+			in[b%3] = append(in[b%3], int(b/3)-40)
+		}
+		checkOperations(t, in, func(e int) int { return e / 2 }, 1+len(data)%4)
+	})
+}
+
+func FuzzStringOperations(f *testing.F) {
+	f.Add([]byte{})
+	f.Add([]byte("a\x00b\x00c\x00a\x00b\x00A"))
+	f.Add([]byte("Go\x00go\x00\x00GO\x00rust\x00Rust\x00\x00"))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		// Tokens separated by zero bytes go to the sets in turn.
+		var in [3][]string
+		for i, token := range bytes.Split(data, []byte{0}) {
+			in[i%3] = append(in[i%3], string(token))
+		}
+		checkOperations(t, in, strings.ToLower, 1+len(data)%4)
+	})
+}
+
+// checkOperations compares the results of set operations on the sets built from in with the oracle.
+// replace is the function passed to ReplaceFunc, and chunk is the chunk size passed to Chunk.
+func checkOperations[E comparable](t *testing.T, in [3][]E, replace func(E) E, chunk int) {
+	a, b, c := FromSlice(in[0]), FromSlice(in[1]), FromSlice(in[2])
+	oa, ob, oc := newOracle(in[0]), newOracle(in[1]), newOracle(in[2])
+
+	check := func(name string, got Set[E], want oracle[E]) {
+		t.Helper()
+		if !want.matches(got) {
+			t.Errorf("%s\nwant: %v\ngot : %v", name, want, got)
+		}
+	}
+	check("Union", Union(a, b, c), oa.union(ob).union(oc))
+	check("Intersection", Intersection(a, b, c), oa.intersection(ob).intersection(oc))
+	check("Intersection of two", Intersection(a, b), oa.intersection(ob))
+	check("Difference", Difference(a, b, c), oa.difference(ob).difference(oc))
+	check("Difference of two", Difference(a, b), oa.difference(ob))
+	check("SymmetricDifference", SymmetricDifference(a, b, c), oa.symmetricDifference(ob).symmetricDifference(oc))
+	check("SymmetricDifference of two", SymmetricDifference(a, b), oa.symmetricDifference(ob))
+
+	replaced := Clone(a)
+	ReplaceFunc(replaced, replace)
+	check("ReplaceFunc", replaced, oa.mapped(replace))
+
+	chunks := 0
+	seen := New[E](len(a))
+	for s := range Chunk(a, chunk) {
+		chunks++
+		if len(s) == 0 || len(s) > chunk || Overlaps(seen, s) {
+			t.Errorf("Chunk yielded an invalid chunk %v of size %d after %v", s, chunk, seen)
+		}
+		Copy(seen, s)
+	}
+	check("Chunk", seen, oa)
+	if want := (len(a) + chunk - 1) / chunk; chunks != want {
+		t.Errorf("Chunk yielded %d chunks, want %d", chunks, want)
+	}
+
+	inB := func(e E) bool { return Contains(b, e) }
+	predicates := []struct {
+		name      string
+		got, want bool
+	}{
+		{"Equal", Equal(a, b), oa.subset(ob) && ob.subset(oa)},
+		{"Subset", Subset(a, b), oa.subset(ob)},
+		{"ProperSubset", ProperSubset(a, b), oa.subset(ob) && !ob.subset(oa)},
+		{"Overlaps", Overlaps(a, b), len(oa.intersection(ob)) > 0},
+		{"ContainsAny", ContainsAny(a, in[1]...), slices.ContainsFunc(in[1], oa.contains)},
+		{"ContainsAll", ContainsAll(a, in[1]...), !slices.ContainsFunc(in[1], func(e E) bool { return !oa.contains(e) })},
+		{"Some", Some(a, inB), slices.ContainsFunc(oa, ob.contains)},
+		{"Every", Every(a, inB), oa.subset(ob)},
+	}
+	for _, p := range predicates {
+		if p.got != p.want {
+			t.Errorf("%s(%v, %v)\nwant: %v\ngot : %v", p.name, a, b, p.want, p.got)
+		}
+	}
+}
+
+// oracle is a set implemented as a slice of distinct elements.
+type oracle[E comparable] []E
+
+func newOracle[E comparable](elems []E) oracle[E] {
+	var o oracle[E]
+	for _, e := range elems {
+		o = o.with(e)
+	}
+	return o
+}
+
+func (o oracle[E]) contains(e E) bool {
+	return slices.Contains(o, e)
+}
+
+func (o oracle[E]) with(e E) oracle[E] {
+	if o.contains(e) {
+		return o
+	}
+	return append(slices.Clip(o), e)
+}
+
+func (o oracle[E]) union(other oracle[E]) oracle[E] {
+	r := slices.Clone(o)
+	for _, e := range other {
+		r = r.with(e)
+	}
+	return r
+}
+
+func (o oracle[E]) intersection(other oracle[E]) oracle[E] {
+	var r oracle[E]
+	for _, e := range o {
+		if other.contains(e) {
+			r = append(r, e)
+		}
+	}
+	return r
+}
+
+func (o oracle[E]) difference(other oracle[E]) oracle[E] {
+	var r oracle[E]
+	for _, e := range o {
+		if !other.contains(e) {
+			r = append(r, e)
+		}
+	}
+	return r
+}
+
+func (o oracle[E]) symmetricDifference(other oracle[E]) oracle[E] {
+	return o.difference(other).union(other.difference(o))
+}
+
+func (o oracle[E]) subset(other oracle[E]) bool {
+	return len(o.difference(other)) == 0
+}
+
+func (o oracle[E]) mapped(f func(E) E) oracle[E] {
+	var r oracle[E]
+	for _, e := range o {
+		r = r.with(f(e))
+	}
+	return r
+}
+
+// matches reports whether s contains exactly the elements of o.
+func (o oracle[E]) matches(s Set[E]) bool {
+	if len(s) != len(o) {
+		return false
+	}
+	for _, e := range o {
+		if !Contains(s, e) {
+			return false
+		}
+	}
+	return true
+}
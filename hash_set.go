@@ -0,0 +1,374 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sets
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// HashSet is a set of elements of any type that uses user-defined hash and equality functions
+// instead of Go's ==, e.g. for byte slices or structs compared on a subset of their fields.
+// Elements with equal hashes are kept in chained buckets.
+//
+// The functions must be consistent: equal elements must have equal hashes.
+// Operations combining several sets use the functions of the receiver.
+//
+// Time complexities below assume a hash function with few collisions;
+// in the worst case, when all elements share a hash, lookups take O(len(s)) time.
+//
+// Use NewHashSet to create a HashSet; the zero value is not ready to use.
+type HashSet[E any] struct {
+	hash    func(E) uint64
+	equal   func(a, b E) bool
+	buckets map[uint64][]E
+	n       int
+}
+
+// NewHashSet creates a new HashSet containing vals, which uses hash and equal to compare elements.
+// If hash or equal is nil, NewHashSet panics.
+//
+// Time complexity: O(len(vals)). Space complexity: O(len(vals)).
+func NewHashSet[E any](hash func(E) uint64, equal func(a, b E) bool, vals ...E) *HashSet[E] {
+	if hash == nil || equal == nil {
+		panic("cannot be nil")
+	}
+	s := &HashSet[E]{hash: hash, equal: equal, buckets: make(map[uint64][]E, len(vals))}
+	s.Insert(vals...)
+	return s
+}
+
+// Insert inserts the given elements into the set.
+// Elements equal to an element already present in the set are ignored.
+//
+// Time complexity: O(len(v)). Space complexity: O(len(v)).
+func (s *HashSet[E]) Insert(v ...E) {
+	for _, e := range v {
+		s.insert(e)
+	}
+}
+
+// InsertSeq inserts all elements from seq into the set.
+//
+// Time complexity: O(N). Space complexity: O(N). N is the number of elements in seq.
+func (s *HashSet[E]) InsertSeq(seq iter.Seq[E]) {
+	for e := range seq {
+		s.insert(e)
+	}
+}
+
+// Delete deletes the elements equal to the specified elements from the set.
+// Elements not present in the set are ignored.
+//
+// Time complexity: O(len(v)). Space complexity: O(1).
+func (s *HashSet[E]) Delete(v ...E) {
+	for _, e := range v {
+		s.delete(e)
+	}
+}
+
+// DeleteFunc deletes any elements from s for which del returns true.
+//
+// Time complexity: O(len(s)). Space complexity: O(1).
+func (s *HashSet[E]) DeleteFunc(del func(E) bool) {
+	for h, bucket := range s.buckets {
+		kept := slices.DeleteFunc(bucket, del)
+		s.n -= len(bucket) - len(kept)
+		if len(kept) == 0 {
+			delete(s.buckets, h)
+		} else {
+			s.buckets[h] = kept
+		}
+	}
+}
+
+// Replace replaces the element equal to old with new. If old is not present, Replace is a no-op.
+//
+// Time complexity: O(1). Space complexity: O(1).
+func (s *HashSet[E]) Replace(old, new E) { //nolint:revive // 'new' follows stdlib pattern (see strings.Replace)
+	if s.delete(old) {
+		s.insert(new)
+	}
+}
+
+// ReplaceFunc replaces each element e in s with f(e).
+// Since f may map multiple elements to equal values, the resulting set may be smaller.
+//
+// Time complexity: O(len(s)). Space complexity: O(len(s)).
+func (s *HashSet[E]) ReplaceFunc(f func(E) E) {
+	elements := s.ToSlice()
+	s.Clear()
+	for _, e := range elements {
+		s.insert(f(e))
+	}
+}
+
+// Clear deletes all elements from the set.
+//
+// Time complexity: O(len(s)). Space complexity: O(1).
+func (s *HashSet[E]) Clear() {
+	clear(s.buckets)
+	s.n = 0
+}
+
+// Contains reports whether an element equal to v is present in the set.
+//
+// Time complexity: O(1). Space complexity: O(1).
+func (s *HashSet[E]) Contains(v E) bool {
+	_, ok := s.index(v)
+	return ok
+}
+
+// ContainsAny reports whether any of the given elements are present in the set.
+//
+// Time complexity: O(len(v)). Space complexity: O(1).
+func (s *HashSet[E]) ContainsAny(v ...E) bool {
+	return slices.ContainsFunc(v, s.Contains)
+}
+
+// ContainsAll reports whether all of the given elements are present in the set.
+//
+// Time complexity: O(len(v)). Space complexity: O(1).
+func (s *HashSet[E]) ContainsAll(v ...E) bool {
+	for _, e := range v {
+		if !s.Contains(e) {
+			return false
+		}
+	}
+	return true
+}
+
+// Some reports whether at least one element e of s satisfies f(e).
+//
+// Time complexity: O(len(s)). Space complexity: O(1).
+func (s *HashSet[E]) Some(f func(E) bool) bool {
+	for e := range s.All() {
+		if f(e) {
+			return true
+		}
+	}
+	return false
+}
+
+// Every reports whether all elements e of s satisfy f(e).
+//
+// Time complexity: O(len(s)). Space complexity: O(1).
+func (s *HashSet[E]) Every(f func(E) bool) bool {
+	return !s.Some(func(e E) bool { return !f(e) })
+}
+
+// Equal reports whether s and other contain equal elements.
+//
+// Time complexity: O(len(s)). Space complexity: O(1).
+func (s *HashSet[E]) Equal(other *HashSet[E]) bool {
+	return s.n == other.n && other.Subset(s)
+}
+
+// Overlaps reports whether s and other have any element in common.
+//
+// Time complexity: O(min(len(s), len(other))). Space complexity: O(1).
+func (s *HashSet[E]) Overlaps(other *HashSet[E]) bool {
+	small, large := other, s
+	if small.n > large.n {
+		small, large = large, small
+	}
+	return small.Some(large.Contains)
+}
+
+// Subset reports whether all elements of s are also in superset.
+//
+// Time complexity: O(len(s)). Space complexity: O(1).
+func (s *HashSet[E]) Subset(superset *HashSet[E]) bool {
+	return s.n <= superset.n && s.Every(superset.Contains)
+}
+
+// ProperSubset reports whether s is a proper subset of superset,
+// i.e. all elements of s are in superset and the sets are not equal.
+//
+// Time complexity: O(len(s)). Space complexity: O(1).
+func (s *HashSet[E]) ProperSubset(superset *HashSet[E]) bool {
+	return s.n < superset.n && s.Every(superset.Contains)
+}
+
+// Union returns a new set containing all elements from s and all of the others.
+//
+// Time complexity: O(N). Space complexity: O(N). N is the sum of all set sizes.
+func (s *HashSet[E]) Union(others ...*HashSet[E]) *HashSet[E] {
+	r := s.Clone()
+	for _, o := range others {
+		r.InsertSeq(o.All())
+	}
+	return r
+}
+
+// Intersection returns a new set containing only elements present in s and in all of the others.
+//
+// Time complexity: O(len(s) * len(others)). Space complexity: O(len(s)).
+func (s *HashSet[E]) Intersection(others ...*HashSet[E]) *HashSet[E] {
+	return s.Filter(func(e E) bool {
+		for _, o := range others {
+			if !o.Contains(e) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// Difference returns a new set containing elements present in s but not in any of the others.
+//
+// Time complexity: O(len(s) * len(others)). Space complexity: O(len(s)).
+func (s *HashSet[E]) Difference(others ...*HashSet[E]) *HashSet[E] {
+	return s.Filter(func(e E) bool {
+		for _, o := range others {
+			if o.Contains(e) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// SymmetricDifference returns a new set containing elements that belong to
+// an odd number of the sets s and others (i.e., the n-ary XOR of the sets).
+//
+// Time complexity: O(N). Space complexity: O(N). N is the sum of all set sizes.
+func (s *HashSet[E]) SymmetricDifference(others ...*HashSet[E]) *HashSet[E] {
+	r := s.Clone()
+	for _, o := range others {
+		for e := range o.All() {
+			if !r.delete(e) {
+				r.insert(e)
+			}
+		}
+	}
+	return r
+}
+
+// Filter returns a new set containing only the elements e of s for which f(e) is true.
+//
+// Time complexity: O(len(s)). Space complexity: O(len(s)).
+func (s *HashSet[E]) Filter(f func(E) bool) *HashSet[E] {
+	r := NewHashSet(s.hash, s.equal)
+	for e := range s.All() {
+		if f(e) {
+			r.insert(e)
+		}
+	}
+	return r
+}
+
+// Len returns the number of elements in the set.
+//
+// Time complexity: O(1). Space complexity: O(1).
+func (s *HashSet[E]) Len() int {
+	return s.n
+}
+
+// All returns an iterator over the elements of the set.
+// The iteration order is not specified.
+//
+// Creation: O(1) time, O(1) space.
+// Iteration: O(len(s)) time, O(1) space.
+func (s *HashSet[E]) All() iter.Seq[E] {
+	return func(yield func(E) bool) {
+		for _, bucket := range s.buckets {
+			for _, e := range bucket {
+				if !yield(e) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// ToSlice returns all elements of the set as a slice.
+// The order of elements is non-deterministic.
+//
+// Time complexity: O(len(s)). Space complexity: O(len(s)).
+func (s *HashSet[E]) ToSlice() []E {
+	r := make([]E, 0, s.n)
+	for e := range s.All() {
+		r = append(r, e)
+	}
+	return r
+}
+
+// Clone returns a shallow copy of s that shares its hash and equality functions.
+//
+// Time complexity: O(len(s)). Space complexity: O(len(s)).
+func (s *HashSet[E]) Clone() *HashSet[E] {
+	r := &HashSet[E]{hash: s.hash, equal: s.equal, buckets: make(map[uint64][]E, len(s.buckets)), n: s.n}
+	for h, bucket := range s.buckets {
+		r.buckets[h] = slices.Clone(bucket)
+	}
+	return r
+}
+
+// String returns a string representation of the set in the format "{elem1, elem2, ...}".
+// Elements are sorted by their string representation for consistent output.
+//
+// Time complexity: O(len(s)). Space complexity: O(len(s)).
+func (s *HashSet[E]) String() string {
+	elements := make([]string, 0, s.n)
+	for e := range s.All() {
+		elements = append(elements, fmt.Sprintf("%v", e))
+	}
+	sort.Strings(elements)
+	return "{" + strings.Join(elements, ", ") + "}"
+}
+
+// index returns the hash of v and whether an element equal to v is present in its bucket.
+func (s *HashSet[E]) index(v E) (uint64, bool) {
+	h := s.hash(v)
+	return h, slices.ContainsFunc(s.buckets[h], func(e E) bool { return s.equal(e, v) })
+}
+
+// insert adds v and reports whether it was absent.
+func (s *HashSet[E]) insert(v E) bool {
+	h, ok := s.index(v)
+	if ok {
+		return false
+	}
+	s.buckets[h] = append(s.buckets[h], v)
+	s.n++
+	return true
+}
+
+// delete removes the element equal to v and reports whether it was present.
+func (s *HashSet[E]) delete(v E) bool {
+	h := s.hash(v)
+	bucket := s.buckets[h]
+	i := slices.IndexFunc(bucket, func(e E) bool { return s.equal(e, v) })
+	if i < 0 {
+		return false
+	}
+	if len(bucket) == 1 {
+		delete(s.buckets, h)
+	} else {
+		s.buckets[h] = slices.Delete(bucket, i, i+1)
+	}
+	s.n--
+	return true
+}
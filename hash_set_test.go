@@ -0,0 +1,273 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sets
+
+import (
+	"bytes"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// newIntHashSet returns a HashSet of ints whose hash function puts many elements
+// into the same bucket, so that chaining is exercised.
+func newIntHashSet(vals ...int) *HashSet[int] {
+	return NewHashSet(
+		func(e int) uint64 { return uint64(e % 3) },
+		func(a, b int) bool { return a == b },
+		vals...,
+	)
+}
+
+// newBytesHashSet returns a HashSet of byte slices compared by content.
+func newBytesHashSet(vals ...string) *HashSet[[]byte] {
+	s := NewHashSet(
+		func(b []byte) uint64 { return uint64(len(b)) },
+		bytes.Equal,
+	)
+	for _, v := range vals {
+		s.Insert([]byte(v))
+	}
+	return s
+}
+
+func TestHashSetInsertDelete(t *testing.T) {
+	s := newBytesHashSet("ab", "cd", "ab", "xyz")
+	if got, want := s.String(), "{[120 121 122], [97 98], [99 100]}"; got != want {
+		t.Errorf("\nwant: %v\ngot : %v", want, got)
+	}
+	if got := s.Len(); got != 3 {
+		t.Errorf("\nwant: %v\ngot : %v", 3, got)
+	}
+	if !s.Contains([]byte("cd")) || s.Contains([]byte("dc")) || s.Contains([]byte("q")) {
+		t.Errorf("Contains() returned wrong result")
+	}
+
+	s.Delete([]byte("ab"), []byte("ba"), []byte("nope"))
+	if got := s.Len(); got != 2 || s.Contains([]byte("ab")) {
+		t.Errorf("Delete() left %v", s)
+	}
+	s.Delete([]byte("xyz"))
+	if _, ok := s.buckets[3]; ok {
+		t.Errorf("Delete() kept an empty bucket")
+	}
+
+	s.InsertSeq(slices.Values([][]byte{[]byte("cd"), []byte("ef")}))
+	if got := s.Len(); got != 2 {
+		t.Errorf("\nwant: %v\ngot : %v", 2, got)
+	}
+	s.Clear()
+	if got := s.Len(); got != 0 || s.Contains([]byte("cd")) {
+		t.Errorf("Clear() left %v", s)
+	}
+}
+
+func TestHashSetDeleteFunc(t *testing.T) {
+	s := newIntHashSet(1, 2, 3, 4, 5, 6, 9)
+	s.DeleteFunc(func(e int) bool { return e%3 == 0 || e == 1 })
+	if got, want := s.String(), "{2, 4, 5}"; got != want {
+		t.Errorf("\nwant: %v\ngot : %v", want, got)
+	}
+	if got := s.Len(); got != 3 {
+		t.Errorf("\nwant: %v\ngot : %v", 3, got)
+	}
+	if _, ok := s.buckets[0]; ok {
+		t.Errorf("DeleteFunc() kept an empty bucket")
+	}
+}
+
+func TestHashSetReplace(t *testing.T) {
+	tests := []struct {
+		name     string
+		set      *HashSet[int]
+		old, new int
+		want     string
+	}{
+		{name: "present", set: newIntHashSet(1, 2), old: 1, new: 4, want: "{2, 4}"},
+		{name: "absent", set: newIntHashSet(1, 2), old: 3, new: 4, want: "{1, 2}"},
+		{name: "into existing", set: newIntHashSet(1, 2), old: 1, new: 2, want: "{2}"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.set.Replace(tt.old, tt.new)
+			if got := tt.set.String(); got != tt.want {
+				t.Errorf("\nwant: %v\ngot : %v", tt.want, got)
+			}
+		})
+	}
+
+	s := newIntHashSet(1, 2, 3, 4)
+	s.ReplaceFunc(func(e int) int { return e / 2 })
+	if got, want := s.String(), "{0, 1, 2}"; got != want || s.Len() != 3 {
+		t.Errorf("\nwant: %v\ngot : %v", want, got)
+	}
+}
+
+func TestHashSetPredicates(t *testing.T) {
+	s := newIntHashSet(1, 2, 3)
+	tests := []struct {
+		name string
+		got  bool
+		want bool
+	}{
+		{name: "contains any present", got: s.ContainsAny(7, 3), want: true},
+		{name: "contains any absent", got: s.ContainsAny(7, 8), want: false},
+		{name: "contains any none", got: s.ContainsAny(), want: false},
+		{name: "contains all present", got: s.ContainsAll(1, 3), want: true},
+		{name: "contains all partial", got: s.ContainsAll(1, 4), want: false},
+		{name: "contains all none", got: s.ContainsAll(), want: true},
+		{name: "some", got: s.Some(func(e int) bool { return e > 2 }), want: true},
+		{name: "some none", got: s.Some(func(e int) bool { return e > 3 }), want: false},
+		{name: "every", got: s.Every(func(e int) bool { return e > 0 }), want: true},
+		{name: "every not", got: s.Every(func(e int) bool { return e > 1 }), want: false},
+		{name: "every empty", got: newIntHashSet().Every(func(int) bool { return false }), want: true},
+		{name: "equal", got: s.Equal(newIntHashSet(3, 2, 1)), want: true},
+		{name: "equal different", got: s.Equal(newIntHashSet(1, 2, 4)), want: false},
+		{name: "equal different size", got: s.Equal(newIntHashSet(1, 2)), want: false},
+		{name: "overlaps", got: s.Overlaps(newIntHashSet(3, 4, 5, 6)), want: true},
+		{name: "overlaps larger receiver", got: newIntHashSet(3, 4, 5, 6).Overlaps(s), want: true},
+		{name: "overlaps disjoint", got: s.Overlaps(newIntHashSet(4, 5)), want: false},
+		{name: "overlaps empty", got: s.Overlaps(newIntHashSet()), want: false},
+		{name: "subset", got: newIntHashSet(1, 3).Subset(s), want: true},
+		{name: "subset equal", got: s.Subset(newIntHashSet(1, 2, 3)), want: true},
+		{name: "subset not", got: newIntHashSet(1, 4).Subset(s), want: false},
+		{name: "subset larger", got: newIntHashSet(1, 2, 3, 4).Subset(s), want: false},
+		{name: "subset empty", got: newIntHashSet().Subset(s), want: true},
+		{name: "proper subset", got: newIntHashSet(1, 3).ProperSubset(s), want: true},
+		{name: "proper subset equal", got: s.ProperSubset(newIntHashSet(1, 2, 3)), want: false},
+		{name: "proper subset not", got: newIntHashSet(4).ProperSubset(s), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("\nwant: %v\ngot : %v", tt.want, tt.got)
+			}
+		})
+	}
+}
+
+func TestHashSetOperations(t *testing.T) {
+	a := newIntHashSet(1, 2, 3, 4)
+	b := newIntHashSet(3, 4, 5, 6)
+	c := newIntHashSet(4, 6, 7)
+	tests := []struct {
+		name string
+		got  *HashSet[int]
+		want string
+	}{
+		{name: "union", got: a.Union(b, c), want: "{1, 2, 3, 4, 5, 6, 7}"},
+		{name: "union of none", got: a.Union(), want: "{1, 2, 3, 4}"},
+		{name: "intersection", got: a.Intersection(b), want: "{3, 4}"},
+		{name: "intersection of three", got: a.Intersection(b, c), want: "{4}"},
+		{name: "intersection of none", got: a.Intersection(), want: "{1, 2, 3, 4}"},
+		{name: "difference", got: a.Difference(b), want: "{1, 2}"},
+		{name: "difference of two", got: b.Difference(a, c), want: "{5}"},
+		{name: "symmetric difference", got: a.SymmetricDifference(b), want: "{1, 2, 5, 6}"},
+		{name: "symmetric difference of three", got: a.SymmetricDifference(b, c), want: "{1, 2, 4, 5, 7}"},
+		{name: "filter", got: a.Filter(func(e int) bool { return e%2 == 0 }), want: "{2, 4}"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.got.String(); got != tt.want {
+				t.Errorf("\nwant: %v\ngot : %v", tt.want, got)
+			}
+			if got, want := tt.got.Len(), len(tt.got.ToSlice()); got != want {
+				t.Errorf("Len()\nwant: %v\ngot : %v", want, got)
+			}
+		})
+	}
+	if got := a.String(); got != "{1, 2, 3, 4}" {
+		t.Errorf("operations modified the receiver: %v", got)
+	}
+}
+
+func TestHashSetClone(t *testing.T) {
+	s := newIntHashSet(1, 4, 7)
+	clone := s.Clone()
+	clone.Delete(4)
+	clone.Insert(10)
+	if got, want := s.String(), "{1, 4, 7}"; got != want {
+		t.Errorf("Clone() shares storage with the original\nwant: %v\ngot : %v", want, got)
+	}
+	if got, want := clone.String(), "{1, 10, 7}"; got != want {
+		t.Errorf("\nwant: %v\ngot : %v", want, got)
+	}
+}
+
+func TestHashSetAll(t *testing.T) {
+	s := newIntHashSet(1, 2, 3, 4, 5)
+	if got := slices.Sorted(s.All()); !slices.Equal(got, []int{1, 2, 3, 4, 5}) {
+		t.Errorf("\nwant: %v\ngot : %v", []int{1, 2, 3, 4, 5}, got)
+	}
+	count := 0
+	for range s.All() {
+		count++
+		if count == 2 {
+			break
+		}
+	}
+	if count != 2 {
+		t.Errorf("expected to iterate 2 times, got %d", count)
+	}
+}
+
+func TestHashSetMatchesSet(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	h := newIntHashSet()
+	ref := New[int](0)
+	for range 2000 {
+		e := r.IntN(100)
+		switch r.IntN(3) {
+		case 0, 1:
+			h.Insert(e)
+			Insert(ref, e)
+		default:
+			h.Delete(e)
+			Delete(ref, e)
+		}
+		if h.Len() != len(ref) || h.Contains(e) != Contains(ref, e) {
+			t.Fatalf("HashSet diverged from Set\nwant: %v\ngot : %v", ref, h)
+		}
+	}
+	if got := FromSlice(h.ToSlice()); !Equal(got, ref) {
+		t.Errorf("\nwant: %v\ngot : %v", ref, got)
+	}
+}
+
+func TestHashSetPanics(t *testing.T) {
+	tests := []struct {
+		name string
+		f    func()
+	}{
+		{name: "nil hash", f: func() { NewHashSet(nil, func(a, b int) bool { return a == b }) }},
+		{name: "nil equal", f: func() { NewHashSet[int](func(int) uint64 { return 0 }, nil) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected panic")
+				}
+			}()
+			tt.f()
+		})
+	}
+}
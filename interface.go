@@ -0,0 +1,74 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sets
+
+import "iter"
+
+// ReadOnly is the read-only view shared by all set implementations of the module.
+// Functions written against ReadOnly and Mutable, such as those of the anyset package,
+// work with any of them.
+type ReadOnly[E any] interface {
+	// Len returns the number of elements in the set.
+	Len() int
+	// Contains reports whether v is present in the set.
+	Contains(v E) bool
+	// All returns an iterator over the elements of the set.
+	All() iter.Seq[E]
+}
+
+// Mutable is a set that can be modified in place.
+type Mutable[E any] interface {
+	ReadOnly[E]
+	// Insert inserts the given elements into the set.
+	Insert(v ...E)
+	// Delete deletes the given elements from the set.
+	Delete(v ...E)
+}
+
+var (
+	_ Mutable[int]    = Adapt(Set[int]{})
+	_ Mutable[int]    = (*Observable[int])(nil)
+	_ Mutable[int]    = (*Bounded[int])(nil)
+	_ Mutable[string] = (*TrieSet)(nil)
+	_ Mutable[string] = (*Normalized)(nil)
+	_ Mutable[int]    = (*Keyed[int, int])(nil)
+	_ Mutable[int]    = (*HashSet[int])(nil)
+	_ Mutable[int]    = (*Tx[int])(nil)
+	_ Mutable[int]    = (*Versioned[int])(nil)
+	_ ReadOnly[int]   = (*Transactional[int])(nil)
+)
+
+// Adapt returns a view of s that implements Mutable.
+// The view shares storage with s, so changes made through either are visible in both.
+//
+// Time complexity: O(1). Space complexity: O(1).
+func Adapt[S ~map[E]struct{}, E comparable](s S) Mutable[E] {
+	return adapter[E](s)
+}
+
+// adapter implements Mutable on top of a map-based set.
+type adapter[E comparable] map[E]struct{}
+
+func (a adapter[E]) Len() int          { return len(a) }
+func (a adapter[E]) Contains(v E) bool { return Contains(a, v) }
+func (a adapter[E]) All() iter.Seq[E]  { return All(a) }
+func (a adapter[E]) Insert(v ...E)     { Insert(a, v...) }
+func (a adapter[E]) Delete(v ...E)     { Delete(a, v...) }
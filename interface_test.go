@@ -0,0 +1,45 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sets
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestAdapt(t *testing.T) {
+	s := From(1, 2)
+	a := Adapt(s)
+	a.Insert(3, 4)
+	a.Delete(1)
+	if want := From(2, 3, 4); !Equal(s, want) {
+		t.Errorf("Adapt() does not share storage\nwant: %v\ngot : %v", want, s)
+	}
+	if got := a.Len(); got != 3 {
+		t.Errorf("\nwant: %v\ngot : %v", 3, got)
+	}
+	if !a.Contains(2) || a.Contains(1) {
+		t.Errorf("Contains() returned wrong result")
+	}
+	if got, want := slices.Sorted(a.All()), []int{2, 3, 4}; !slices.Equal(got, want) {
+		t.Errorf("\nwant: %v\ngot : %v", want, got)
+	}
+}
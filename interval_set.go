@@ -0,0 +1,345 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sets
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Integer is a constraint that permits any integer type.
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// Interval is a half-open interval [Lo, Hi) of ordered values.
+// An interval with Lo >= Hi is empty.
+type Interval[E cmp.Ordered] struct {
+	Lo E // Inclusive lower bound.
+	Hi E // Exclusive upper bound.
+}
+
+// Empty reports whether iv contains no values.
+//
+// Time complexity: O(1). Space complexity: O(1).
+func (iv Interval[E]) Empty() bool {
+	return !(iv.Lo < iv.Hi)
+}
+
+// Contains reports whether v lies within iv.
+//
+// Time complexity: O(1). Space complexity: O(1).
+func (iv Interval[E]) Contains(v E) bool {
+	return iv.Lo <= v && v < iv.Hi
+}
+
+// String returns a string representation of the interval in the format "[lo, hi)".
+//
+// Time complexity: O(1). Space complexity: O(1).
+func (iv Interval[E]) String() string {
+	return fmt.Sprintf("[%v, %v)", iv.Lo, iv.Hi)
+}
+
+// IntervalSet is a set of ordered values stored as a sorted list of disjoint half-open intervals.
+// Overlapping and adjacent intervals are coalesced, so the representation of a set is unique.
+// The zero value is an empty set ready to use. Read-only methods accept a nil *IntervalSet.
+//
+// Values that are not ordered with themselves, such as floating-point NaN, are never members of the set.
+type IntervalSet[E cmp.Ordered] struct {
+	ivs []Interval[E]
+}
+
+// NewIntervalSet creates a new IntervalSet containing the values of the provided intervals.
+// Empty intervals are ignored.
+//
+// Time complexity: O(n*log(n)). Space complexity: O(n). n is the number of intervals.
+func NewIntervalSet[E cmp.Ordered](intervals ...Interval[E]) *IntervalSet[E] {
+	return &IntervalSet[E]{ivs: normalize(slices.Clone(intervals))}
+}
+
+// ToIntervalSet returns an IntervalSet containing the elements of s,
+// with runs of consecutive integers coalesced into intervals.
+// ToIntervalSet panics if s contains the maximum value of E, which a half-open interval cannot represent.
+//
+// Time complexity: O(len(s)*log(len(s))). Space complexity: O(len(s)).
+func ToIntervalSet[S ~map[E]struct{}, E Integer](s S) *IntervalSet[E] {
+	elements := ToSlice(s)
+	slices.Sort(elements)
+	r := &IntervalSet[E]{}
+	for _, e := range elements {
+		if e+1 < e {
+			panic("cannot represent the maximum value")
+		}
+		if n := len(r.ivs); n > 0 && r.ivs[n-1].Hi == e {
+			r.ivs[n-1].Hi = e + 1
+			continue
+		}
+		r.ivs = append(r.ivs, Interval[E]{Lo: e, Hi: e + 1})
+	}
+	return r
+}
+
+// FromIntervalSet returns a new Set containing every integer covered by s.
+// The result is as large as the total length of the intervals, so use it only for sets of moderate size.
+//
+// Time complexity: O(N). Space complexity: O(N). N is the number of integers in s.
+func FromIntervalSet[E Integer](s *IntervalSet[E]) Set[E] {
+	r := New[E](0)
+	for _, iv := range s.intervals() {
+		for e := iv.Lo; e < iv.Hi; e++ {
+			r[e] = struct{}{}
+		}
+	}
+	return r
+}
+
+// AddRange adds all values of [lo, hi) to the set. If lo >= hi, AddRange is a no-op.
+//
+// Time complexity: O(n). Space complexity: O(1). n is the number of intervals.
+func (s *IntervalSet[E]) AddRange(lo, hi E) {
+	if !(lo < hi) {
+		return
+	}
+	// Intervals in [i, j) overlap or touch [lo, hi) and are merged with it.
+	i := sort.Search(len(s.ivs), func(k int) bool { return s.ivs[k].Hi >= lo })
+	j := sort.Search(len(s.ivs), func(k int) bool { return s.ivs[k].Lo > hi })
+	if i < j {
+		lo = min(lo, s.ivs[i].Lo)
+		hi = max(hi, s.ivs[j-1].Hi)
+	}
+	s.ivs = slices.Replace(s.ivs, i, j, Interval[E]{Lo: lo, Hi: hi})
+}
+
+// RemoveRange removes all values of [lo, hi) from the set. If lo >= hi, RemoveRange is a no-op.
+//
+// Time complexity: O(n). Space complexity: O(1). n is the number of intervals.
+func (s *IntervalSet[E]) RemoveRange(lo, hi E) {
+	if !(lo < hi) {
+		return
+	}
+	// Intervals in [i, j) overlap [lo, hi); only their parts outside of it remain.
+	i := sort.Search(len(s.ivs), func(k int) bool { return s.ivs[k].Hi > lo })
+	j := sort.Search(len(s.ivs), func(k int) bool { return s.ivs[k].Lo >= hi })
+	if i == j {
+		return
+	}
+	rest := make([]Interval[E], 0, 2)
+	if first := s.ivs[i]; first.Lo < lo {
+		rest = append(rest, Interval[E]{Lo: first.Lo, Hi: lo})
+	}
+	if last := s.ivs[j-1]; hi < last.Hi {
+		rest = append(rest, Interval[E]{Lo: hi, Hi: last.Hi})
+	}
+	s.ivs = slices.Replace(s.ivs, i, j, rest...)
+}
+
+// Contains reports whether v is present in the set.
+//
+// Time complexity: O(log(n)). Space complexity: O(1). n is the number of intervals.
+func (s *IntervalSet[E]) Contains(v E) bool {
+	ivs := s.intervals()
+	i := sort.Search(len(ivs), func(k int) bool { return ivs[k].Hi > v })
+	return i < len(ivs) && ivs[i].Lo <= v
+}
+
+// ContainsRange reports whether all values of [lo, hi) are present in the set.
+// An empty range is contained in every set.
+//
+// Time complexity: O(log(n)). Space complexity: O(1). n is the number of intervals.
+func (s *IntervalSet[E]) ContainsRange(lo, hi E) bool {
+	if !(lo < hi) {
+		return true
+	}
+	ivs := s.intervals()
+	i := sort.Search(len(ivs), func(k int) bool { return ivs[k].Hi > lo })
+	return i < len(ivs) && ivs[i].Lo <= lo && hi <= ivs[i].Hi
+}
+
+// Overlaps reports whether s and other have any value in common.
+//
+// Time complexity: O(n + m). Space complexity: O(1). n and m are the numbers of intervals.
+func (s *IntervalSet[E]) Overlaps(other *IntervalSet[E]) bool {
+	a, b := s.intervals(), other.intervals()
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		if a[i].Lo < b[j].Hi && b[j].Lo < a[i].Hi {
+			return true
+		}
+		if a[i].Hi < b[j].Hi {
+			i++
+		} else {
+			j++
+		}
+	}
+	return false
+}
+
+// Equal reports whether s and other contain the same values.
+//
+// Time complexity: O(n). Space complexity: O(1). n is the number of intervals.
+func (s *IntervalSet[E]) Equal(other *IntervalSet[E]) bool {
+	return slices.Equal(s.intervals(), other.intervals())
+}
+
+// Union returns a new set containing all values present in s or in any of the others.
+//
+// Time complexity: O(N*log(N)). Space complexity: O(N). N is the total number of intervals.
+func (s *IntervalSet[E]) Union(others ...*IntervalSet[E]) *IntervalSet[E] {
+	all := slices.Clone(s.intervals())
+	for _, o := range others {
+		all = append(all, o.intervals()...)
+	}
+	return &IntervalSet[E]{ivs: normalize(all)}
+}
+
+// Intersection returns a new set containing only values present in s and in all of the others.
+//
+// Time complexity: O(N). Space complexity: O(N). N is the total number of intervals.
+func (s *IntervalSet[E]) Intersection(others ...*IntervalSet[E]) *IntervalSet[E] {
+	r := slices.Clone(s.intervals())
+	for _, o := range others {
+		r = intersect(r, o.intervals())
+	}
+	return &IntervalSet[E]{ivs: r}
+}
+
+// Difference returns a new set containing values present in s but not in any of the others.
+//
+// Time complexity: O(N*log(N)). Space complexity: O(N). N is the total number of intervals.
+func (s *IntervalSet[E]) Difference(others ...*IntervalSet[E]) *IntervalSet[E] {
+	if len(others) == 0 {
+		return s.Clone()
+	}
+	subtrahend := others[0].Union(others[1:]...)
+	return &IntervalSet[E]{ivs: subtract(s.intervals(), subtrahend.ivs)}
+}
+
+// Complement returns a new set containing the values of [lo, hi) that are not present in s.
+//
+// Time complexity: O(n). Space complexity: O(n). n is the number of intervals.
+func (s *IntervalSet[E]) Complement(lo, hi E) *IntervalSet[E] {
+	bounds := NewIntervalSet(Interval[E]{Lo: lo, Hi: hi})
+	return &IntervalSet[E]{ivs: subtract(bounds.ivs, s.intervals())}
+}
+
+// NumIntervals returns the number of disjoint intervals in the set, not the number of its elements.
+//
+// Time complexity: O(1). Space complexity: O(1).
+func (s *IntervalSet[E]) NumIntervals() int {
+	return len(s.intervals())
+}
+
+// Intervals returns an iterator over the disjoint intervals of the set in ascending order.
+//
+// Creation: O(1) time, O(1) space.
+// Iteration: O(n) time, O(1) space. n is the number of intervals.
+func (s *IntervalSet[E]) Intervals() iter.Seq[Interval[E]] {
+	return func(yield func(Interval[E]) bool) {
+		for _, iv := range s.intervals() {
+			if !yield(iv) {
+				return
+			}
+		}
+	}
+}
+
+// Clone returns a copy of s.
+//
+// Time complexity: O(n). Space complexity: O(n). n is the number of intervals.
+func (s *IntervalSet[E]) Clone() *IntervalSet[E] {
+	return &IntervalSet[E]{ivs: slices.Clone(s.intervals())}
+}
+
+// String returns a string representation of the set in the format "{[lo1, hi1), [lo2, hi2), ...}".
+//
+// Time complexity: O(n). Space complexity: O(n). n is the number of intervals.
+func (s *IntervalSet[E]) String() string {
+	ivs := s.intervals()
+	elements := make([]string, len(ivs))
+	for i, iv := range ivs {
+		elements[i] = iv.String()
+	}
+	return "{" + strings.Join(elements, ", ") + "}"
+}
+
+func (s *IntervalSet[E]) intervals() []Interval[E] {
+	if s == nil {
+		return nil
+	}
+	return s.ivs
+}
+
+// normalize sorts intervals in place and coalesces overlapping and adjacent ones.
+func normalize[E cmp.Ordered](ivs []Interval[E]) []Interval[E] {
+	ivs = slices.DeleteFunc(ivs, Interval[E].Empty)
+	slices.SortFunc(ivs, func(a, b Interval[E]) int { return cmp.Compare(a.Lo, b.Lo) })
+	r := ivs[:0]
+	for _, iv := range ivs {
+		if n := len(r); n > 0 && iv.Lo <= r[n-1].Hi {
+			r[n-1].Hi = max(r[n-1].Hi, iv.Hi)
+			continue
+		}
+		r = append(r, iv)
+	}
+	return r
+}
+
+// intersect returns the intersection of two normalized interval lists.
+func intersect[E cmp.Ordered](a, b []Interval[E]) []Interval[E] {
+	var r []Interval[E]
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		lo, hi := max(a[i].Lo, b[j].Lo), min(a[i].Hi, b[j].Hi)
+		if lo < hi {
+			r = append(r, Interval[E]{Lo: lo, Hi: hi})
+		}
+		if a[i].Hi < b[j].Hi {
+			i++
+		} else {
+			j++
+		}
+	}
+	return r
+}
+
+// subtract returns the values of normalized list a that are not covered by normalized list b.
+func subtract[E cmp.Ordered](a, b []Interval[E]) []Interval[E] {
+	var r []Interval[E]
+	j := 0
+	for _, iv := range a {
+		lo := iv.Lo
+		for j < len(b) && b[j].Hi <= lo {
+			j++
+		}
+		for k := j; k < len(b) && b[k].Lo < iv.Hi; k++ {
+			if lo < b[k].Lo {
+				r = append(r, Interval[E]{Lo: lo, Hi: b[k].Lo})
+			}
+			lo = max(lo, b[k].Hi)
+		}
+		if lo < iv.Hi {
+			r = append(r, Interval[E]{Lo: lo, Hi: iv.Hi})
+		}
+	}
+	return r
+}
@@ -0,0 +1,512 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sets
+
+import (
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func iv(lo, hi int) Interval[int] {
+	return Interval[int]{Lo: lo, Hi: hi}
+}
+
+func TestInterval(t *testing.T) {
+	tests := []struct {
+		name      string
+		iv        Interval[int]
+		v         int
+		wantEmpty bool
+		wantIn    bool
+		wantStr   string
+	}{
+		{
+			name:      "regular",
+			iv:        iv(1, 3),
+			v:         1,
+			wantEmpty: false,
+			wantIn:    true,
+			wantStr:   "[1, 3)",
+		},
+		{
+			name:      "exclusive upper bound",
+			iv:        iv(1, 3),
+			v:         3,
+			wantEmpty: false,
+			wantIn:    false,
+			wantStr:   "[1, 3)",
+		},
+		{
+			name:      "empty",
+			iv:        iv(2, 2),
+			v:         2,
+			wantEmpty: true,
+			wantIn:    false,
+			wantStr:   "[2, 2)",
+		},
+		{
+			name:      "inverted",
+			iv:        iv(3, 1),
+			v:         2,
+			wantEmpty: true,
+			wantIn:    false,
+			wantStr:   "[3, 1)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.iv.Empty(); got != tt.wantEmpty {
+				t.Errorf("Empty()\nwant: %v\ngot : %v", tt.wantEmpty, got)
+			}
+			if got := tt.iv.Contains(tt.v); got != tt.wantIn {
+				t.Errorf("Contains()\nwant: %v\ngot : %v", tt.wantIn, got)
+			}
+			if got := tt.iv.String(); got != tt.wantStr {
+				t.Errorf("String()\nwant: %v\ngot : %v", tt.wantStr, got)
+			}
+		})
+	}
+}
+
+func TestNewIntervalSet(t *testing.T) {
+	tests := []struct {
+		name      string
+		intervals []Interval[int]
+		want      string
+	}{
+		{
+			name:      "no intervals",
+			intervals: nil,
+			want:      "{}",
+		},
+		{
+			name:      "disjoint unsorted",
+			intervals: []Interval[int]{iv(5, 7), iv(1, 3)},
+			want:      "{[1, 3), [5, 7)}",
+		},
+		{
+			name:      "overlapping",
+			intervals: []Interval[int]{iv(1, 5), iv(3, 8), iv(2, 4)},
+			want:      "{[1, 8)}",
+		},
+		{
+			name:      "adjacent",
+			intervals: []Interval[int]{iv(1, 3), iv(3, 5)},
+			want:      "{[1, 5)}",
+		},
+		{
+			name:      "empty intervals ignored",
+			intervals: []Interval[int]{iv(4, 4), iv(9, 1), iv(1, 2)},
+			want:      "{[1, 2)}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewIntervalSet(tt.intervals...).String(); got != tt.want {
+				t.Errorf("\nwant: %v\ngot : %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestIntervalSetAddRange(t *testing.T) {
+	tests := []struct {
+		name    string
+		initial []Interval[int]
+		lo, hi  int
+		want    string
+	}{
+		{
+			name:    "into empty",
+			initial: nil,
+			lo:      1,
+			hi:      3,
+			want:    "{[1, 3)}",
+		},
+		{
+			name:    "empty range",
+			initial: []Interval[int]{iv(1, 3)},
+			lo:      5,
+			hi:      5,
+			want:    "{[1, 3)}",
+		},
+		{
+			name:    "before all",
+			initial: []Interval[int]{iv(5, 7)},
+			lo:      1,
+			hi:      3,
+			want:    "{[1, 3), [5, 7)}",
+		},
+		{
+			name:    "after all",
+			initial: []Interval[int]{iv(1, 3)},
+			lo:      5,
+			hi:      7,
+			want:    "{[1, 3), [5, 7)}",
+		},
+		{
+			name:    "between",
+			initial: []Interval[int]{iv(1, 2), iv(8, 9)},
+			lo:      4,
+			hi:      6,
+			want:    "{[1, 2), [4, 6), [8, 9)}",
+		},
+		{
+			name:    "touching both neighbours",
+			initial: []Interval[int]{iv(1, 3), iv(5, 7)},
+			lo:      3,
+			hi:      5,
+			want:    "{[1, 7)}",
+		},
+		{
+			name:    "spanning several",
+			initial: []Interval[int]{iv(1, 3), iv(5, 7), iv(9, 11), iv(20, 21)},
+			lo:      2,
+			hi:      10,
+			want:    "{[1, 11), [20, 21)}",
+		},
+		{
+			name:    "inside existing",
+			initial: []Interval[int]{iv(1, 10)},
+			lo:      3,
+			hi:      5,
+			want:    "{[1, 10)}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewIntervalSet(tt.initial...)
+			s.AddRange(tt.lo, tt.hi)
+			if got := s.String(); got != tt.want {
+				t.Errorf("\nwant: %v\ngot : %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestIntervalSetRemoveRange(t *testing.T) {
+	tests := []struct {
+		name    string
+		initial []Interval[int]
+		lo, hi  int
+		want    string
+	}{
+		{
+			name:    "from empty",
+			initial: nil,
+			lo:      1,
+			hi:      3,
+			want:    "{}",
+		},
+		{
+			name:    "empty range",
+			initial: []Interval[int]{iv(1, 3)},
+			lo:      2,
+			hi:      1,
+			want:    "{[1, 3)}",
+		},
+		{
+			name:    "gap",
+			initial: []Interval[int]{iv(1, 3), iv(5, 7)},
+			lo:      3,
+			hi:      5,
+			want:    "{[1, 3), [5, 7)}",
+		},
+		{
+			name:    "split",
+			initial: []Interval[int]{iv(1, 10)},
+			lo:      3,
+			hi:      5,
+			want:    "{[1, 3), [5, 10)}",
+		},
+		{
+			name:    "trim both ends",
+			initial: []Interval[int]{iv(1, 4), iv(5, 6), iv(7, 10)},
+			lo:      2,
+			hi:      8,
+			want:    "{[1, 2), [8, 10)}",
+		},
+		{
+			name:    "remove exactly",
+			initial: []Interval[int]{iv(1, 4), iv(5, 6)},
+			lo:      1,
+			hi:      4,
+			want:    "{[5, 6)}",
+		},
+		{
+			name:    "remove all",
+			initial: []Interval[int]{iv(1, 4), iv(5, 6)},
+			lo:      0,
+			hi:      100,
+			want:    "{}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewIntervalSet(tt.initial...)
+			s.RemoveRange(tt.lo, tt.hi)
+			if got := s.String(); got != tt.want {
+				t.Errorf("\nwant: %v\ngot : %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestIntervalSetContains(t *testing.T) {
+	s := NewIntervalSet(iv(1, 3), iv(5, 8))
+	tests := []struct {
+		name string
+		s    *IntervalSet[int]
+		v    int
+		want bool
+	}{
+		{name: "nil set", s: nil, v: 1, want: false},
+		{name: "below", s: s, v: 0, want: false},
+		{name: "lower bound", s: s, v: 1, want: true},
+		{name: "inside", s: s, v: 6, want: true},
+		{name: "upper bound", s: s, v: 3, want: false},
+		{name: "gap", s: s, v: 4, want: false},
+		{name: "above", s: s, v: 8, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.s.Contains(tt.v); got != tt.want {
+				t.Errorf("\nwant: %v\ngot : %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestIntervalSetContainsRange(t *testing.T) {
+	s := NewIntervalSet(iv(1, 3), iv(5, 8))
+	tests := []struct {
+		name   string
+		lo, hi int
+		want   bool
+	}{
+		{name: "empty range", lo: 4, hi: 4, want: true},
+		{name: "whole interval", lo: 5, hi: 8, want: true},
+		{name: "inside", lo: 6, hi: 7, want: true},
+		{name: "across gap", lo: 2, hi: 6, want: false},
+		{name: "beyond end", lo: 6, hi: 9, want: false},
+		{name: "in gap", lo: 3, hi: 5, want: false},
+		{name: "above all", lo: 10, hi: 12, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := s.ContainsRange(tt.lo, tt.hi); got != tt.want {
+				t.Errorf("\nwant: %v\ngot : %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestIntervalSetOperations(t *testing.T) {
+	a := NewIntervalSet(iv(1, 5), iv(10, 15))
+	b := NewIntervalSet(iv(3, 12))
+	c := NewIntervalSet(iv(14, 20))
+	tests := []struct {
+		name string
+		got  *IntervalSet[int]
+		want string
+	}{
+		{name: "union", got: a.Union(b, c), want: "{[1, 20)}"},
+		{name: "union of none", got: a.Union(), want: "{[1, 5), [10, 15)}"},
+		{name: "union with nil", got: (*IntervalSet[int])(nil).Union(nil, b), want: "{[3, 12)}"},
+		{name: "intersection", got: a.Intersection(b), want: "{[3, 5), [10, 12)}"},
+		{name: "intersection of three", got: a.Intersection(b, c), want: "{}"},
+		{name: "intersection of none", got: a.Intersection(), want: "{[1, 5), [10, 15)}"},
+		{name: "difference", got: a.Difference(b), want: "{[1, 3), [12, 15)}"},
+		{name: "difference of two", got: a.Difference(b, c), want: "{[1, 3), [12, 14)}"},
+		{name: "difference of none", got: a.Difference(), want: "{[1, 5), [10, 15)}"},
+		{name: "difference splits", got: b.Difference(NewIntervalSet(iv(4, 5), iv(6, 7))), want: "{[3, 4), [5, 6), [7, 12)}"},
+		{name: "complement", got: a.Complement(0, 12), want: "{[0, 1), [5, 10)}"},
+		{name: "complement of empty", got: NewIntervalSet[int]().Complement(0, 3), want: "{[0, 3)}"},
+		{name: "complement with empty bounds", got: a.Complement(3, 3), want: "{}"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.got.String(); got != tt.want {
+				t.Errorf("\nwant: %v\ngot : %v", tt.want, got)
+			}
+		})
+	}
+	if got := a.String(); got != "{[1, 5), [10, 15)}" {
+		t.Errorf("operations modified the receiver: %v", got)
+	}
+}
+
+func TestIntervalSetOverlaps(t *testing.T) {
+	a := NewIntervalSet(iv(1, 3), iv(10, 12))
+	tests := []struct {
+		name  string
+		other *IntervalSet[int]
+		want  bool
+	}{
+		{name: "nil", other: nil, want: false},
+		{name: "in gap", other: NewIntervalSet(iv(3, 10)), want: false},
+		{name: "touching is not overlapping", other: NewIntervalSet(iv(12, 14)), want: false},
+		{name: "overlapping first", other: NewIntervalSet(iv(0, 2)), want: true},
+		{name: "overlapping last", other: NewIntervalSet(iv(5, 6), iv(11, 20)), want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := a.Overlaps(tt.other); got != tt.want {
+				t.Errorf("\nwant: %v\ngot : %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestIntervalSetEqualCloneLen(t *testing.T) {
+	var zero IntervalSet[int]
+	zero.AddRange(1, 3)
+	zero.AddRange(5, 6)
+
+	clone := zero.Clone()
+	if !clone.Equal(&zero) {
+		t.Errorf("Clone() is not equal to the original")
+	}
+	clone.AddRange(3, 5)
+	if clone.Equal(&zero) {
+		t.Errorf("Clone() shares storage with the original")
+	}
+	if got := zero.NumIntervals(); got != 2 {
+		t.Errorf("\nwant: %v\ngot : %v", 2, got)
+	}
+	if got := clone.NumIntervals(); got != 1 {
+		t.Errorf("\nwant: %v\ngot : %v", 1, got)
+	}
+	if !(*IntervalSet[int])(nil).Equal(NewIntervalSet[int]()) {
+		t.Errorf("nil set is not equal to an empty set")
+	}
+}
+
+func TestIntervalSetIntervals(t *testing.T) {
+	s := NewIntervalSet(iv(5, 6), iv(1, 2), iv(3, 4))
+	got := slices.Collect(s.Intervals())
+	if want := []Interval[int]{iv(1, 2), iv(3, 4), iv(5, 6)}; !slices.Equal(got, want) {
+		t.Errorf("\nwant: %v\ngot : %v", want, got)
+	}
+
+	count := 0
+	for range s.Intervals() {
+		count++
+		break
+	}
+	if count != 1 {
+		t.Errorf("expected to iterate 1 time, got %d", count)
+	}
+}
+
+func TestIntervalSetFloat(t *testing.T) {
+	s := NewIntervalSet[float64]()
+	s.AddRange(0, 1.5)
+	s.AddRange(math.NaN(), 10)
+	s.AddRange(2, math.Inf(1))
+
+	if s.Contains(math.NaN()) {
+		t.Errorf("NaN is reported as a member")
+	}
+	if !s.Contains(1e300) || s.Contains(1.75) {
+		t.Errorf("Contains() returned wrong result")
+	}
+	if got, want := s.String(), "{[0, 1.5), [2, +Inf)}"; got != want {
+		t.Errorf("\nwant: %v\ngot : %v", want, got)
+	}
+}
+
+func TestToIntervalSet(t *testing.T) {
+	tests := []struct {
+		name string
+		s    Set[int]
+		want string
+	}{
+		{name: "nil", s: nil, want: "{}"},
+		{name: "single", s: From(4), want: "{[4, 5)}"},
+		{name: "runs", s: From(1, 2, 3, 7, 8, -1), want: "{[-1, 0), [1, 4), [7, 9)}"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ToIntervalSet(tt.s)
+			if got.String() != tt.want {
+				t.Errorf("\nwant: %v\ngot : %v", tt.want, got)
+			}
+			if back := FromIntervalSet(got); !Equal(back, tt.s) {
+				t.Errorf("round trip\nwant: %v\ngot : %v", tt.s, back)
+			}
+		})
+	}
+}
+
+func TestToIntervalSetPanic(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("ToIntervalSet() should panic on the maximum value, but did not panic")
+		}
+	}()
+	ToIntervalSet(From[uint8](1, math.MaxUint8))
+}
+
+func TestIntervalSetAgainstSet(t *testing.T) {
+	const universe = 40
+	r := rand.New(rand.NewPCG(1, 2))
+	random := func() (*IntervalSet[int], Set[int]) {
+		is := NewIntervalSet[int]()
+		for range r.IntN(6) {
+			lo := r.IntN(universe)
+			hi := lo + r.IntN(8)
+			if r.IntN(3) == 0 {
+				is.RemoveRange(lo, hi)
+			} else {
+				is.AddRange(lo, hi)
+			}
+		}
+		return is, FromIntervalSet(is)
+	}
+	for range 500 {
+		a, sa := random()
+		b, sb := random()
+		c, sc := random()
+
+		if got, want := FromIntervalSet(a.Union(b, c)), Union(sa, sb, sc); !Equal(got, want) {
+			t.Fatalf("Union(%v, %v, %v)\nwant: %v\ngot : %v", a, b, c, want, got)
+		}
+		if got, want := FromIntervalSet(a.Intersection(b, c)), Intersection(sa, sb, sc); !Equal(got, want) {
+			t.Fatalf("Intersection(%v, %v, %v)\nwant: %v\ngot : %v", a, b, c, want, got)
+		}
+		if got, want := FromIntervalSet(a.Difference(b, c)), Difference(sa, sb, sc); !Equal(got, want) {
+			t.Fatalf("Difference(%v, %v, %v)\nwant: %v\ngot : %v", a, b, c, want, got)
+		}
+		bounds := FromIntervalSet(NewIntervalSet(iv(0, universe)))
+		if got, want := FromIntervalSet(a.Complement(0, universe)), Difference(bounds, sa); !Equal(got, want) {
+			t.Fatalf("Complement(%v)\nwant: %v\ngot : %v", a, want, got)
+		}
+		if got, want := a.Overlaps(b), Overlaps(sa, sb); got != want {
+			t.Fatalf("Overlaps(%v, %v)\nwant: %v\ngot : %v", a, b, want, got)
+		}
+		if !ToIntervalSet(sa).Equal(a) {
+			t.Fatalf("representation of %v is not unique", a)
+		}
+	}
+}
@@ -0,0 +1,494 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sets
+
+import (
+	"iter"
+	"net/netip"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// IPRange is an inclusive range of IP addresses of the same family.
+type IPRange struct {
+	From netip.Addr // First address of the range.
+	To   netip.Addr // Last address of the range.
+}
+
+// IPRangeFrom returns the range of addresses covered by p.
+// If p is not valid, the zero IPRange is returned.
+//
+// Time complexity: O(1). Space complexity: O(1).
+func IPRangeFrom(p netip.Prefix) IPRange {
+	if !p.IsValid() {
+		return IPRange{}
+	}
+	p = p.Masked()
+	return IPRange{From: p.Addr(), To: lastAddr(p)}
+}
+
+// IsValid reports whether both ends of r are valid addresses of the same family and From is not after To.
+//
+// Time complexity: O(1). Space complexity: O(1).
+func (r IPRange) IsValid() bool {
+	return r.From.IsValid() && r.To.IsValid() && r.From.Is4() == r.To.Is4() && r.From.Compare(r.To) <= 0
+}
+
+// Contains reports whether addr lies within r.
+//
+// Time complexity: O(1). Space complexity: O(1).
+func (r IPRange) Contains(addr netip.Addr) bool {
+	return r.IsValid() && addr.Is4() == r.From.Is4() && r.From.Compare(addr) <= 0 && addr.Compare(r.To) <= 0
+}
+
+// Prefixes returns the minimal list of prefixes that exactly cover r.
+// If r is not valid, Prefixes returns nil.
+//
+// Time complexity: O(b^2). Space complexity: O(b). b is the bit length of the addresses.
+func (r IPRange) Prefixes() []netip.Prefix {
+	if !r.IsValid() {
+		return nil
+	}
+	return r.appendPrefixes(nil)
+}
+
+// String returns a string representation of the range in the format "from-to".
+//
+// Time complexity: O(1). Space complexity: O(1).
+func (r IPRange) String() string {
+	return r.From.String() + "-" + r.To.String()
+}
+
+func (r IPRange) appendPrefixes(dst []netip.Prefix) []netip.Prefix {
+	from := r.From
+	for {
+		// Grow the prefix starting at from while it stays aligned and within r.
+		bits := from.BitLen()
+		for bits > 0 {
+			p := netip.PrefixFrom(from, bits-1)
+			if p.Masked().Addr() != from || lastAddr(p).Compare(r.To) > 0 {
+				break
+			}
+			bits--
+		}
+		p := netip.PrefixFrom(from, bits)
+		dst = append(dst, p)
+		last := lastAddr(p)
+		if last == r.To {
+			return dst
+		}
+		from = last.Next()
+	}
+}
+
+// IPSet is a set of IPv4 and IPv6 addresses stored as a sorted list of disjoint address ranges.
+// Overlapping and adjacent ranges are merged, so the representation of a set is unique.
+// The zero value is an empty set ready to use. Read-only methods accept a nil *IPSet.
+//
+// Address zones are ignored. IPv4-mapped IPv6 addresses belong to the IPv6 family
+// and are distinct from the corresponding IPv4 addresses; use netip.Addr.Unmap to treat them as IPv4.
+type IPSet struct {
+	rs []IPRange
+}
+
+// NewIPSet creates a new IPSet containing the provided prefixes. Invalid prefixes are ignored.
+//
+// Time complexity: O(n*log(n)). Space complexity: O(n). n is the number of prefixes.
+func NewIPSet(prefixes ...netip.Prefix) *IPSet {
+	rs := make([]IPRange, 0, len(prefixes))
+	for _, p := range prefixes {
+		if p.IsValid() {
+			rs = append(rs, IPRangeFrom(p))
+		}
+	}
+	return &IPSet{rs: normalizeIPRanges(rs)}
+}
+
+// ToIPSet returns an IPSet containing the addresses of s. Invalid addresses are ignored.
+//
+// Time complexity: O(len(s)*log(len(s))). Space complexity: O(len(s)).
+func ToIPSet[S ~map[netip.Addr]struct{}](s S) *IPSet {
+	rs := make([]IPRange, 0, len(s))
+	for addr := range s {
+		if addr.IsValid() {
+			addr = addr.WithZone("")
+			rs = append(rs, IPRange{From: addr, To: addr})
+		}
+	}
+	return &IPSet{rs: normalizeIPRanges(rs)}
+}
+
+// FromIPSet returns a new Set containing every address covered by s.
+// The result is as large as the number of addresses in s, so use it only for sets of exact addresses
+// or small subnets.
+//
+// Time complexity: O(N). Space complexity: O(N). N is the number of addresses in s.
+func FromIPSet(s *IPSet) Set[netip.Addr] {
+	r := New[netip.Addr](0)
+	for _, rng := range s.ranges() {
+		for addr := rng.From; ; addr = addr.Next() {
+			r[addr] = struct{}{}
+			if addr == rng.To {
+				break
+			}
+		}
+	}
+	return r
+}
+
+// AddAddr adds addr to the set. If addr is not valid, AddAddr is a no-op.
+//
+// Time complexity: O(n). Space complexity: O(1). n is the number of ranges.
+func (s *IPSet) AddAddr(addr netip.Addr) {
+	s.AddRange(IPRange{From: addr, To: addr})
+}
+
+// AddPrefix adds all addresses of p to the set. If p is not valid, AddPrefix is a no-op.
+//
+// Time complexity: O(n). Space complexity: O(1). n is the number of ranges.
+func (s *IPSet) AddPrefix(p netip.Prefix) {
+	s.AddRange(IPRangeFrom(p))
+}
+
+// AddRange adds all addresses of r to the set. If r is not valid, AddRange is a no-op.
+//
+// Time complexity: O(n). Space complexity: O(1). n is the number of ranges.
+func (s *IPSet) AddRange(r IPRange) {
+	r = stripZones(r)
+	if !r.IsValid() {
+		return
+	}
+	// Ranges in [i, j) overlap or touch r and are merged with it.
+	lower, upper := r.From, r.To
+	if prev := r.From.Prev(); prev.IsValid() {
+		lower = prev
+	}
+	if next := r.To.Next(); next.IsValid() {
+		upper = next
+	}
+	i := sort.Search(len(s.rs), func(k int) bool { return s.rs[k].To.Compare(lower) >= 0 })
+	j := sort.Search(len(s.rs), func(k int) bool { return s.rs[k].From.Compare(upper) > 0 })
+	if i < j {
+		r.From = minAddr(r.From, s.rs[i].From)
+		r.To = maxAddr(r.To, s.rs[j-1].To)
+	}
+	s.rs = slices.Replace(s.rs, i, j, r)
+}
+
+// RemoveAddr removes addr from the set. If addr is not valid, RemoveAddr is a no-op.
+//
+// Time complexity: O(n). Space complexity: O(1). n is the number of ranges.
+func (s *IPSet) RemoveAddr(addr netip.Addr) {
+	s.RemoveRange(IPRange{From: addr, To: addr})
+}
+
+// RemovePrefix removes all addresses of p from the set. If p is not valid, RemovePrefix is a no-op.
+//
+// Time complexity: O(n). Space complexity: O(1). n is the number of ranges.
+func (s *IPSet) RemovePrefix(p netip.Prefix) {
+	s.RemoveRange(IPRangeFrom(p))
+}
+
+// RemoveRange removes all addresses of r from the set. If r is not valid, RemoveRange is a no-op.
+//
+// Time complexity: O(n). Space complexity: O(1). n is the number of ranges.
+func (s *IPSet) RemoveRange(r IPRange) {
+	r = stripZones(r)
+	if !r.IsValid() {
+		return
+	}
+	// Ranges in [i, j) overlap r; only their parts outside of it remain.
+	i := sort.Search(len(s.rs), func(k int) bool { return s.rs[k].To.Compare(r.From) >= 0 })
+	j := sort.Search(len(s.rs), func(k int) bool { return s.rs[k].From.Compare(r.To) > 0 })
+	if i == j {
+		return
+	}
+	rest := make([]IPRange, 0, 2)
+	if first := s.rs[i]; first.From.Compare(r.From) < 0 {
+		rest = append(rest, IPRange{From: first.From, To: r.From.Prev()})
+	}
+	if last := s.rs[j-1]; r.To.Compare(last.To) < 0 {
+		rest = append(rest, IPRange{From: r.To.Next(), To: last.To})
+	}
+	s.rs = slices.Replace(s.rs, i, j, rest...)
+}
+
+// Contains reports whether addr is present in the set.
+//
+// Time complexity: O(log(n)). Space complexity: O(1). n is the number of ranges.
+func (s *IPSet) Contains(addr netip.Addr) bool {
+	addr = addr.WithZone("")
+	rs := s.ranges()
+	i := sort.Search(len(rs), func(k int) bool { return rs[k].To.Compare(addr) >= 0 })
+	return i < len(rs) && rs[i].Contains(addr)
+}
+
+// ContainsPrefix reports whether all addresses of p are present in the set.
+// An invalid prefix is not contained in any set.
+//
+// Time complexity: O(log(n)). Space complexity: O(1). n is the number of ranges.
+func (s *IPSet) ContainsPrefix(p netip.Prefix) bool {
+	r := IPRangeFrom(p)
+	if !r.IsValid() {
+		return false
+	}
+	rs := s.ranges()
+	i := sort.Search(len(rs), func(k int) bool { return rs[k].To.Compare(r.From) >= 0 })
+	return i < len(rs) && rs[i].Contains(r.From) && rs[i].Contains(r.To)
+}
+
+// Overlaps reports whether s and other have any address in common.
+//
+// Time complexity: O(n + m). Space complexity: O(1). n and m are the numbers of ranges.
+func (s *IPSet) Overlaps(other *IPSet) bool {
+	a, b := s.ranges(), other.ranges()
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		if a[i].From.Compare(b[j].To) <= 0 && b[j].From.Compare(a[i].To) <= 0 {
+			return true
+		}
+		if a[i].To.Compare(b[j].To) < 0 {
+			i++
+		} else {
+			j++
+		}
+	}
+	return false
+}
+
+// Equal reports whether s and other contain the same addresses.
+//
+// Time complexity: O(n). Space complexity: O(1). n is the number of ranges.
+func (s *IPSet) Equal(other *IPSet) bool {
+	return slices.Equal(s.ranges(), other.ranges())
+}
+
+// Union returns a new set containing all addresses present in s or in any of the others.
+//
+// Time complexity: O(N*log(N)). Space complexity: O(N). N is the total number of ranges.
+func (s *IPSet) Union(others ...*IPSet) *IPSet {
+	all := slices.Clone(s.ranges())
+	for _, o := range others {
+		all = append(all, o.ranges()...)
+	}
+	return &IPSet{rs: normalizeIPRanges(all)}
+}
+
+// Intersection returns a new set containing only addresses present in s and in all of the others.
+//
+// Time complexity: O(N). Space complexity: O(N). N is the total number of ranges.
+func (s *IPSet) Intersection(others ...*IPSet) *IPSet {
+	r := slices.Clone(s.ranges())
+	for _, o := range others {
+		r = intersectIPRanges(r, o.ranges())
+	}
+	return &IPSet{rs: r}
+}
+
+// Difference returns a new set containing addresses present in s but not in any of the others.
+//
+// Time complexity: O(N*log(N)). Space complexity: O(N). N is the total number of ranges.
+func (s *IPSet) Difference(others ...*IPSet) *IPSet {
+	if len(others) == 0 {
+		return s.Clone()
+	}
+	subtrahend := others[0].Union(others[1:]...)
+	return &IPSet{rs: subtractIPRanges(s.ranges(), subtrahend.rs)}
+}
+
+// Complement returns a new set containing all IPv4 and IPv6 addresses that are not present in s.
+// Intersect the result with a set holding 0.0.0.0/0 or ::/0 to restrict it to a single family.
+//
+// Time complexity: O(n). Space complexity: O(n). n is the number of ranges.
+func (s *IPSet) Complement() *IPSet {
+	all := []IPRange{
+		IPRangeFrom(netip.PrefixFrom(netip.IPv4Unspecified(), 0)),
+		IPRangeFrom(netip.PrefixFrom(netip.IPv6Unspecified(), 0)),
+	}
+	return &IPSet{rs: subtractIPRanges(all, s.ranges())}
+}
+
+// NumRanges returns the number of disjoint address ranges in the set, not the number of addresses.
+//
+// Time complexity: O(1). Space complexity: O(1).
+func (s *IPSet) NumRanges() int {
+	return len(s.ranges())
+}
+
+// Ranges returns an iterator over the disjoint address ranges of the set in ascending order.
+// IPv4 ranges are yielded before IPv6 ranges.
+//
+// Creation: O(1) time, O(1) space.
+// Iteration: O(n) time, O(1) space. n is the number of ranges.
+func (s *IPSet) Ranges() iter.Seq[IPRange] {
+	return func(yield func(IPRange) bool) {
+		for _, r := range s.ranges() {
+			if !yield(r) {
+				return
+			}
+		}
+	}
+}
+
+// Prefixes returns an iterator over the minimal list of prefixes that exactly cover the set, in ascending order.
+//
+// Creation: O(1) time, O(1) space.
+// Iteration: O(n*b^2) time, O(b) space. n is the number of ranges. b is the bit length of the addresses.
+func (s *IPSet) Prefixes() iter.Seq[netip.Prefix] {
+	return func(yield func(netip.Prefix) bool) {
+		for _, r := range s.ranges() {
+			for _, p := range r.appendPrefixes(nil) {
+				if !yield(p) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Clone returns a copy of s.
+//
+// Time complexity: O(n). Space complexity: O(n). n is the number of ranges.
+func (s *IPSet) Clone() *IPSet {
+	return &IPSet{rs: slices.Clone(s.ranges())}
+}
+
+// String returns a string representation of the set as its minimal prefixes in the format "{prefix1, prefix2, ...}".
+//
+// Time complexity: O(n*b^2). Space complexity: O(n*b). n is the number of ranges. b is the bit length of the addresses.
+func (s *IPSet) String() string {
+	var elements []string
+	for p := range s.Prefixes() {
+		elements = append(elements, p.String())
+	}
+	return "{" + strings.Join(elements, ", ") + "}"
+}
+
+func (s *IPSet) ranges() []IPRange {
+	if s == nil {
+		return nil
+	}
+	return s.rs
+}
+
+// normalizeIPRanges sorts valid ranges in place and merges overlapping and adjacent ones.
+func normalizeIPRanges(rs []IPRange) []IPRange {
+	slices.SortFunc(rs, func(a, b IPRange) int { return a.From.Compare(b.From) })
+	r := rs[:0]
+	for _, rng := range rs {
+		if n := len(r); n > 0 {
+			last := &r[n-1]
+			if next := last.To.Next(); rng.From.Compare(last.To) <= 0 || rng.From == next {
+				last.To = maxAddr(last.To, rng.To)
+				continue
+			}
+		}
+		r = append(r, rng)
+	}
+	return r
+}
+
+// intersectIPRanges returns the intersection of two normalized range lists.
+func intersectIPRanges(a, b []IPRange) []IPRange {
+	var r []IPRange
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		from, to := maxAddr(a[i].From, b[j].From), minAddr(a[i].To, b[j].To)
+		if from.Compare(to) <= 0 {
+			r = append(r, IPRange{From: from, To: to})
+		}
+		if a[i].To.Compare(b[j].To) < 0 {
+			i++
+		} else {
+			j++
+		}
+	}
+	return r
+}
+
+// subtractIPRanges returns the addresses of normalized list a that are not covered by normalized list b.
+func subtractIPRanges(a, b []IPRange) []IPRange {
+	var r []IPRange
+	j := 0
+	for _, rng := range a {
+		from := rng.From
+		for j < len(b) && b[j].To.Compare(from) < 0 {
+			j++
+		}
+		covered := false
+		for k := j; k < len(b) && b[k].From.Compare(rng.To) <= 0; k++ {
+			if from.Compare(b[k].From) < 0 {
+				r = append(r, IPRange{From: from, To: b[k].From.Prev()})
+			}
+			if b[k].To.Compare(rng.To) >= 0 {
+				covered = true
+				break
+			}
+			from = b[k].To.Next()
+		}
+		if !covered {
+			r = append(r, IPRange{From: from, To: rng.To})
+		}
+	}
+	return r
+}
+
+// lastAddr returns the last address covered by p.
+func lastAddr(p netip.Prefix) netip.Addr {
+	addr := p.Masked().Addr()
+	if addr.Is4() {
+		b := addr.As4()
+		setHostBits(b[:], p.Bits())
+		return netip.AddrFrom4(b)
+	}
+	b := addr.As16()
+	setHostBits(b[:], p.Bits())
+	return netip.AddrFrom16(b)
+}
+
+// setHostBits sets all bits of b after the first bits bits.
+func setHostBits(b []byte, bits int) {
+	for i := range b {
+		switch start := i * 8; {
+		case start >= bits:
+			b[i] = 0xff
+		case start+8 > bits:
+			b[i] |= 0xff >> (bits - start)
+		}
+	}
+}
+
+func stripZones(r IPRange) IPRange {
+	return IPRange{From: r.From.WithZone(""), To: r.To.WithZone("")}
+}
+
+func minAddr(a, b netip.Addr) netip.Addr {
+	if a.Compare(b) <= 0 {
+		return a
+	}
+	return b
+}
+
+func maxAddr(a, b netip.Addr) netip.Addr {
+	if a.Compare(b) >= 0 {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,503 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sets
+
+import (
+	"math/rand/v2"
+	"net/netip"
+	"slices"
+	"testing"
+)
+
+func ipSet(prefixes ...string) *IPSet {
+	s := NewIPSet()
+	for _, p := range prefixes {
+		s.AddPrefix(netip.MustParsePrefix(p))
+	}
+	return s
+}
+
+func ipRange(from, to string) IPRange {
+	return IPRange{From: netip.MustParseAddr(from), To: netip.MustParseAddr(to)}
+}
+
+func TestIPRange(t *testing.T) {
+	tests := []struct {
+		name         string
+		r            IPRange
+		addr         string
+		wantValid    bool
+		wantContains bool
+		wantPrefixes []string
+		wantString   string
+	}{
+		{
+			name:         "single address",
+			r:            ipRange("10.0.0.1", "10.0.0.1"),
+			addr:         "10.0.0.1",
+			wantValid:    true,
+			wantContains: true,
+			wantPrefixes: []string{"10.0.0.1/32"},
+			wantString:   "10.0.0.1-10.0.0.1",
+		},
+		{
+			name:         "unaligned range",
+			r:            ipRange("10.0.0.1", "10.0.0.10"),
+			addr:         "10.0.0.11",
+			wantValid:    true,
+			wantContains: false,
+			wantPrefixes: []string{"10.0.0.1/32", "10.0.0.2/31", "10.0.0.4/30", "10.0.0.8/31", "10.0.0.10/32"},
+			wantString:   "10.0.0.1-10.0.0.10",
+		},
+		{
+			name:         "whole ipv4 space",
+			r:            ipRange("0.0.0.0", "255.255.255.255"),
+			addr:         "::1",
+			wantValid:    true,
+			wantContains: false,
+			wantPrefixes: []string{"0.0.0.0/0"},
+			wantString:   "0.0.0.0-255.255.255.255",
+		},
+		{
+			name:         "ipv6",
+			r:            ipRange("2001:db8::", "2001:db8::ffff"),
+			addr:         "2001:db8::1",
+			wantValid:    true,
+			wantContains: true,
+			wantPrefixes: []string{"2001:db8::/112"},
+			wantString:   "2001:db8::-2001:db8::ffff",
+		},
+		{
+			name:         "inverted",
+			r:            ipRange("10.0.0.2", "10.0.0.1"),
+			addr:         "10.0.0.1",
+			wantValid:    false,
+			wantContains: false,
+			wantPrefixes: nil,
+			wantString:   "10.0.0.2-10.0.0.1",
+		},
+		{
+			name:         "mixed families",
+			r:            ipRange("10.0.0.1", "::1"),
+			addr:         "10.0.0.1",
+			wantValid:    false,
+			wantContains: false,
+			wantPrefixes: nil,
+			wantString:   "10.0.0.1-::1",
+		},
+		{
+			name:         "zero",
+			r:            IPRange{},
+			addr:         "10.0.0.1",
+			wantValid:    false,
+			wantContains: false,
+			wantPrefixes: nil,
+			wantString:   "invalid IP-invalid IP",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.r.IsValid(); got != tt.wantValid {
+				t.Errorf("IsValid()\nwant: %v\ngot : %v", tt.wantValid, got)
+			}
+			if got := tt.r.Contains(netip.MustParseAddr(tt.addr)); got != tt.wantContains {
+				t.Errorf("Contains()\nwant: %v\ngot : %v", tt.wantContains, got)
+			}
+			var got []string
+			for _, p := range tt.r.Prefixes() {
+				got = append(got, p.String())
+			}
+			if !slices.Equal(got, tt.wantPrefixes) {
+				t.Errorf("Prefixes()\nwant: %v\ngot : %v", tt.wantPrefixes, got)
+			}
+			if got := tt.r.String(); got != tt.wantString {
+				t.Errorf("String()\nwant: %v\ngot : %v", tt.wantString, got)
+			}
+		})
+	}
+}
+
+func TestIPRangeFrom(t *testing.T) {
+	if got, want := IPRangeFrom(netip.MustParsePrefix("10.1.2.3/16")), ipRange("10.1.0.0", "10.1.255.255"); got != want {
+		t.Errorf("\nwant: %v\ngot : %v", want, got)
+	}
+	if got := IPRangeFrom(netip.Prefix{}); got != (IPRange{}) {
+		t.Errorf("\nwant: %v\ngot : %v", IPRange{}, got)
+	}
+}
+
+func TestIPSetAdd(t *testing.T) {
+	tests := []struct {
+		name string
+		add  func(s *IPSet)
+		want string
+	}{
+		{
+			name: "merges adjacent prefixes",
+			add: func(s *IPSet) {
+				s.AddPrefix(netip.MustParsePrefix("10.0.0.0/25"))
+				s.AddPrefix(netip.MustParsePrefix("10.0.0.128/25"))
+			},
+			want: "{10.0.0.0/24}",
+		},
+		{
+			name: "merges overlapping prefixes",
+			add: func(s *IPSet) {
+				s.AddPrefix(netip.MustParsePrefix("10.0.0.0/24"))
+				s.AddPrefix(netip.MustParsePrefix("10.0.0.0/8"))
+				s.AddPrefix(netip.MustParsePrefix("10.20.0.0/16"))
+			},
+			want: "{10.0.0.0/8}",
+		},
+		{
+			name: "fills gap",
+			add: func(s *IPSet) {
+				s.AddAddr(netip.MustParseAddr("10.0.0.0"))
+				s.AddAddr(netip.MustParseAddr("10.0.0.2"))
+				s.AddAddr(netip.MustParseAddr("10.0.0.3"))
+				s.AddAddr(netip.MustParseAddr("10.0.0.1"))
+			},
+			want: "{10.0.0.0/30}",
+		},
+		{
+			name: "keeps families apart",
+			add: func(s *IPSet) {
+				s.AddRange(ipRange("255.255.255.255", "255.255.255.255"))
+				s.AddRange(ipRange("::", "::"))
+				s.AddRange(ipRange("0.0.0.0", "0.0.0.0"))
+			},
+			want: "{0.0.0.0/32, 255.255.255.255/32, ::/128}",
+		},
+		{
+			name: "ignores invalid input and zones",
+			add: func(s *IPSet) {
+				s.AddAddr(netip.Addr{})
+				s.AddPrefix(netip.Prefix{})
+				s.AddRange(ipRange("10.0.0.2", "10.0.0.1"))
+				s.AddAddr(netip.MustParseAddr("fe80::1%eth0"))
+			},
+			want: "{fe80::1/128}",
+		},
+		{
+			name: "unaligned range",
+			add: func(s *IPSet) {
+				s.AddRange(ipRange("192.168.0.255", "192.168.2.0"))
+			},
+			want: "{192.168.0.255/32, 192.168.1.0/24, 192.168.2.0/32}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var s IPSet
+			tt.add(&s)
+			if got := s.String(); got != tt.want {
+				t.Errorf("\nwant: %v\ngot : %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestIPSetRemove(t *testing.T) {
+	tests := []struct {
+		name   string
+		s      *IPSet
+		remove func(s *IPSet)
+		want   string
+	}{
+		{
+			name:   "split prefix",
+			s:      ipSet("10.0.0.0/30"),
+			remove: func(s *IPSet) { s.RemoveAddr(netip.MustParseAddr("10.0.0.1")) },
+			want:   "{10.0.0.0/32, 10.0.0.2/31}",
+		},
+		{
+			name:   "remove subnet",
+			s:      ipSet("10.0.0.0/8", "2001:db8::/32"),
+			remove: func(s *IPSet) { s.RemovePrefix(netip.MustParsePrefix("10.128.0.0/9")) },
+			want:   "{10.0.0.0/9, 2001:db8::/32}",
+		},
+		{
+			name:   "remove across ranges",
+			s:      ipSet("10.0.0.0/24", "10.0.2.0/24"),
+			remove: func(s *IPSet) { s.RemoveRange(ipRange("10.0.0.128", "10.0.2.127")) },
+			want:   "{10.0.0.0/25, 10.0.2.128/25}",
+		},
+		{
+			name:   "remove absent",
+			s:      ipSet("10.0.0.0/24"),
+			remove: func(s *IPSet) { s.RemoveAddr(netip.MustParseAddr("10.0.1.0")) },
+			want:   "{10.0.0.0/24}",
+		},
+		{
+			name:   "remove invalid",
+			s:      ipSet("10.0.0.0/24"),
+			remove: func(s *IPSet) { s.RemovePrefix(netip.Prefix{}) },
+			want:   "{10.0.0.0/24}",
+		},
+		{
+			name:   "remove family",
+			s:      ipSet("10.0.0.0/24", "::/0"),
+			remove: func(s *IPSet) { s.RemovePrefix(netip.MustParsePrefix("0.0.0.0/0")) },
+			want:   "{::/0}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.remove(tt.s)
+			if got := tt.s.String(); got != tt.want {
+				t.Errorf("\nwant: %v\ngot : %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestIPSetContains(t *testing.T) {
+	s := ipSet("10.0.0.0/8", "192.168.1.0/24", "2001:db8::/32")
+	tests := []struct {
+		name string
+		s    *IPSet
+		addr string
+		want bool
+	}{
+		{name: "nil set", s: nil, addr: "10.0.0.1", want: false},
+		{name: "in first", s: s, addr: "10.255.255.255", want: true},
+		{name: "between", s: s, addr: "11.0.0.0", want: false},
+		{name: "in second", s: s, addr: "192.168.1.7", want: true},
+		{name: "ipv6", s: s, addr: "2001:db8:1::1", want: true},
+		{name: "ipv6 outside", s: s, addr: "2001:db9::1", want: false},
+		{name: "mapped is ipv6", s: s, addr: "::ffff:10.0.0.1", want: false},
+		{name: "zone ignored", s: s, addr: "2001:db8::1%eth0", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.s.Contains(netip.MustParseAddr(tt.addr)); got != tt.want {
+				t.Errorf("\nwant: %v\ngot : %v", tt.want, got)
+			}
+		})
+	}
+	if s.Contains(netip.Addr{}) {
+		t.Errorf("Contains() reported an invalid address")
+	}
+}
+
+func TestIPSetContainsPrefix(t *testing.T) {
+	s := ipSet("10.0.0.0/8", "192.168.1.0/24")
+	tests := []struct {
+		name   string
+		prefix netip.Prefix
+		want   bool
+	}{
+		{name: "inside", prefix: netip.MustParsePrefix("10.1.0.0/16"), want: true},
+		{name: "equal", prefix: netip.MustParsePrefix("192.168.1.0/24"), want: true},
+		{name: "larger", prefix: netip.MustParsePrefix("192.168.0.0/16"), want: false},
+		{name: "outside", prefix: netip.MustParsePrefix("172.16.0.0/12"), want: false},
+		{name: "beyond all", prefix: netip.MustParsePrefix("224.0.0.0/4"), want: false},
+		{name: "invalid", prefix: netip.Prefix{}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := s.ContainsPrefix(tt.prefix); got != tt.want {
+				t.Errorf("\nwant: %v\ngot : %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestIPSetOperations(t *testing.T) {
+	a := ipSet("10.0.0.0/8", "2001:db8::/32")
+	b := ipSet("10.128.0.0/9", "172.16.0.0/12")
+	c := ipSet("2001:db8:8000::/33")
+	tests := []struct {
+		name string
+		got  *IPSet
+		want string
+	}{
+		{name: "union", got: a.Union(b, c), want: "{10.0.0.0/8, 172.16.0.0/12, 2001:db8::/32}"},
+		{name: "union with nil", got: (*IPSet)(nil).Union(nil, b), want: "{10.128.0.0/9, 172.16.0.0/12}"},
+		{name: "intersection", got: a.Intersection(b), want: "{10.128.0.0/9}"},
+		{name: "intersection of none", got: a.Intersection(), want: "{10.0.0.0/8, 2001:db8::/32}"},
+		{name: "difference", got: a.Difference(b, c), want: "{10.0.0.0/9, 2001:db8::/33}"},
+		{name: "difference of none", got: a.Difference(), want: "{10.0.0.0/8, 2001:db8::/32}"},
+		{name: "difference splits", got: a.Difference(ipSet("10.1.0.0/16", "10.3.0.0/16")), want: "{10.0.0.0/16, 10.2.0.0/16, 10.4.0.0/14, 10.8.0.0/13, 10.16.0.0/12, 10.32.0.0/11, 10.64.0.0/10, 10.128.0.0/9, 2001:db8::/32}"},
+		{name: "complement of empty", got: NewIPSet().Complement(), want: "{0.0.0.0/0, ::/0}"},
+		{name: "complement of everything", got: ipSet("0.0.0.0/0", "::/0").Complement(), want: "{}"},
+		{name: "complement", got: ipSet("128.0.0.0/1", "::/1").Complement(), want: "{0.0.0.0/1, 8000::/1}"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.got.String(); got != tt.want {
+				t.Errorf("\nwant: %v\ngot : %v", tt.want, got)
+			}
+		})
+	}
+	if got := a.String(); got != "{10.0.0.0/8, 2001:db8::/32}" {
+		t.Errorf("operations modified the receiver: %v", got)
+	}
+}
+
+func TestIPSetOverlaps(t *testing.T) {
+	a := ipSet("10.0.0.0/24", "10.0.2.0/24")
+	tests := []struct {
+		name  string
+		other *IPSet
+		want  bool
+	}{
+		{name: "nil", other: nil, want: false},
+		{name: "in gap", other: ipSet("10.0.1.0/24"), want: false},
+		{name: "other family", other: ipSet("::/0"), want: false},
+		{name: "first", other: ipSet("10.0.0.7/32"), want: true},
+		{name: "last", other: ipSet("10.0.1.0/24", "10.0.2.255/32"), want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := a.Overlaps(tt.other); got != tt.want {
+				t.Errorf("\nwant: %v\ngot : %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestIPSetIteration(t *testing.T) {
+	s := NewIPSet(
+		netip.MustParsePrefix("2001:db8::/32"),
+		netip.MustParsePrefix("10.0.0.0/24"),
+		netip.Prefix{},
+	)
+	s.AddRange(ipRange("10.0.1.0", "10.0.1.2"))
+
+	ranges := slices.Collect(s.Ranges())
+	wantRanges := []IPRange{ipRange("10.0.0.0", "10.0.1.2"), ipRange("2001:db8::", "2001:db8:ffff:ffff:ffff:ffff:ffff:ffff")}
+	if !slices.Equal(ranges, wantRanges) {
+		t.Errorf("\nwant: %v\ngot : %v", wantRanges, ranges)
+	}
+	if got := s.NumRanges(); got != 2 {
+		t.Errorf("\nwant: %v\ngot : %v", 2, got)
+	}
+
+	var prefixes []string
+	for p := range s.Prefixes() {
+		prefixes = append(prefixes, p.String())
+	}
+	wantPrefixes := []string{"10.0.0.0/24", "10.0.1.0/31", "10.0.1.2/32", "2001:db8::/32"}
+	if !slices.Equal(prefixes, wantPrefixes) {
+		t.Errorf("\nwant: %v\ngot : %v", wantPrefixes, prefixes)
+	}
+
+	count := 0
+	for range s.Ranges() {
+		count++
+		break
+	}
+	for range s.Prefixes() {
+		count++
+		break
+	}
+	if count != 2 {
+		t.Errorf("expected to iterate 2 times, got %d", count)
+	}
+}
+
+func TestIPSetEqualClone(t *testing.T) {
+	s := ipSet("10.0.0.0/8")
+	clone := s.Clone()
+	if !clone.Equal(s) {
+		t.Errorf("Clone() is not equal to the original")
+	}
+	clone.AddPrefix(netip.MustParsePrefix("11.0.0.0/8"))
+	if clone.Equal(s) {
+		t.Errorf("Clone() shares storage with the original")
+	}
+	if !(*IPSet)(nil).Equal(NewIPSet()) {
+		t.Errorf("nil set is not equal to an empty set")
+	}
+}
+
+func TestToIPSet(t *testing.T) {
+	addrs := From(
+		netip.MustParseAddr("10.0.0.1"),
+		netip.MustParseAddr("10.0.0.2"),
+		netip.MustParseAddr("10.0.0.3"),
+		netip.MustParseAddr("::1"),
+		netip.Addr{},
+	)
+	s := ToIPSet(addrs)
+	if got, want := s.String(), "{10.0.0.1/32, 10.0.0.2/31, ::1/128}"; got != want {
+		t.Errorf("\nwant: %v\ngot : %v", want, got)
+	}
+
+	Delete(addrs, netip.Addr{})
+	if got := FromIPSet(s); !Equal(got, addrs) {
+		t.Errorf("round trip\nwant: %v\ngot : %v", addrs, got)
+	}
+	if got := FromIPSet(nil); len(got) != 0 {
+		t.Errorf("FromIPSet(nil) returned %v", got)
+	}
+}
+
+func TestIPSetAgainstSet(t *testing.T) {
+	r := rand.New(rand.NewPCG(3, 4))
+	addr := func() netip.Addr {
+		if r.IntN(2) == 0 {
+			return netip.AddrFrom4([4]byte{10, 0, 0, byte(r.IntN(64))})
+		}
+		return netip.AddrFrom16([16]byte{0x20, 0x01, 15: byte(r.IntN(64))})
+	}
+	random := func() (*IPSet, Set[netip.Addr]) {
+		s := NewIPSet()
+		for range r.IntN(6) {
+			from := addr()
+			to := from
+			for range r.IntN(10) {
+				to = to.Next()
+			}
+			if r.IntN(3) == 0 {
+				s.RemoveRange(IPRange{From: from, To: to})
+			} else {
+				s.AddRange(IPRange{From: from, To: to})
+			}
+		}
+		return s, FromIPSet(s)
+	}
+	for range 500 {
+		a, sa := random()
+		b, sb := random()
+		c, sc := random()
+
+		if got, want := FromIPSet(a.Union(b, c)), Union(sa, sb, sc); !Equal(got, want) {
+			t.Fatalf("Union(%v, %v, %v)\nwant: %v\ngot : %v", a, b, c, want, got)
+		}
+		if got, want := FromIPSet(a.Intersection(b, c)), Intersection(sa, sb, sc); !Equal(got, want) {
+			t.Fatalf("Intersection(%v, %v, %v)\nwant: %v\ngot : %v", a, b, c, want, got)
+		}
+		if got, want := FromIPSet(a.Difference(b, c)), Difference(sa, sb, sc); !Equal(got, want) {
+			t.Fatalf("Difference(%v, %v, %v)\nwant: %v\ngot : %v", a, b, c, want, got)
+		}
+		if got, want := a.Overlaps(b), Overlaps(sa, sb); got != want {
+			t.Fatalf("Overlaps(%v, %v)\nwant: %v\ngot : %v", a, b, want, got)
+		}
+		if !ToIPSet(sa).Equal(a) {
+			t.Fatalf("representation of %v is not unique", a)
+		}
+		if a.Complement().Overlaps(a) || !a.Complement().Union(a).Equal(NewIPSet().Complement()) {
+			t.Fatalf("Complement(%v) is wrong", a)
+		}
+	}
+}
@@ -0,0 +1,427 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package journal provides a durable set of strings that persists its changes to an append-only
+// log, so that long-lived processes can restore large sets quickly after a restart.
+//
+// A Set stores its state in a directory with two files: a snapshot of the elements and a log of
+// the insertions and deletions made since the snapshot. Every record carries a CRC-32C checksum.
+// Open loads the snapshot and replays the log; a truncated or corrupt record at the end of the log,
+// e.g. from a crash during a write, is discarded together with everything after it. When the log
+// grows larger than the set, it is compacted into a new snapshot, which replaces the old one
+// atomically.
+//
+// Only one Set may use a directory at a time.
+package journal
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"iter"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/kkhmel/sets"
+)
+
+// File names and headers.
+const (
+	snapshotName   = "snapshot"
+	logName        = "log"
+	snapshotHeader = "SETSNAP\x01"
+	logHeader      = "SETLOG\x00\x01"
+)
+
+// defaultCompactAfter is the default value of Options.CompactAfter.
+const defaultCompactAfter = 10000
+
+// MaxElementLen is the maximum length of an element of a Set.
+const MaxElementLen = 1 << 24
+
+var (
+	// ErrClosed is returned when modifying a closed Set.
+	ErrClosed = errors.New("journal: set is closed")
+	// ErrTooLong is returned when inserting or deleting an element longer than MaxElementLen.
+	ErrTooLong = errors.New("journal: element is too long")
+)
+
+var _ sets.ReadOnly[string] = (*Set)(nil)
+
+// Options configure a Set.
+type Options struct {
+	// CompactAfter is the minimum number of log records that triggers a compaction. The log is
+	// compacted once it holds more than CompactAfter records and more records than the set has
+	// elements. If zero, 10000 is used. If negative, the log is only compacted by Compact.
+	// If writing the snapshot fails, the change that triggered the compaction still succeeds,
+	// and the compaction is retried once the log has doubled; Compact reports the error.
+	CompactAfter int
+	// Sync makes every change durable before Insert or Delete returns by syncing the log to disk.
+	// Otherwise, changes may be lost if the operating system crashes, but not if the process does.
+	Sync bool
+}
+
+// Set is a set of strings that persists its changes to a directory.
+// Set is safe for concurrent use.
+type Set struct {
+	mu      sync.RWMutex
+	dir     string
+	opts    Options
+	elems   sets.Set[string]
+	log     logFile
+	size    int64 // size of the log up to the last complete change
+	records int   // number of records in the log
+	retryAt int   // number of records before retrying a failed automatic compaction
+	err     error // sticky error that prevents further changes
+}
+
+// logFile is the part of *os.File used for the log.
+type logFile interface {
+	io.WriteCloser
+	io.StringWriter
+	Sync() error
+	Truncate(size int64) error
+}
+
+// Open opens the set stored in dir, creating the directory if it does not exist.
+//
+// Time complexity: O(N). Space complexity: O(len(s)). N is the number of records in the snapshot and the log.
+func Open(dir string, opts Options) (*Set, error) {
+	if opts.CompactAfter == 0 {
+		opts.CompactAfter = defaultCompactAfter
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	s := &Set{dir: dir, opts: opts, elems: sets.New[string](0)}
+	if err := s.loadSnapshot(); err != nil {
+		return nil, err
+	}
+	if err := s.openLog(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Insert inserts the given elements into the set and appends them to the log.
+// Elements already present in the set are ignored. If an element is longer than MaxElementLen,
+// Insert returns ErrTooLong without changing anything. If writing the log fails, the set is not
+// modified, the records already written for the call are removed from the log as far as possible,
+// and the set rejects all further changes with the same error; reopen it to recover.
+//
+// Time complexity: O(len(v)). Space complexity: O(len(v)).
+func (s *Set) Insert(v ...string) error {
+	return s.write(opInsert, v, func(e string) bool { return !sets.Contains(s.elems, e) })
+}
+
+// Delete deletes the given elements from the set and appends the deletions to the log.
+// Elements not present in the set are ignored. Errors are handled as by Insert.
+//
+// Time complexity: O(len(v)). Space complexity: O(len(v)).
+func (s *Set) Delete(v ...string) error {
+	return s.write(opDelete, v, func(e string) bool { return sets.Contains(s.elems, e) })
+}
+
+// Contains reports whether v is present in the set.
+//
+// Time complexity: O(1). Space complexity: O(1).
+func (s *Set) Contains(v string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return sets.Contains(s.elems, v)
+}
+
+// Len returns the number of elements in the set.
+//
+// Time complexity: O(1). Space complexity: O(1).
+func (s *Set) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.elems)
+}
+
+// All returns an iterator over a snapshot of the elements of the set.
+// The iteration order is not specified. The set may be modified during iteration.
+//
+// Creation: O(1) time, O(1) space.
+// Iteration: O(len(s)) time, O(len(s)) space.
+func (s *Set) All() iter.Seq[string] {
+	return func(yield func(string) bool) {
+		s.mu.RLock()
+		elements := sets.ToSlice(s.elems)
+		s.mu.RUnlock()
+		for _, e := range elements {
+			if !yield(e) {
+				return
+			}
+		}
+	}
+}
+
+// Clone returns a snapshot of the current elements as a new Set.
+//
+// Time complexity: O(len(s)). Space complexity: O(len(s)).
+func (s *Set) Clone() sets.Set[string] {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return sets.Clone(s.elems)
+}
+
+// Compact writes the elements to a new snapshot and empties the log.
+// Errors are handled as by Insert, except that a failure to write the snapshot
+// leaves the set usable.
+//
+// Time complexity: O(len(s)). Space complexity: O(1).
+func (s *Set) Compact() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return s.err
+	}
+	return s.compact()
+}
+
+// Close closes the log. The set can still be read, but not modified.
+// Closing a closed set returns ErrClosed.
+func (s *Set) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if errors.Is(s.err, ErrClosed) {
+		return ErrClosed
+	}
+	s.err = ErrClosed
+	return s.log.Close()
+}
+
+// write appends a record of op for each element of v for which changes reports true,
+// and applies the operation to the set.
+func (s *Set) write(op byte, v []string, changes func(string) bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return s.err
+	}
+	for _, e := range v {
+		if len(e) > MaxElementLen {
+			return ErrTooLong
+		}
+	}
+	var buf []byte
+	batch := sets.New[string](len(v))
+	for _, e := range v {
+		if changes(e) && !sets.Contains(batch, e) {
+			sets.Insert(batch, e)
+			buf = appendRecord(buf, op, e)
+		}
+	}
+	if len(batch) == 0 {
+		return nil
+	}
+	_, err := s.log.Write(buf)
+	if err == nil && s.opts.Sync {
+		err = s.log.Sync()
+	}
+	if err != nil {
+		// Remove the records of the failed change, so that Open does not replay them.
+		if terr := s.log.Truncate(s.size); terr != nil {
+			err = errors.Join(err, terr)
+		}
+		s.err = err
+		return err
+	}
+	s.size += int64(len(buf))
+	if op == opInsert {
+		sets.Copy(s.elems, batch)
+	} else {
+		sets.Delete(s.elems, sets.ToSlice(batch)...)
+	}
+	s.records += len(batch)
+	if s.opts.CompactAfter > 0 && s.records > s.opts.CompactAfter && s.records > len(s.elems) && s.records >= s.retryAt {
+		// The change has been applied, so a failed compaction is not an error of the change.
+		// Failures after writing the snapshot are sticky and reported by the next change.
+		if s.compact() != nil {
+			s.retryAt = 2 * s.records
+		}
+	}
+	return nil
+}
+
+// compact writes a new snapshot and truncates the log. It must be called with s.mu held.
+func (s *Set) compact() error {
+	if err := s.writeSnapshot(); err != nil {
+		return err
+	}
+	// The snapshot holds all changes in the log, so a crash before the log has been
+	// truncated only makes the next Open replay changes that it already contains.
+	err := s.log.Truncate(0)
+	if err == nil {
+		_, err = s.log.WriteString(logHeader)
+	}
+	if err == nil {
+		err = s.log.Sync()
+	}
+	if err != nil {
+		s.err = err
+		return err
+	}
+	s.records, s.size, s.retryAt = 0, int64(len(logHeader)), 0
+	return nil
+}
+
+// writeSnapshot atomically replaces the snapshot with the current elements.
+func (s *Set) writeSnapshot() error {
+	f, err := os.CreateTemp(s.dir, snapshotName+".tmp*")
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(f)
+	_, _ = w.WriteString(snapshotHeader) // errors are reported by Flush
+	var buf []byte
+	for e := range s.elems {
+		buf = appendRecord(buf[:0], opInsert, e)
+		_, _ = w.Write(buf)
+	}
+	err = w.Flush()
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), s.path(snapshotName))
+	}
+	if err == nil {
+		err = syncDir(s.dir)
+	}
+	if err != nil {
+		_ = os.Remove(f.Name())
+	}
+	return err
+}
+
+// loadSnapshot reads the snapshot, if any, into s.elems.
+func (s *Set) loadSnapshot() error {
+	f, err := os.Open(s.path(snapshotName))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	r := bufio.NewReader(f)
+	if err := readHeader(r, snapshotHeader); err != nil {
+		return fmt.Errorf("journal: %s: %w", f.Name(), err)
+	}
+	for {
+		op, e, _, err := readRecord(r)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("journal: %s: %w", f.Name(), err)
+		}
+		apply(s.elems, op, e)
+	}
+}
+
+// openLog opens the log, replays its records and discards an invalid tail.
+func (s *Set) openLog() error {
+	f, err := os.OpenFile(s.path(logName), os.O_RDWR|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	r := bufio.NewReader(f)
+	size := int64(len(logHeader))
+	err = readHeader(r, logHeader)
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		// The log was created, but its header was not written completely.
+		size = 0
+		err = nil
+	}
+	for err == nil && size > 0 {
+		var (
+			op byte
+			e  string
+			n  int
+		)
+		op, e, n, err = readRecord(r)
+		if err == nil {
+			apply(s.elems, op, e)
+			size += int64(n)
+			s.records++
+		}
+	}
+	if errors.Is(err, io.EOF) || errors.Is(err, errInvalidRecord) {
+		err = nil
+	}
+	// Drop an invalid tail, which later records must not follow, and write a missing header.
+	if err == nil {
+		err = f.Truncate(size)
+	}
+	if err == nil && size == 0 {
+		_, err = f.WriteString(logHeader)
+		size = int64(len(logHeader))
+	}
+	if err != nil {
+		_ = f.Close()
+		return fmt.Errorf("journal: %s: %w", f.Name(), err)
+	}
+	s.log, s.size = f, size
+	return nil
+}
+
+func (s *Set) path(name string) string {
+	return filepath.Join(s.dir, name)
+}
+
+// readHeader reads a file header and checks that it is header.
+func readHeader(r io.Reader, header string) error {
+	buf := make([]byte, len(header))
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return err
+	}
+	if string(buf) != header {
+		return errors.New("invalid header")
+	}
+	return nil
+}
+
+func apply(s sets.Set[string], op byte, e string) {
+	if op == opInsert {
+		sets.Insert(s, e)
+	} else {
+		sets.Delete(s, e)
+	}
+}
+
+// syncDir makes a rename in dir durable.
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err == nil {
+		err = d.Sync()
+		_ = d.Close()
+	}
+	return err
+}
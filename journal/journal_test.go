@@ -0,0 +1,441 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package journal
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kkhmel/sets"
+)
+
+func open(t *testing.T, dir string, opts Options) *Set {
+	t.Helper()
+	s, err := Open(dir, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func assertElements(t *testing.T, s *Set, want sets.Set[string]) {
+	t.Helper()
+	if got := s.Clone(); !sets.Equal(got, want) {
+		t.Errorf("\nwant: %v\ngot : %v", want, got)
+	}
+}
+
+func TestReopen(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "set")
+	s := open(t, dir, Options{Sync: true})
+	if err := s.Insert("a", "b", "c", "a"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Delete("b", "z"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Insert("a"); err != nil {
+		t.Fatal(err)
+	}
+	if s.records != 4 {
+		t.Errorf("%d records for 4 changes", s.records)
+	}
+	if !s.Contains("a") || s.Contains("b") || s.Len() != 2 {
+		t.Errorf("Contains() = %v, %v; Len() = %v", s.Contains("a"), s.Contains("b"), s.Len())
+	}
+	if got := sets.Collect(s.All()); !sets.Equal(got, sets.From("a", "c")) {
+		t.Errorf("\nwant: %v\ngot : %v", sets.From("a", "c"), got)
+	}
+	for range s.All() {
+		break
+	}
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	s = open(t, dir, Options{})
+	defer s.Close()
+	assertElements(t, s, sets.From("a", "c"))
+	if s.records != 4 {
+		t.Errorf("%d records replayed, want 4", s.records)
+	}
+}
+
+func TestCompaction(t *testing.T) {
+	dir := t.TempDir()
+	s := open(t, dir, Options{CompactAfter: 10})
+	want := sets.New[string](0)
+	for i := range 100 {
+		e := fmt.Sprint(i % 7)
+		if i%3 == 0 {
+			_ = s.Delete(e)
+			sets.Delete(want, e)
+		} else {
+			_ = s.Insert(e)
+			sets.Insert(want, e)
+		}
+	}
+	if s.records > 10 {
+		t.Errorf("log was not compacted: %d records", s.records)
+	}
+	if _, err := os.Stat(filepath.Join(dir, snapshotName)); err != nil {
+		t.Errorf("no snapshot: %v", err)
+	}
+	s.Close()
+
+	s = open(t, dir, Options{CompactAfter: -1})
+	assertElements(t, s, want)
+	for i := range 100 {
+		_ = s.Insert(fmt.Sprint("x", i))
+	}
+	if s.records != 100 {
+		t.Errorf("log was compacted with negative CompactAfter: %d records", s.records)
+	}
+	if err := s.Compact(); err != nil {
+		t.Fatal(err)
+	}
+	if info, _ := os.Stat(filepath.Join(dir, logName)); s.records != 0 || info.Size() != int64(len(logHeader)) {
+		t.Errorf("Compact() left %d records", s.records)
+	}
+	sets.Insert(want, sets.ToSlice(s.Clone())...)
+	s.Close()
+
+	s = open(t, dir, Options{})
+	defer s.Close()
+	assertElements(t, s, want)
+}
+
+func TestTruncatedWrites(t *testing.T) {
+	// A crash can cut the log at any byte. Reopening must restore the changes of the
+	// complete records and accept new changes after them.
+	dir := t.TempDir()
+	s := open(t, dir, Options{})
+	var states []sets.Set[string]
+	var sizes []int64
+	for i := range 5 {
+		_ = s.Insert(fmt.Sprint("e", i), fmt.Sprint("f", i))
+		if i%2 == 1 {
+			_ = s.Delete(fmt.Sprint("e", i-1))
+		}
+		states = append(states, s.Clone())
+		if info, _ := os.Stat(filepath.Join(dir, logName)); info.Size() != s.size {
+			t.Fatalf("log size is %d, recorded %d", info.Size(), s.size)
+		}
+		sizes = append(sizes, s.size)
+	}
+	s.Close()
+	full, err := os.ReadFile(filepath.Join(dir, logName))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for n := range len(full) + 1 {
+		crashed := t.TempDir()
+		if err := os.WriteFile(filepath.Join(crashed, logName), full[:n], 0o644); err != nil {
+			t.Fatal(err)
+		}
+		s := open(t, crashed, Options{})
+
+		// The restored state is the state after the last batch whose records are all complete,
+		// or a state in the middle of a batch.
+		want := sets.New[string](0)
+		for i, size := range sizes {
+			if int64(n) >= size {
+				want = states[i]
+			}
+		}
+		if got := s.Clone(); !sets.Subset(want, got) && !sets.Subset(got, want) {
+			t.Errorf("log cut at %d:\nwant: %v\ngot : %v", n, want, got)
+		}
+
+		_ = s.Insert("new")
+		s.Close()
+		s = open(t, crashed, Options{})
+		if !s.Contains("new") {
+			t.Errorf("log cut at %d: change after recovery was lost", n)
+		}
+		s.Close()
+	}
+}
+
+func TestCorruptTail(t *testing.T) {
+	dir := t.TempDir()
+	s := open(t, dir, Options{})
+	_ = s.Insert("a")
+	_ = s.Insert("b")
+	s.Close()
+
+	path := filepath.Join(dir, logName)
+	data, _ := os.ReadFile(path)
+	data[len(data)-1] ^= 0xff
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	s = open(t, dir, Options{})
+	defer s.Close()
+	assertElements(t, s, sets.From("a"))
+}
+
+func TestCrashDuringCompaction(t *testing.T) {
+	// A crash after writing the snapshot but before truncating the log replays the old log
+	// on top of the snapshot.
+	dir := t.TempDir()
+	s := open(t, dir, Options{CompactAfter: -1})
+	_ = s.Insert("a", "b", "c")
+	_ = s.Delete("a")
+	_ = s.Insert("a")
+	_ = s.Delete("b")
+	log, _ := os.ReadFile(filepath.Join(dir, logName))
+	if err := s.Compact(); err != nil {
+		t.Fatal(err)
+	}
+	s.Close()
+	if err := os.WriteFile(filepath.Join(dir, logName), log, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	s = open(t, dir, Options{})
+	defer s.Close()
+	assertElements(t, s, sets.From("a", "c"))
+}
+
+func TestOpenErrors(t *testing.T) {
+	setup := func(files map[string]string) string {
+		dir := t.TempDir()
+		for name, content := range files {
+			if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return dir
+	}
+	loop := func(name string) string {
+		dir := t.TempDir()
+		if err := os.Symlink(name, filepath.Join(dir, name)); err != nil {
+			t.Fatal(err)
+		}
+		return dir
+	}
+	file := filepath.Join(t.TempDir(), "file")
+	_ = os.WriteFile(file, nil, 0o644)
+
+	tests := map[string]string{
+		"directory is a file": file,
+		"snapshot header":     setup(map[string]string{snapshotName: "SETSNAP\x02"}),
+		"empty snapshot":      setup(map[string]string{snapshotName: ""}),
+		"corrupt snapshot":    setup(map[string]string{snapshotName: snapshotHeader + "\x04\x00\x00\x00abcdefgh"}),
+		"log header":          setup(map[string]string{logName: "SETLOG\x00\x02"}),
+		"unreadable snapshot": loop(snapshotName),
+		"unopenable log":      loop(logName),
+	}
+	for name, dir := range tests {
+		if s, err := Open(dir, Options{}); err == nil {
+			t.Errorf("%s: Open() succeeded", name)
+			s.Close()
+		}
+	}
+
+	// A log whose header was not written completely is empty.
+	s := open(t, setup(map[string]string{logName: "SET"}), Options{})
+	defer s.Close()
+	if s.Len() != 0 {
+		t.Errorf("\nwant: %v\ngot : %v", 0, s.Len())
+	}
+	_ = s.Insert("a")
+	if data, _ := os.ReadFile(filepath.Join(s.dir, logName)); string(data[:len(logHeader)]) != logHeader {
+		t.Errorf("log header was not rewritten: %q", data)
+	}
+}
+
+func TestWriteErrors(t *testing.T) {
+	s := open(t, t.TempDir(), Options{})
+	_ = s.Insert("a")
+	s.log.Close() // makes all writes fail
+
+	err := s.Insert("b")
+	if err == nil {
+		t.Fatalf("Insert() succeeded")
+	}
+	if s.Contains("b") {
+		t.Errorf("a failed Insert() modified the set")
+	}
+	if err2 := s.Delete("a"); !errors.Is(err2, err) {
+		t.Errorf("error is not sticky\nwant: %v\ngot : %v", err, err2)
+	}
+	if err2 := s.Compact(); !errors.Is(err2, err) {
+		t.Errorf("error is not sticky\nwant: %v\ngot : %v", err, err2)
+	}
+
+	// Truncating the log fails after the snapshot has been written.
+	s = open(t, t.TempDir(), Options{})
+	_ = s.Insert("a")
+	s.log.Close()
+	if err := s.Compact(); err == nil || !errors.Is(s.err, err) {
+		t.Errorf("Compact() = %v, sticky error %v", err, s.err)
+	}
+}
+
+// shortWriteLog writes only the first n bytes of a write to the log and then fails.
+type shortWriteLog struct {
+	*os.File
+	n int
+}
+
+func (l shortWriteLog) Write(p []byte) (int, error) {
+	n, _ := l.File.Write(p[:min(l.n, len(p))])
+	return n, errors.New("disk full")
+}
+
+func TestPartialWrite(t *testing.T) {
+	// Complete records of a failed change must not be replayed by Open.
+	dir := t.TempDir()
+	s := open(t, dir, Options{})
+	_ = s.Insert("a")
+	s.log = shortWriteLog{File: s.log.(*os.File), n: 20}
+	if err := s.Insert("bb", "cc", "dd"); err == nil {
+		t.Fatalf("Insert() succeeded")
+	}
+	s.Close()
+
+	s = open(t, dir, Options{})
+	defer s.Close()
+	if got, want := s.Clone(), sets.From("a"); !sets.Equal(got, want) {
+		t.Errorf("\nwant: %v\ngot : %v", want, got)
+	}
+}
+
+func TestTooLong(t *testing.T) {
+	dir := t.TempDir()
+	s := open(t, dir, Options{})
+	long := strings.Repeat("x", MaxElementLen+1)
+	if err := s.Insert("a", long); !errors.Is(err, ErrTooLong) {
+		t.Errorf("\nwant: %v\ngot : %v", ErrTooLong, err)
+	}
+	if err := s.Delete(long); !errors.Is(err, ErrTooLong) {
+		t.Errorf("\nwant: %v\ngot : %v", ErrTooLong, err)
+	}
+	// The set is unchanged and still usable, and elements of the maximum length survive reopening.
+	maxLen := long[:MaxElementLen]
+	if err := s.Insert(maxLen); err != nil || s.Contains("a") {
+		t.Errorf("Insert() = %v; Contains(\"a\") = %v", err, s.Contains("a"))
+	}
+	if err := s.Compact(); err != nil {
+		t.Fatal(err)
+	}
+	s.Close()
+	s = open(t, dir, Options{})
+	defer s.Close()
+	if !s.Contains(maxLen) {
+		t.Errorf("element of length MaxElementLen was lost")
+	}
+}
+
+func TestSnapshotErrors(t *testing.T) {
+	// A failure to write the snapshot leaves the set usable.
+	dir := t.TempDir()
+	s := open(t, dir, Options{})
+	defer s.Close()
+	_ = s.Insert("a")
+
+	if err := os.Mkdir(filepath.Join(dir, snapshotName), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Compact(); err == nil {
+		t.Errorf("Compact() replaced a directory")
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 2 {
+		t.Errorf("temporary snapshot was not removed: %v", entries)
+	}
+	if err := os.Remove(filepath.Join(dir, snapshotName)); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.Rename(dir, dir+".moved"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Compact(); err == nil {
+		t.Errorf("Compact() succeeded without a directory")
+	}
+	if err := os.Rename(dir+".moved", dir); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Insert("b"); err != nil {
+		t.Errorf("set is not usable after a failed compaction: %v", err)
+	}
+}
+
+func TestAutomaticCompactionErrors(t *testing.T) {
+	// A failed automatic compaction does not fail the change that triggered it.
+	dir := t.TempDir()
+	s := open(t, dir, Options{CompactAfter: 10})
+	if err := os.Mkdir(filepath.Join(dir, snapshotName), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	toggle := func(n int) {
+		t.Helper()
+		for range n {
+			op := s.Insert
+			if s.Contains("a") {
+				op = s.Delete
+			}
+			if err := op("a"); err != nil {
+				t.Fatalf("change failed: %v", err)
+			}
+		}
+	}
+	toggle(11)
+	if s.records != 11 || s.retryAt != 22 || !s.Contains("a") {
+		t.Errorf("records = %d, retryAt = %d", s.records, s.retryAt)
+	}
+
+	// Compaction is only retried once the log has doubled.
+	_ = os.Remove(filepath.Join(dir, snapshotName))
+	toggle(10)
+	if s.records != 21 {
+		t.Errorf("compaction was retried after %d records", s.records)
+	}
+	toggle(1)
+	if s.records != 0 || s.retryAt != 0 {
+		t.Errorf("records = %d, retryAt = %d", s.records, s.retryAt)
+	}
+	s.Close()
+
+	s = open(t, dir, Options{})
+	defer s.Close()
+	assertElements(t, s, sets.New[string](0))
+}
+
+func TestClose(t *testing.T) {
+	s := open(t, t.TempDir(), Options{})
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Close(); !errors.Is(err, ErrClosed) {
+		t.Errorf("\nwant: %v\ngot : %v", ErrClosed, err)
+	}
+	if err := s.Insert("a"); !errors.Is(err, ErrClosed) {
+		t.Errorf("\nwant: %v\ngot : %v", ErrClosed, err)
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package journal
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"io"
+)
+
+// Operations of records.
+const (
+	opInsert byte = 1
+	opDelete byte = 2
+)
+
+// recordHeaderSize is the size of the length and checksum preceding the body of a record.
+const recordHeaderSize = 8
+
+// maxRecordSize limits the body size of records, so that a corrupt length
+// does not cause a huge allocation.
+const maxRecordSize = 1 << 28
+
+// errInvalidRecord reports a truncated or corrupt record.
+var errInvalidRecord = errors.New("journal: invalid record")
+
+var crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+// appendRecord appends the record of op on elem to buf. A record is the length of its body
+// as a little-endian uint32, the CRC-32C of the body as a little-endian uint32, and the body,
+// which is the operation byte followed by the element.
+func appendRecord(buf []byte, op byte, elem string) []byte {
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(1+len(elem)))
+	start := len(buf) + 4
+	buf = append(buf, 0, 0, 0, 0, op)
+	buf = append(buf, elem...)
+	binary.LittleEndian.PutUint32(buf[start-4:], crc32.Checksum(buf[start:], crcTable))
+	return buf
+}
+
+// readRecord reads a record from r and returns its operation, element and encoded size.
+// It returns io.EOF at the end of r and errInvalidRecord for a truncated or corrupt record.
+func readRecord(r *bufio.Reader) (op byte, elem string, size int, err error) {
+	var header [recordHeaderSize]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			err = errInvalidRecord
+		}
+		return 0, "", 0, err
+	}
+	n := binary.LittleEndian.Uint32(header[:4])
+	if n == 0 || n > maxRecordSize {
+		return 0, "", 0, errInvalidRecord
+	}
+	body := make([]byte, n)
+	if _, err := io.ReadFull(r, body); err != nil {
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			err = errInvalidRecord
+		}
+		return 0, "", 0, err
+	}
+	if crc32.Checksum(body, crcTable) != binary.LittleEndian.Uint32(header[4:]) ||
+		(body[0] != opInsert && body[0] != opDelete) {
+		return 0, "", 0, errInvalidRecord
+	}
+	return body[0], string(body[1:]), recordHeaderSize + int(n), nil
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package journal
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestRecordRoundTrip(t *testing.T) {
+	var buf []byte
+	buf = appendRecord(buf, opInsert, "alpha")
+	buf = appendRecord(buf, opDelete, "")
+	buf = appendRecord(buf, opInsert, strings.Repeat("x", 1000))
+
+	r := bufio.NewReader(bytes.NewReader(buf))
+	want := []struct {
+		op   byte
+		elem string
+	}{{opInsert, "alpha"}, {opDelete, ""}, {opInsert, strings.Repeat("x", 1000)}}
+	total := 0
+	for _, w := range want {
+		op, elem, n, err := readRecord(r)
+		if err != nil || op != w.op || elem != w.elem {
+			t.Errorf("readRecord() = %v, %.10q, %v; want %v, %.10q", op, elem, err, w.op, w.elem)
+		}
+		total += n
+	}
+	if total != len(buf) {
+		t.Errorf("\nwant: %v\ngot : %v", len(buf), total)
+	}
+	if _, _, _, err := readRecord(r); !errors.Is(err, io.EOF) {
+		t.Errorf("\nwant: %v\ngot : %v", io.EOF, err)
+	}
+}
+
+func TestReadRecordInvalid(t *testing.T) {
+	valid := appendRecord(nil, opInsert, "abc")
+	corrupt := func(i int, b byte) []byte {
+		r := bytes.Clone(valid)
+		r[i] = b
+		return r
+	}
+	tests := map[string][]byte{
+		"truncated header": valid[:5],
+		"truncated body":   valid[:len(valid)-1],
+		"missing body":     valid[:recordHeaderSize],
+		"zero length":      corrupt(0, 0),
+		"huge length":      {0xff, 0xff, 0xff, 0xff, 0, 0, 0, 0},
+		"checksum":         corrupt(len(valid)-1, 'x'),
+		"operation":        appendRecord(nil, 7, "abc"),
+	}
+	for name, data := range tests {
+		if _, _, _, err := readRecord(bufio.NewReader(bytes.NewReader(data))); !errors.Is(err, errInvalidRecord) {
+			t.Errorf("%s:\nwant: %v\ngot : %v", name, errInvalidRecord, err)
+		}
+	}
+
+	readErr := errors.New("read failed")
+	for name, r := range map[string]io.Reader{
+		"header": iotest.ErrReader(readErr),
+		"body":   io.MultiReader(bytes.NewReader(valid[:recordHeaderSize]), iotest.ErrReader(readErr)),
+	} {
+		if _, _, _, err := readRecord(bufio.NewReader(r)); !errors.Is(err, readErr) {
+			t.Errorf("%s:\nwant: %v\ngot : %v", name, readErr, err)
+		}
+	}
+}
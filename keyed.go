@@ -0,0 +1,205 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sets
+
+import (
+	"iter"
+	"maps"
+)
+
+// Keyed is a set of elements of any type, including types that are not comparable
+// such as structs holding slices or maps. Elements are identified by a comparable key
+// computed by a key function, and the set stores one representative element per key.
+//
+// Operations combining several sets match elements by key,
+// so all sets involved are expected to use equivalent key functions.
+//
+// Use NewKeyed to create a Keyed set; the zero value is not ready to use.
+type Keyed[E any, K comparable] struct {
+	key   func(E) K
+	items map[K]E
+}
+
+// NewKeyed creates a new Keyed set containing vals, which identifies elements by key.
+//
+// Time complexity: O(len(vals)). Space complexity: O(len(vals)).
+func NewKeyed[E any, K comparable](key func(E) K, vals ...E) *Keyed[E, K] {
+	s := &Keyed[E, K]{key: key, items: make(map[K]E, len(vals))}
+	s.Insert(vals...)
+	return s
+}
+
+// Insert inserts the given elements into the set.
+// Elements whose key is already present are ignored, so the first inserted representative is kept.
+// Use Delete followed by Insert to change the representative of a key.
+//
+// Time complexity: O(len(v)). Space complexity: O(len(v)).
+func (s *Keyed[E, K]) Insert(v ...E) {
+	for _, e := range v {
+		k := s.key(e)
+		if _, ok := s.items[k]; !ok {
+			s.items[k] = e
+		}
+	}
+}
+
+// Delete deletes the elements with the same keys as the specified elements from the set.
+//
+// Time complexity: O(len(v)). Space complexity: O(1).
+func (s *Keyed[E, K]) Delete(v ...E) {
+	for _, e := range v {
+		delete(s.items, s.key(e))
+	}
+}
+
+// DeleteKey deletes the elements with the specified keys from the set.
+//
+// Time complexity: O(len(k)). Space complexity: O(1).
+func (s *Keyed[E, K]) DeleteKey(k ...K) {
+	for _, key := range k {
+		delete(s.items, key)
+	}
+}
+
+// Contains reports whether an element with the same key as v is present in the set.
+//
+// Time complexity: O(1). Space complexity: O(1).
+func (s *Keyed[E, K]) Contains(v E) bool {
+	return s.ContainsKey(s.key(v))
+}
+
+// ContainsKey reports whether an element with key k is present in the set.
+//
+// Time complexity: O(1). Space complexity: O(1).
+func (s *Keyed[E, K]) ContainsKey(k K) bool {
+	_, ok := s.items[k]
+	return ok
+}
+
+// Get returns the element stored under key k.
+// The boolean is false if there is no such element.
+//
+// Time complexity: O(1). Space complexity: O(1).
+func (s *Keyed[E, K]) Get(k K) (E, bool) {
+	e, ok := s.items[k]
+	return e, ok
+}
+
+// Len returns the number of elements in the set.
+//
+// Time complexity: O(1). Space complexity: O(1).
+func (s *Keyed[E, K]) Len() int {
+	return len(s.items)
+}
+
+// All returns an iterator over the elements of the set.
+// The iteration order is not specified.
+//
+// Creation: O(1) time, O(1) space.
+// Iteration: O(len(s)) time, O(1) space.
+func (s *Keyed[E, K]) All() iter.Seq[E] {
+	return maps.Values(s.items)
+}
+
+// Entries returns an iterator over the key-element pairs of the set.
+// The iteration order is not specified.
+//
+// Creation: O(1) time, O(1) space.
+// Iteration: O(len(s)) time, O(1) space.
+func (s *Keyed[E, K]) Entries() iter.Seq2[K, E] {
+	return maps.All(s.items)
+}
+
+// Keys returns a new Set containing the keys of the elements of s.
+//
+// Time complexity: O(len(s)). Space complexity: O(len(s)).
+func (s *Keyed[E, K]) Keys() Set[K] {
+	r := New[K](len(s.items))
+	for k := range s.items {
+		r[k] = struct{}{}
+	}
+	return r
+}
+
+// Union returns a new set containing all elements from s and all of the others.
+// For keys present in several sets, the representative of the first such set is kept.
+//
+// Time complexity: O(N). Space complexity: O(N). N is the sum of all set sizes.
+func (s *Keyed[E, K]) Union(others ...*Keyed[E, K]) *Keyed[E, K] {
+	r := s.Clone()
+	for _, o := range others {
+		for k, e := range o.items {
+			if _, ok := r.items[k]; !ok {
+				r.items[k] = e
+			}
+		}
+	}
+	return r
+}
+
+// Intersection returns a new set containing the elements of s whose keys are present in all of the others.
+//
+// Time complexity: O(len(s) * len(others)). Space complexity: O(len(s)).
+func (s *Keyed[E, K]) Intersection(others ...*Keyed[E, K]) *Keyed[E, K] {
+	r := &Keyed[E, K]{key: s.key, items: make(map[K]E)}
+elementsLoop:
+	for k, e := range s.items {
+		for _, o := range others {
+			if _, ok := o.items[k]; !ok {
+				continue elementsLoop
+			}
+		}
+		r.items[k] = e
+	}
+	return r
+}
+
+// Difference returns a new set containing the elements of s whose keys are not present in any of the others.
+//
+// Time complexity: O(len(s) * len(others)). Space complexity: O(len(s)).
+func (s *Keyed[E, K]) Difference(others ...*Keyed[E, K]) *Keyed[E, K] {
+	r := &Keyed[E, K]{key: s.key, items: make(map[K]E)}
+elementsLoop:
+	for k, e := range s.items {
+		for _, o := range others {
+			if _, ok := o.items[k]; ok {
+				continue elementsLoop
+			}
+		}
+		r.items[k] = e
+	}
+	return r
+}
+
+// Clone returns a shallow copy of s that shares its key function.
+//
+// Time complexity: O(len(s)). Space complexity: O(len(s)).
+func (s *Keyed[E, K]) Clone() *Keyed[E, K] {
+	return &Keyed[E, K]{key: s.key, items: maps.Clone(s.items)}
+}
+
+// String returns a string representation of the set in the format "{key1, key2, ...}".
+// Keys are sorted by their string representation for consistent output.
+//
+// Time complexity: O(len(s)). Space complexity: O(len(s)).
+func (s *Keyed[E, K]) String() string {
+	return s.Keys().String()
+}
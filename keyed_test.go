@@ -0,0 +1,176 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sets
+
+import (
+	"maps"
+	"slices"
+	"testing"
+)
+
+// record is not comparable because of its slice field.
+type record struct {
+	id   string
+	tags []string
+}
+
+func recordID(r record) string { return r.id }
+
+func newRecords(ids ...string) *Keyed[record, string] {
+	s := NewKeyed(recordID)
+	for _, id := range ids {
+		s.Insert(record{id: id, tags: []string{id}})
+	}
+	return s
+}
+
+func TestKeyedInsert(t *testing.T) {
+	s := NewKeyed(recordID,
+		record{id: "a", tags: []string{"first"}},
+		record{id: "b"},
+		record{id: "a", tags: []string{"second"}},
+	)
+	if got := s.Len(); got != 2 {
+		t.Errorf("\nwant: %v\ngot : %v", 2, got)
+	}
+	got, ok := s.Get("a")
+	if !ok || !slices.Equal(got.tags, []string{"first"}) {
+		t.Errorf("Insert() did not keep the first representative: %v", got)
+	}
+	if _, ok := s.Get("c"); ok {
+		t.Errorf("Get() reported an absent key")
+	}
+
+	s.Delete(record{id: "a"})
+	s.Insert(record{id: "a", tags: []string{"third"}})
+	if got, _ := s.Get("a"); !slices.Equal(got.tags, []string{"third"}) {
+		t.Errorf("representative was not replaced after Delete(): %v", got)
+	}
+}
+
+func TestKeyedLookups(t *testing.T) {
+	s := newRecords("a", "b", "c")
+	tests := []struct {
+		name string
+		got  bool
+		want bool
+	}{
+		{name: "contains present", got: s.Contains(record{id: "a", tags: []string{"other"}}), want: true},
+		{name: "contains absent", got: s.Contains(record{id: "z"}), want: false},
+		{name: "contains key present", got: s.ContainsKey("b"), want: true},
+		{name: "contains key absent", got: s.ContainsKey("z"), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("\nwant: %v\ngot : %v", tt.want, tt.got)
+			}
+		})
+	}
+}
+
+func TestKeyedDelete(t *testing.T) {
+	s := newRecords("a", "b", "c", "d")
+	s.Delete(record{id: "a"}, record{id: "z"})
+	s.DeleteKey("b", "y")
+	if got, want := s.Keys(), From("c", "d"); !Equal(got, want) {
+		t.Errorf("\nwant: %v\ngot : %v", want, got)
+	}
+}
+
+func TestKeyedIteration(t *testing.T) {
+	s := newRecords("a", "b", "c")
+	var ids []string
+	for r := range s.All() {
+		ids = append(ids, r.id)
+	}
+	if want := []string{"a", "b", "c"}; !slices.Equal(slices.Sorted(slices.Values(ids)), want) {
+		t.Errorf("\nwant: %v\ngot : %v", want, ids)
+	}
+	for k, r := range s.Entries() {
+		if k != r.id || !slices.Equal(r.tags, []string{k}) {
+			t.Errorf("Entries() yielded mismatched pair %v: %v", k, r)
+		}
+	}
+	if got := slices.Sorted(maps.Keys(maps.Collect(s.Entries()))); !slices.Equal(got, []string{"a", "b", "c"}) {
+		t.Errorf("Entries() yielded wrong keys: %v", got)
+	}
+
+	count := 0
+	for range s.All() {
+		count++
+		break
+	}
+	if count != 1 {
+		t.Errorf("expected to iterate 1 time, got %d", count)
+	}
+}
+
+func TestKeyedOperations(t *testing.T) {
+	a := newRecords("a", "b", "c")
+	b := NewKeyed(recordID, record{id: "b", tags: []string{"from b"}}, record{id: "d"})
+	c := newRecords("b", "e")
+	tests := []struct {
+		name string
+		got  *Keyed[record, string]
+		want string
+	}{
+		{name: "union", got: a.Union(b, c), want: "{a, b, c, d, e}"},
+		{name: "union of none", got: a.Union(), want: "{a, b, c}"},
+		{name: "intersection", got: a.Intersection(b), want: "{b}"},
+		{name: "intersection of three", got: a.Intersection(b, c), want: "{b}"},
+		{name: "intersection with disjoint", got: a.Intersection(newRecords("z")), want: "{}"},
+		{name: "difference", got: a.Difference(b), want: "{a, c}"},
+		{name: "difference of two", got: b.Difference(a, c), want: "{d}"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.got.String(); got != tt.want {
+				t.Errorf("\nwant: %v\ngot : %v", tt.want, got)
+			}
+		})
+	}
+
+	if got, _ := a.Union(b).Get("b"); !slices.Equal(got.tags, []string{"b"}) {
+		t.Errorf("Union() did not keep the representative of the first set: %v", got)
+	}
+	if got, _ := b.Intersection(a).Get("b"); !slices.Equal(got.tags, []string{"from b"}) {
+		t.Errorf("Intersection() did not keep the representative of the receiver: %v", got)
+	}
+	u := a.Union(b)
+	u.Insert(record{id: "x"})
+	if !u.ContainsKey("x") || a.ContainsKey("x") {
+		t.Errorf("result does not use the key function or shares storage with the receiver")
+	}
+}
+
+func TestKeyedClone(t *testing.T) {
+	s := newRecords("a", "b")
+	clone := s.Clone()
+	clone.Insert(record{id: "c"})
+	clone.DeleteKey("a")
+	if got, want := s.String(), "{a, b}"; got != want {
+		t.Errorf("Clone() shares storage with the original\nwant: %v\ngot : %v", want, got)
+	}
+	if got, want := clone.String(), "{b, c}"; got != want {
+		t.Errorf("\nwant: %v\ngot : %v", want, got)
+	}
+}
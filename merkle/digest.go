@@ -0,0 +1,185 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package merkle
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// ErrMismatch is returned when comparing digests of trees with different depths.
+var ErrMismatch = errors.New("merkle: digests have different depths")
+
+// Encoding header: magic, version and depth.
+const (
+	magic   = "MKD"
+	version = 1
+)
+
+// Digest holds the digests of all nodes of a tree, level by level from the root to the leaves.
+// A node whose subtree holds no elements has the digest 0.
+type Digest struct {
+	levels [][]uint64
+}
+
+func newDigest(depth int) Digest {
+	levels := make([][]uint64, depth+1)
+	for l := range levels {
+		levels[l] = make([]uint64, 1<<(fanoutBits*l))
+	}
+	return Digest{levels: levels}
+}
+
+// Depth returns the number of levels below the root.
+//
+// Time complexity: O(1). Space complexity: O(1).
+func (d *Digest) Depth() int {
+	return len(d.levels) - 1
+}
+
+// Level returns a copy of the digests of the nodes at the given level, where level 0 holds
+// the root and level Depth() the leaf buckets. The children of node i at level l are the nodes
+// i*Fanout to (i+1)*Fanout-1 at level l+1. If level is out of range, Level panics.
+//
+// Time complexity: O(Fanout^level). Space complexity: O(Fanout^level).
+func (d *Digest) Level(level int) []uint64 {
+	return slices.Clone(d.levels[level])
+}
+
+// Clone returns a copy of d.
+//
+// Time complexity: O(Fanout^depth). Space complexity: O(Fanout^depth).
+func (d *Digest) Clone() *Digest {
+	levels := make([][]uint64, len(d.levels))
+	for l, nodes := range d.levels {
+		levels[l] = slices.Clone(nodes)
+	}
+	return &Digest{levels: levels}
+}
+
+// Diff returns the indexes of the leaf buckets whose digests differ between d and other
+// in ascending order, descending only into differing nodes.
+// If the digests have different depths, the error is ErrMismatch.
+//
+// Time complexity: O(N * Fanout * depth). Space complexity: O(N). N is the number of differing buckets.
+func (d *Digest) Diff(other *Digest) ([]int, error) {
+	if d.Depth() != other.Depth() {
+		return nil, ErrMismatch
+	}
+	var r []int
+	d.diff(other, 0, 0, &r)
+	return r, nil
+}
+
+func (d *Digest) diff(other *Digest, level, i int, r *[]int) {
+	if d.levels[level][i] == other.levels[level][i] {
+		return
+	}
+	if level == d.Depth() {
+		*r = append(*r, i)
+		return
+	}
+	for c := i * Fanout; c < (i+1)*Fanout; c++ {
+		d.diff(other, level+1, c, r)
+	}
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler. The encoding is the magic "MKD",
+// a version byte, a depth byte and the digests of all levels from the root to the leaves
+// as big-endian uint64 values, so it does not depend on the process that produced it.
+func (d *Digest) MarshalBinary() ([]byte, error) {
+	n := 0
+	for _, nodes := range d.levels {
+		n += len(nodes)
+	}
+	data := make([]byte, 0, len(magic)+2+n*8)
+	data = append(data, magic...)
+	data = append(data, version, byte(d.Depth()))
+	for _, nodes := range d.levels {
+		for _, v := range nodes {
+			data = binary.BigEndian.AppendUint64(data, v)
+		}
+	}
+	return data, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. It replaces the content of d.
+// It returns an error if data is not a valid encoding or if the digests of the nodes
+// are inconsistent with the digests of their children.
+func (d *Digest) UnmarshalBinary(data []byte) error {
+	header := len(magic) + 2
+	if len(data) < header || string(data[:len(magic)]) != magic {
+		return errors.New("merkle: invalid digest header")
+	}
+	if v := data[len(magic)]; v != version {
+		return fmt.Errorf("merkle: unsupported digest version %d", v)
+	}
+	depth := int(data[len(magic)+1])
+	if depth < 1 || depth > MaxDepth {
+		return fmt.Errorf("merkle: invalid digest depth %d", depth)
+	}
+	r := newDigest(depth)
+	data = data[header:]
+	for _, nodes := range r.levels {
+		for i := range nodes {
+			if len(data) < 8 {
+				return errors.New("merkle: truncated digest")
+			}
+			nodes[i], data = binary.BigEndian.Uint64(data), data[8:]
+		}
+	}
+	if len(data) > 0 {
+		return errors.New("merkle: trailing data after digest")
+	}
+	for l := range depth {
+		for i, v := range r.levels[l] {
+			if v != nodeDigest(r.levels[l+1][i*Fanout:(i+1)*Fanout]) {
+				return fmt.Errorf("merkle: inconsistent digest of node %d at level %d", i, l)
+			}
+		}
+	}
+	*d = r
+	return nil
+}
+
+// set sets the digest of the leaf i and recomputes the digests of its ancestors.
+func (d *Digest) set(i int, v uint64) {
+	d.levels[d.Depth()][i] = v
+	for l := d.Depth() - 1; l >= 0; l-- {
+		i /= Fanout
+		d.levels[l][i] = nodeDigest(d.levels[l+1][i*Fanout : (i+1)*Fanout])
+	}
+}
+
+// nodeDigest returns the digest of a node with the given children digests,
+// which is 0 if all children are empty.
+func nodeDigest(children []uint64) uint64 {
+	if !slices.ContainsFunc(children, func(c uint64) bool { return c != 0 }) {
+		return 0
+	}
+	h := uint64(0xcbf29ce484222325)
+	for _, c := range children {
+		h = mix(h ^ c)
+	}
+	return h
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package merkle
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestDigestLevels(t *testing.T) {
+	tr := NewTree(2, HashString, "a", "b", "c")
+	d := tr.Digest()
+	if got := d.Depth(); got != 2 {
+		t.Errorf("\nwant: %v\ngot : %v", 2, got)
+	}
+	if got := d.Level(0); !slices.Equal(got, []uint64{tr.Root()}) {
+		t.Errorf("\nwant: %v\ngot : %v", []uint64{tr.Root()}, got)
+	}
+	leaves := d.Level(2)
+	if len(leaves) != Fanout*Fanout {
+		t.Errorf("\nwant: %v\ngot : %v", Fanout*Fanout, len(leaves))
+	}
+	nonEmpty := 0
+	for _, v := range leaves {
+		if v != 0 {
+			nonEmpty++
+		}
+	}
+	if nonEmpty == 0 || nonEmpty > 3 {
+		t.Errorf("%d non-empty leaves for 3 elements", nonEmpty)
+	}
+
+	// Digests are snapshots.
+	leaves[0] = 1
+	tr.Insert("d")
+	if d.Level(0)[0] != d.Clone().Level(0)[0] || d.Level(0)[0] == tr.Root() || d.Level(2)[0] == 1 {
+		t.Errorf("Digest() or Level() shares state")
+	}
+}
+
+func TestDigestEncoding(t *testing.T) {
+	tr := NewTree(2, HashInteger[int], 1, 2, 3, 4, 5)
+	data, err := tr.Digest().MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) != 5+(1+16+256)*8 || !bytes.HasPrefix(data, []byte("MKD\x01\x02")) {
+		t.Errorf("unexpected encoding of %d bytes: % x", len(data), data[:5])
+	}
+	again, _ := NewTree(2, HashInteger[int], 5, 4, 3, 2, 1).Digest().MarshalBinary()
+	if !bytes.Equal(data, again) {
+		t.Errorf("encodings of equal sets differ")
+	}
+
+	var d Digest
+	if err := d.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if diff, err := tr.Diff(&d); err != nil || len(diff) != 0 {
+		t.Errorf("Diff() = %v, %v", diff, err)
+	}
+
+	corrupt := slices.Clone(data)
+	binary.BigEndian.PutUint64(corrupt[len(corrupt)-8:], 1)
+	invalid := map[string][]byte{
+		"empty":         nil,
+		"magic":         []byte("XYZ\x01\x01"),
+		"version":       []byte("MKD\x02\x01"),
+		"zero depth":    []byte("MKD\x01\x00"),
+		"large depth":   []byte("MKD\x01\x06"),
+		"truncated":     data[:len(data)-1],
+		"trailing data": append(slices.Clone(data), 0),
+		"inconsistent":  corrupt,
+	}
+	for name, data := range invalid {
+		if err := d.UnmarshalBinary(data); err == nil {
+			t.Errorf("%s: UnmarshalBinary() accepted invalid input", name)
+		}
+	}
+	if diff, err := tr.Diff(&d); err != nil || len(diff) != 0 {
+		t.Errorf("a failed UnmarshalBinary() modified the digest: %v, %v", diff, err)
+	}
+	if _, err := d.Diff(NewTree(1, HashString).Digest()); !errors.Is(err, ErrMismatch) {
+		t.Errorf("\nwant: %v\ngot : %v", ErrMismatch, err)
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package merkle_test
+
+import (
+	"fmt"
+
+	"github.com/kkhmel/sets"
+	"github.com/kkhmel/sets/merkle"
+)
+
+func ExampleTree_Diff() {
+	local := merkle.NewTree(2, merkle.HashString, "alpha", "beta", "gamma")
+	remote := merkle.NewTree(2, merkle.HashString, "alpha", "beta", "delta")
+
+	// The remote replica sends its digest; only the differing buckets are exchanged.
+	buckets, err := local.Diff(remote.Digest())
+	if err != nil {
+		panic(err)
+	}
+	local.Insert(sets.ToSlice(remote.Extract(buckets...))...)
+	remote.Insert(sets.ToSlice(local.Extract(buckets...))...)
+
+	fmt.Println(len(buckets), local.Root() == remote.Root(), local.Len())
+
+	// Output:
+	// 2 true 4
+}
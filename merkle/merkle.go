@@ -0,0 +1,253 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package merkle provides Merkle-tree digests of sets for anti-entropy between replicas.
+//
+// A Tree partitions the elements of a set into leaf buckets by the prefix of their hashes and
+// keeps a digest of every bucket and of every group of 16 sibling nodes above them, up to a single
+// root. Replicas compare their roots and descend only into the nodes that differ, which finds
+// the buckets holding the differences without comparing the sets element by element:
+//
+//	buckets, err := local.Diff(remoteDigest)
+//	// send local.Extract(buckets...) and merge the remote elements of the same buckets
+//
+// The digests only depend on the elements and the hash function, so trees with the same depth
+// built by different processes agree as long as the hash function is stable across processes,
+// like HashString and HashInteger but unlike hash/maphash.
+package merkle
+
+import (
+	"hash/fnv"
+	"iter"
+
+	"github.com/kkhmel/sets"
+)
+
+// Fanout is the number of children of each node above the leaves.
+const Fanout = 16
+
+// fanoutBits is the number of hash bits selecting the child at each level.
+const fanoutBits = 4
+
+// MaxDepth is the maximum depth of a tree, at which it has Fanout^MaxDepth leaf buckets.
+const MaxDepth = 5
+
+// Tree is a set that maintains a Merkle tree of digests over its elements.
+// The digests are updated incrementally on every Insert and Delete.
+type Tree[E comparable] struct {
+	hash   func(E) uint64
+	leaves []leaf[E]
+	digest Digest
+	n      int
+}
+
+// leaf is a bucket of elements whose hashes share a prefix.
+type leaf[E comparable] struct {
+	elems sets.Set[E]
+	sum   uint64 // XOR of the mixed hashes of the elements
+}
+
+// NewTree creates a new Tree with depth levels below the root, i.e. Fanout^depth leaf buckets,
+// containing vals. The hash function must be stable across processes for the digests of different
+// processes to be comparable. If depth is less than 1 or greater than MaxDepth, or hash is nil,
+// NewTree panics.
+//
+// Time complexity: O(Fanout^depth + len(vals) * depth). Space complexity: O(Fanout^depth + len(vals)).
+func NewTree[E comparable](depth int, hash func(E) uint64, vals ...E) *Tree[E] {
+	if depth < 1 {
+		panic("cannot be less than 1")
+	}
+	if depth > MaxDepth {
+		panic("cannot be greater than MaxDepth")
+	}
+	if hash == nil {
+		panic("cannot be nil")
+	}
+	t := &Tree[E]{hash: hash, leaves: make([]leaf[E], 1<<(fanoutBits*depth)), digest: newDigest(depth)}
+	t.Insert(vals...)
+	return t
+}
+
+// Insert inserts the given elements into the set and updates the digests of their buckets.
+// Elements already present in the set are ignored.
+//
+// Time complexity: O(len(v) * depth). Space complexity: O(len(v)).
+func (t *Tree[E]) Insert(v ...E) {
+	for _, e := range v {
+		h := t.hash(e)
+		i := t.bucket(h)
+		l := &t.leaves[i]
+		if sets.Contains(l.elems, e) {
+			continue
+		}
+		if l.elems == nil {
+			l.elems = sets.New[E](1)
+		}
+		sets.Insert(l.elems, e)
+		t.update(i, h, 1)
+	}
+}
+
+// Delete deletes the given elements from the set and updates the digests of their buckets.
+// Elements not present in the set are ignored.
+//
+// Time complexity: O(len(v) * depth). Space complexity: O(1).
+func (t *Tree[E]) Delete(v ...E) {
+	for _, e := range v {
+		h := t.hash(e)
+		i := t.bucket(h)
+		l := &t.leaves[i]
+		if !sets.Contains(l.elems, e) {
+			continue
+		}
+		sets.Delete(l.elems, e)
+		t.update(i, h, -1)
+	}
+}
+
+// Contains reports whether v is present in the set.
+//
+// Time complexity: O(1). Space complexity: O(1).
+func (t *Tree[E]) Contains(v E) bool {
+	return sets.Contains(t.leaves[t.bucket(t.hash(v))].elems, v)
+}
+
+// Len returns the number of elements in the set.
+//
+// Time complexity: O(1). Space complexity: O(1).
+func (t *Tree[E]) Len() int {
+	return t.n
+}
+
+// All returns an iterator over the elements of the set in order of their buckets.
+// The order of elements within a bucket is not specified.
+//
+// Creation: O(1) time, O(1) space.
+// Iteration: O(Fanout^depth + len(t)) time, O(1) space.
+func (t *Tree[E]) All() iter.Seq[E] {
+	return func(yield func(E) bool) {
+		for _, l := range t.leaves {
+			for e := range l.elems {
+				if !yield(e) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Depth returns the number of levels below the root.
+//
+// Time complexity: O(1). Space complexity: O(1).
+func (t *Tree[E]) Depth() int {
+	return t.digest.Depth()
+}
+
+// Root returns the digest of the root, which is equal for trees with the same depth,
+// hash function and elements.
+//
+// Time complexity: O(1). Space complexity: O(1).
+func (t *Tree[E]) Root() uint64 {
+	return t.digest.levels[0][0]
+}
+
+// Digest returns a copy of the digests of all nodes, which can be sent to another replica.
+//
+// Time complexity: O(Fanout^depth). Space complexity: O(Fanout^depth).
+func (t *Tree[E]) Digest() *Digest {
+	return t.digest.Clone()
+}
+
+// Diff returns the indexes of the leaf buckets whose digests differ between t and remote
+// in ascending order, descending only into differing nodes.
+// If remote has a different depth, the error is ErrMismatch.
+//
+// Time complexity: O(N * Fanout * depth). Space complexity: O(N). N is the number of differing buckets.
+func (t *Tree[E]) Diff(remote *Digest) ([]int, error) {
+	return t.digest.Diff(remote)
+}
+
+// Bucket returns the index of the leaf bucket v belongs to.
+//
+// Time complexity: O(1). Space complexity: O(1).
+func (t *Tree[E]) Bucket(v E) int {
+	return t.bucket(t.hash(v))
+}
+
+// Extract returns a new set containing the elements of the given leaf buckets.
+// If a bucket index is out of range, Extract panics.
+//
+// Time complexity: O(N). Space complexity: O(N). N is the number of elements in the buckets.
+func (t *Tree[E]) Extract(buckets ...int) sets.Set[E] {
+	n := 0
+	for _, b := range buckets {
+		n += len(t.leaves[b].elems)
+	}
+	r := sets.New[E](n)
+	for _, b := range buckets {
+		sets.Copy(r, t.leaves[b].elems)
+	}
+	return r
+}
+
+// bucket returns the index of the leaf bucket for the hash h, given by its top bits.
+func (t *Tree[E]) bucket(h uint64) int {
+	return int(h >> (64 - fanoutBits*t.Depth()))
+}
+
+// update recomputes the digests of the leaf i and its ancestors after an element with hash h
+// has been inserted into it (delta 1) or deleted from it (delta -1).
+func (t *Tree[E]) update(i int, h uint64, delta int) {
+	l := &t.leaves[i]
+	l.sum ^= mix(h)
+	t.n += delta
+	t.digest.set(i, leafDigest(l.sum, len(l.elems)))
+}
+
+// HashString returns a hash of s that is stable across processes.
+func HashString(s string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(s))
+	return mix(h.Sum64())
+}
+
+// HashInteger returns a hash of v that is stable across processes.
+func HashInteger[T sets.Integer](v T) uint64 {
+	return mix(uint64(v))
+}
+
+// leafDigest returns the digest of a leaf with n elements whose mixed hashes XOR to sum.
+// An empty leaf has the digest 0.
+func leafDigest(sum uint64, n int) uint64 {
+	if n == 0 {
+		return 0
+	}
+	return mix(sum + uint64(n)*0x9e3779b97f4a7c15)
+}
+
+// mix is the finalizer of SplitMix64.
+func mix(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
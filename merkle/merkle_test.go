@@ -0,0 +1,155 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package merkle
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/kkhmel/sets"
+)
+
+func TestTree(t *testing.T) {
+	tr := NewTree(2, HashString, "a", "b", "c", "a")
+	if got := tr.Len(); got != 3 {
+		t.Errorf("\nwant: %v\ngot : %v", 3, got)
+	}
+	tr.Delete("b", "z")
+	if got := tr.Len(); got != 2 {
+		t.Errorf("\nwant: %v\ngot : %v", 2, got)
+	}
+	if !tr.Contains("a") || tr.Contains("b") {
+		t.Errorf("Contains() = %v, %v", tr.Contains("a"), tr.Contains("b"))
+	}
+	if got, want := sets.Collect(tr.All()), sets.From("a", "c"); !sets.Equal(got, want) {
+		t.Errorf("\nwant: %v\ngot : %v", want, got)
+	}
+	for range tr.All() {
+		break
+	}
+	if got := tr.Depth(); got != 2 {
+		t.Errorf("\nwant: %v\ngot : %v", 2, got)
+	}
+
+	tr.Delete("a", "c")
+	if got := tr.Root(); got != 0 {
+		t.Errorf("root of an empty tree = %x", got)
+	}
+}
+
+func TestTreeIncremental(t *testing.T) {
+	// Digests updated on every change equal the digests of a tree built from the final elements.
+	rng := rand.New(rand.NewPCG(1, 2))
+	tr := NewTree(3, HashInteger[int])
+	want := sets.New[int](0)
+	for range 5000 {
+		v := rng.IntN(2000)
+		if rng.IntN(3) == 0 {
+			tr.Delete(v)
+			sets.Delete(want, v)
+		} else {
+			tr.Insert(v)
+			sets.Insert(want, v)
+		}
+	}
+	built := NewTree(3, HashInteger[int], sets.ToSlice(want)...)
+	if tr.Root() != built.Root() || tr.Len() != len(want) {
+		t.Errorf("incremental root %x with %d elements, want %x with %d", tr.Root(), tr.Len(), built.Root(), len(want))
+	}
+	if diff, _ := tr.Diff(built.Digest()); len(diff) != 0 {
+		t.Errorf("buckets differ: %v", diff)
+	}
+}
+
+func TestTreeStable(t *testing.T) {
+	// The digests must not change between processes or releases.
+	tr := NewTree(1, HashString, "apple", "banana", "cherry")
+	if got, want := fmt.Sprintf("%016x", tr.Root()), "b7af50f9f4e40b5c"; got != want {
+		t.Errorf("\nwant: %v\ngot : %v", want, got)
+	}
+	if got, want := fmt.Sprintf("%016x", HashString("apple")), "ba8e799dceb3bcb1"; got != want {
+		t.Errorf("\nwant: %v\ngot : %v", want, got)
+	}
+}
+
+func TestTreeDiff(t *testing.T) {
+	rng := rand.New(rand.NewPCG(3, 4))
+	local, remote := NewTree(3, HashInteger[uint64]), NewTree(3, HashInteger[uint64])
+	for range 10000 {
+		v := rng.Uint64()
+		local.Insert(v)
+		remote.Insert(v)
+	}
+	onlyLocal, onlyRemote := []uint64{rng.Uint64(), rng.Uint64()}, []uint64{rng.Uint64()}
+	local.Insert(onlyLocal...)
+	remote.Insert(onlyRemote...)
+
+	diff, err := local.Diff(remote.Digest())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := sets.New[int](0)
+	for _, v := range slices.Concat(onlyLocal, onlyRemote) {
+		sets.Insert(want, local.Bucket(v))
+	}
+	if !slices.IsSorted(diff) || !sets.Equal(sets.FromSlice(diff), want) {
+		t.Errorf("\nwant: %v\ngot : %v", want, diff)
+	}
+
+	// Exchanging the elements of the differing buckets synchronizes the trees.
+	fromLocal, fromRemote := local.Extract(diff...), remote.Extract(diff...)
+	if len(fromLocal) > 20 || len(fromRemote) > 20 {
+		t.Errorf("extracted %d and %d elements for %d buckets", len(fromLocal), len(fromRemote), len(diff))
+	}
+	local.Insert(sets.ToSlice(fromRemote)...)
+	remote.Insert(sets.ToSlice(fromLocal)...)
+	if local.Root() != remote.Root() || local.Len() != 10003 {
+		t.Errorf("trees did not converge: %x, %x", local.Root(), remote.Root())
+	}
+
+	if _, err := local.Diff(NewTree(2, HashInteger[uint64]).Digest()); err != ErrMismatch {
+		t.Errorf("\nwant: %v\ngot : %v", ErrMismatch, err)
+	}
+}
+
+func TestNewTreePanics(t *testing.T) {
+	tests := []struct {
+		name  string
+		depth int
+		hash  func(string) uint64
+	}{
+		{name: "depth too small", depth: 0, hash: HashString},
+		{name: "depth too large", depth: MaxDepth + 1, hash: HashString},
+		{name: "nil hash", depth: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("NewTree() did not panic")
+				}
+			}()
+			NewTree(tt.depth, tt.hash)
+		})
+	}
+}
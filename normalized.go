@@ -0,0 +1,313 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sets
+
+import (
+	"iter"
+	"maps"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// FoldCase returns a case-insensitive key for s using Unicode simple case folding,
+// so that, for example, "Go", "GO" and "go" share a key. The key is meant for comparison only.
+// Multi-rune foldings such as "ß" and "ss" are not unified; use a custom normalizer
+// (e.g. one based on golang.org/x/text) for full Unicode case folding and normalization forms.
+//
+// Time complexity: O(len(s)). Space complexity: O(len(s)).
+func FoldCase(s string) string {
+	return strings.Map(foldRune, s)
+}
+
+// foldRune returns the smallest rune of the case folding orbit of r, which is the same for all runes of the orbit.
+func foldRune(r rune) rune {
+	m := r
+	for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+		m = min(m, f)
+	}
+	return m
+}
+
+// ChainNormalizers returns a normalizer that applies fs from left to right.
+//
+// Time complexity: O(len(fs)) normalizer calls.
+func ChainNormalizers(fs ...func(string) string) func(string) string {
+	fs = slices.Clone(fs)
+	return func(s string) string {
+		for _, f := range fs {
+			s = f(s)
+		}
+		return s
+	}
+}
+
+// Normalized is a set of strings that treats strings with the same normalized key as the same element,
+// e.g. "Foo" and "foo" when normalizing with FoldCase.
+//
+// The set keeps the first-seen spelling of each element for display and records the other spellings
+// that collided with it. Operations combining several sets match elements by the key computed with
+// the normalizer of the receiver.
+//
+// Use NewNormalized to create a Normalized set; the zero value is not ready to use.
+type Normalized struct {
+	norm       func(string) string
+	originals  map[string]string   // key -> first-seen spelling
+	collisions map[string][]string // key -> other distinct spellings, in order of appearance
+}
+
+// NewNormalized creates a new Normalized set containing vals, which uses norm to compute element keys.
+// If norm is nil, strings are compared as is.
+//
+// Time complexity: O(len(vals)) normalizer calls. Space complexity: O(len(vals)).
+func NewNormalized(norm func(string) string, vals ...string) *Normalized {
+	if norm == nil {
+		norm = func(s string) string { return s }
+	}
+	n := &Normalized{
+		norm:       norm,
+		originals:  make(map[string]string, len(vals)),
+		collisions: make(map[string][]string),
+	}
+	n.Insert(vals...)
+	return n
+}
+
+// Insert inserts the given elements into the set.
+// An element whose key is already present keeps its first-seen spelling, and a new spelling is recorded as a collision.
+//
+// Time complexity: O(len(v)) normalizer calls. Space complexity: O(len(v)).
+func (n *Normalized) Insert(v ...string) {
+	for _, e := range v {
+		n.insert(n.norm(e), e)
+	}
+}
+
+// Delete deletes the elements with the same keys as the specified strings from the set,
+// together with their recorded collisions.
+//
+// Time complexity: O(len(v)) normalizer calls. Space complexity: O(1).
+func (n *Normalized) Delete(v ...string) {
+	for _, e := range v {
+		key := n.norm(e)
+		delete(n.originals, key)
+		delete(n.collisions, key)
+	}
+}
+
+// Contains reports whether an element with the same key as v is present in the set.
+//
+// Time complexity: O(1) normalizer calls. Space complexity: O(1).
+func (n *Normalized) Contains(v string) bool {
+	_, ok := n.originals[n.norm(v)]
+	return ok
+}
+
+// Original returns the first-seen spelling of the element with the same key as v.
+// The boolean is false if there is no such element.
+//
+// Time complexity: O(1) normalizer calls. Space complexity: O(1).
+func (n *Normalized) Original(v string) (string, bool) {
+	original, ok := n.originals[n.norm(v)]
+	return original, ok
+}
+
+// Collisions returns, for every element that was inserted under more than one spelling,
+// its first-seen spelling mapped to the other spellings in order of appearance.
+//
+// Time complexity: O(c). Space complexity: O(c). c is the number of recorded spellings.
+func (n *Normalized) Collisions() map[string][]string {
+	r := make(map[string][]string, len(n.collisions))
+	for key, spellings := range n.collisions {
+		r[n.originals[key]] = slices.Clone(spellings)
+	}
+	return r
+}
+
+// Len returns the number of elements in the set.
+//
+// Time complexity: O(1). Space complexity: O(1).
+func (n *Normalized) Len() int {
+	return len(n.originals)
+}
+
+// All returns an iterator over the first-seen spellings of the elements of the set.
+// The iteration order is not specified.
+//
+// Creation: O(1) time, O(1) space.
+// Iteration: O(len(n)) time, O(1) space.
+func (n *Normalized) All() iter.Seq[string] {
+	return func(yield func(string) bool) {
+		for _, original := range n.originals {
+			if !yield(original) {
+				return
+			}
+		}
+	}
+}
+
+// ToSet returns a new Set containing the first-seen spellings of the elements of n.
+//
+// Time complexity: O(len(n)). Space complexity: O(len(n)).
+func (n *Normalized) ToSet() Set[string] {
+	r := New[string](len(n.originals))
+	for _, original := range n.originals {
+		r[original] = struct{}{}
+	}
+	return r
+}
+
+// Equal reports whether n and other contain elements with the same keys.
+//
+// Time complexity: O(len(n)) normalizer calls. Space complexity: O(len(n)).
+func (n *Normalized) Equal(other *Normalized) bool {
+	keys := other.keys(n.norm)
+	if len(keys) != len(n.originals) {
+		return false
+	}
+	for key := range n.originals {
+		if _, ok := keys[key]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// Union returns a new set containing all elements from n and all of the others.
+//
+// Time complexity: O(N*log(N)), with O(N) normalizer calls. Space complexity: O(N). N is the total number of spellings.
+func (n *Normalized) Union(others ...*Normalized) *Normalized {
+	return n.combine(others, func(_ bool, count int) bool { return count > 0 })
+}
+
+// Intersection returns a new set containing only elements present in n and in all of the others.
+//
+// Time complexity: O(N*log(N)), with O(N) normalizer calls. Space complexity: O(N). N is the total number of spellings.
+func (n *Normalized) Intersection(others ...*Normalized) *Normalized {
+	return n.combine(others, func(_ bool, count int) bool { return count == len(others)+1 })
+}
+
+// Difference returns a new set containing elements present in n but not in any of the others.
+//
+// Time complexity: O(N*log(N)), with O(N) normalizer calls. Space complexity: O(N). N is the total number of spellings.
+func (n *Normalized) Difference(others ...*Normalized) *Normalized {
+	return n.combine(others, func(inFirst bool, count int) bool { return inFirst && count == 1 })
+}
+
+// SymmetricDifference returns a new set containing elements that belong to an odd number of the sets n and others.
+//
+// Time complexity: O(N*log(N)), with O(N) normalizer calls. Space complexity: O(N). N is the total number of spellings.
+func (n *Normalized) SymmetricDifference(others ...*Normalized) *Normalized {
+	return n.combine(others, func(_ bool, count int) bool { return count%2 == 1 })
+}
+
+// CartesianProduct returns a new set containing all ordered pairs of the first-seen spellings of
+// the elements of n and other.
+//
+// Time complexity: O(len(n) * len(other)). Space complexity: O(len(n) * len(other)).
+func (n *Normalized) CartesianProduct(other *Normalized) Set[Pair[string, string]] {
+	return CartesianProduct(n.ToSet(), other.ToSet())
+}
+
+// Clone returns a copy of n that shares its normalizer.
+//
+// Time complexity: O(c). Space complexity: O(c). c is the number of recorded spellings.
+func (n *Normalized) Clone() *Normalized {
+	r := NewNormalized(n.norm)
+	for key, original := range n.originals {
+		r.originals[key] = original
+	}
+	for key, spellings := range n.collisions {
+		r.collisions[key] = slices.Clone(spellings)
+	}
+	return r
+}
+
+// String returns a string representation of the set in the format "{elem1, elem2, ...}"
+// using the first-seen spellings. Elements are sorted for consistent output.
+//
+// Time complexity: O(len(n)*log(len(n))). Space complexity: O(len(n)).
+func (n *Normalized) String() string {
+	return n.ToSet().String()
+}
+
+func (n *Normalized) insert(key, spelling string) {
+	original, ok := n.originals[key]
+	if !ok {
+		n.originals[key] = spelling
+		return
+	}
+	if spelling != original && !slices.Contains(n.collisions[key], spelling) {
+		n.collisions[key] = append(n.collisions[key], spelling)
+	}
+}
+
+// spellings returns an iterator over all spellings of n: every first-seen spelling followed by its collisions,
+// ordered by key so that sets combining them keep the same first-seen spellings on every run.
+func (n *Normalized) spellings() iter.Seq[string] {
+	return func(yield func(string) bool) {
+		for _, key := range slices.Sorted(maps.Keys(n.originals)) {
+			if !yield(n.originals[key]) {
+				return
+			}
+			for _, s := range n.collisions[key] {
+				if !yield(s) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// keys returns the keys of the elements of n computed with norm.
+func (n *Normalized) keys(norm func(string) string) Set[string] {
+	r := New[string](len(n.originals))
+	for _, original := range n.originals {
+		r[norm(original)] = struct{}{}
+	}
+	return r
+}
+
+// combine returns a new set with the elements of n and others whose keys satisfy keep.
+// keep receives whether the key is present in n and the number of sets containing it.
+// Spellings are inserted in order of the sets, so first-seen spellings and collisions are preserved.
+// When the sets use different normalizers, several spellings of a set may share a key of n;
+// the first of them in order of their keys in that set becomes the first-seen spelling.
+func (n *Normalized) combine(others []*Normalized, keep func(inFirst bool, count int) bool) *Normalized {
+	all := append([]*Normalized{n}, others...)
+	counts := make(map[string]int)
+	for _, s := range all {
+		for key := range s.keys(n.norm) {
+			counts[key]++
+		}
+	}
+
+	r := NewNormalized(n.norm)
+	for _, s := range all {
+		for spelling := range s.spellings() {
+			key := n.norm(spelling)
+			if _, inFirst := n.originals[key]; keep(inFirst, counts[key]) {
+				r.insert(key, spelling)
+			}
+		}
+	}
+	return r
+}
@@ -0,0 +1,278 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sets
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestFoldCase(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want bool
+	}{
+		{name: "ascii", a: "Hello", b: "hELLO", want: true},
+		{name: "different", a: "hello", b: "help", want: false},
+		{name: "cyrillic", a: "Привет", b: "пРИВЕТ", want: true},
+		{name: "greek sigma forms", a: "ΣΑΣ", b: "σας", want: true},
+		{name: "kelvin sign", a: "K", b: "k", want: true},
+		{name: "multi-rune folding not unified", a: "straße", b: "STRASSE", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FoldCase(tt.a) == FoldCase(tt.b); got != tt.want {
+				t.Errorf("\nwant: %v\ngot : %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestChainNormalizers(t *testing.T) {
+	norm := ChainNormalizers(strings.TrimSpace, strings.ToLower)
+	if got, want := norm("  MiXeD \n"), "mixed"; got != want {
+		t.Errorf("\nwant: %q\ngot : %q", want, got)
+	}
+	if got, want := ChainNormalizers()("As Is"), "As Is"; got != want {
+		t.Errorf("\nwant: %q\ngot : %q", want, got)
+	}
+}
+
+func TestNormalizedInsert(t *testing.T) {
+	tests := []struct {
+		name           string
+		norm           func(string) string
+		insert         []string
+		want           string
+		wantCollisions map[string][]string
+	}{
+		{
+			name:           "identity normalizer",
+			norm:           nil,
+			insert:         []string{"Go", "go", "Go"},
+			want:           "{Go, go}",
+			wantCollisions: map[string][]string{},
+		},
+		{
+			name:           "case folding keeps first spelling",
+			norm:           FoldCase,
+			insert:         []string{"Go", "go", "GO", "go", "Rust"},
+			want:           "{Go, Rust}",
+			wantCollisions: map[string][]string{"Go": {"go", "GO"}},
+		},
+		{
+			name:           "trimming and folding",
+			norm:           ChainNormalizers(strings.TrimSpace, FoldCase),
+			insert:         []string{" Alice@Example.com", "alice@example.com ", "bob@example.com"},
+			want:           "{ Alice@Example.com, bob@example.com}",
+			wantCollisions: map[string][]string{" Alice@Example.com": {"alice@example.com "}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewNormalized(tt.norm, tt.insert...)
+			if got := s.String(); got != tt.want {
+				t.Errorf("\nwant: %v\ngot : %v", tt.want, got)
+			}
+			if got := s.Collisions(); !maps.EqualFunc(got, tt.wantCollisions, slices.Equal) {
+				t.Errorf("Collisions()\nwant: %v\ngot : %v", tt.wantCollisions, got)
+			}
+		})
+	}
+}
+
+func TestNormalizedLookups(t *testing.T) {
+	s := NewNormalized(FoldCase, "Go", "Rust")
+	s.Insert("GO")
+
+	if !s.Contains("go") || !s.Contains("RUST") || s.Contains("zig") {
+		t.Errorf("Contains() returned wrong result")
+	}
+	if got, ok := s.Original("gO"); !ok || got != "Go" {
+		t.Errorf("\nwant: %v\ngot : %v", "Go", got)
+	}
+	if _, ok := s.Original("zig"); ok {
+		t.Errorf("Original() reported an absent element")
+	}
+	if got := s.Len(); got != 2 {
+		t.Errorf("\nwant: %v\ngot : %v", 2, got)
+	}
+	if got, want := slices.Sorted(s.All()), []string{"Go", "Rust"}; !slices.Equal(got, want) {
+		t.Errorf("\nwant: %v\ngot : %v", want, got)
+	}
+	if got, want := s.ToSet(), From("Go", "Rust"); !Equal(got, want) {
+		t.Errorf("\nwant: %v\ngot : %v", want, got)
+	}
+
+	count := 0
+	for range s.All() {
+		count++
+		break
+	}
+	if count != 1 {
+		t.Errorf("expected to iterate 1 time, got %d", count)
+	}
+}
+
+func TestNormalizedDelete(t *testing.T) {
+	s := NewNormalized(FoldCase, "Go", "go", "Rust")
+	s.Delete("GO", "zig")
+	if got, want := s.String(), "{Rust}"; got != want {
+		t.Errorf("\nwant: %v\ngot : %v", want, got)
+	}
+	if got := s.Collisions(); len(got) != 0 {
+		t.Errorf("collisions of deleted elements were kept: %v", got)
+	}
+	s.Insert("go")
+	if got, ok := s.Original("GO"); !ok || got != "go" {
+		t.Errorf("\nwant: %v\ngot : %v", "go", got)
+	}
+}
+
+func TestNormalizedOperations(t *testing.T) {
+	a := NewNormalized(FoldCase, "Go", "Rust", "Zig")
+	b := NewNormalized(FoldCase, "go", "RUST", "C")
+	c := NewNormalized(FoldCase, "GO", "c", "Odin")
+	tests := []struct {
+		name           string
+		got            *Normalized
+		want           string
+		wantCollisions map[string][]string
+	}{
+		{
+			name:           "union",
+			got:            a.Union(b, c),
+			want:           "{C, Go, Odin, Rust, Zig}",
+			wantCollisions: map[string][]string{"Go": {"go", "GO"}, "Rust": {"RUST"}, "C": {"c"}},
+		},
+		{
+			name:           "union of none",
+			got:            a.Union(),
+			want:           "{Go, Rust, Zig}",
+			wantCollisions: map[string][]string{},
+		},
+		{
+			name:           "intersection",
+			got:            a.Intersection(b),
+			want:           "{Go, Rust}",
+			wantCollisions: map[string][]string{"Go": {"go"}, "Rust": {"RUST"}},
+		},
+		{
+			name:           "intersection of three",
+			got:            a.Intersection(b, c),
+			want:           "{Go}",
+			wantCollisions: map[string][]string{"Go": {"go", "GO"}},
+		},
+		{
+			name:           "difference",
+			got:            a.Difference(b),
+			want:           "{Zig}",
+			wantCollisions: map[string][]string{},
+		},
+		{
+			name:           "difference of two",
+			got:            b.Difference(a, c),
+			want:           "{}",
+			wantCollisions: map[string][]string{},
+		},
+		{
+			name:           "symmetric difference",
+			got:            a.SymmetricDifference(b, c),
+			want:           "{Go, Odin, Zig}",
+			wantCollisions: map[string][]string{"Go": {"go", "GO"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.got.String(); got != tt.want {
+				t.Errorf("\nwant: %v\ngot : %v", tt.want, got)
+			}
+			if got := tt.got.Collisions(); !maps.EqualFunc(got, tt.wantCollisions, slices.Equal) {
+				t.Errorf("Collisions()\nwant: %v\ngot : %v", tt.wantCollisions, got)
+			}
+		})
+	}
+	if got := a.String(); got != "{Go, Rust, Zig}" {
+		t.Errorf("operations modified the receiver: %v", got)
+	}
+}
+
+func TestNormalizedOperationsMixedNormalizers(t *testing.T) {
+	folded := NewNormalized(FoldCase)
+	exact := NewNormalized(nil, "b", "a", "B", "A", "c")
+	want := map[string][]string{"A": {"a"}, "B": {"b"}}
+	// Repeat, since an unordered iteration would pick different first-seen spellings on different runs.
+	for range 20 {
+		got := folded.Union(exact)
+		if got.String() != "{A, B, c}" || !maps.EqualFunc(got.Collisions(), want, slices.Equal) {
+			t.Fatalf("\nwant: {A, B, c} %v\ngot : %v %v", want, got, got.Collisions())
+		}
+	}
+}
+
+func TestNormalizedCartesianProduct(t *testing.T) {
+	a := NewNormalized(FoldCase, "A", "a")
+	b := NewNormalized(FoldCase, "x", "Y")
+	want := From(Pair[string, string]{"A", "x"}, Pair[string, string]{"A", "Y"})
+	if got := a.CartesianProduct(b); !Equal(got, want) {
+		t.Errorf("\nwant: %v\ngot : %v", want, got)
+	}
+}
+
+func TestNormalizedEqualClone(t *testing.T) {
+	s := NewNormalized(FoldCase, "Go", "go", "Rust")
+	clone := s.Clone()
+	if !clone.Equal(s) || !s.Equal(NewNormalized(FoldCase, "RUST", "GO")) {
+		t.Errorf("Equal() reported equal sets as different")
+	}
+	if !maps.EqualFunc(clone.Collisions(), s.Collisions(), slices.Equal) {
+		t.Errorf("Clone() lost collisions")
+	}
+
+	clone.Insert("GO", "Zig")
+	if clone.Equal(s) || s.Contains("zig") || len(s.Collisions()["Go"]) != 1 {
+		t.Errorf("Clone() shares storage with the original")
+	}
+	if s.Equal(NewNormalized(FoldCase, "go", "zig")) {
+		t.Errorf("Equal() reported different sets as equal")
+	}
+}
+
+func TestNormalizedEarlyTermination(t *testing.T) {
+	s := NewNormalized(FoldCase, "a", "A", "b", "B")
+	count := 0
+	for range s.spellings() {
+		count++
+		break
+	}
+	for range s.spellings() {
+		count++
+		if count == 3 {
+			break
+		}
+	}
+	if count != 3 {
+		t.Errorf("expected to iterate 3 times, got %d", count)
+	}
+}
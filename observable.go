@@ -0,0 +1,295 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sets
+
+import (
+	"iter"
+	"sync"
+	"sync/atomic"
+)
+
+// Change describes a batch of modifications applied to a set by a single mutation.
+// Added holds elements that were absent before the mutation and present after it,
+// Removed holds elements that were present before the mutation and absent after it.
+// Both sets are never nil and must not be modified by receivers.
+type Change[E comparable] struct {
+	Added   Set[E]
+	Removed Set[E]
+}
+
+// Empty reports whether c neither adds nor removes any element.
+//
+// Time complexity: O(1). Space complexity: O(1).
+func (c Change[E]) Empty() bool {
+	return len(c.Added) == 0 && len(c.Removed) == 0
+}
+
+// Observable is a set that notifies registered listeners about every change made to it.
+// Each mutating call produces at most one Change event; calls that do not modify the set produce none.
+//
+// Observable is safe for concurrent use. Events are delivered in the order in which
+// the corresponding mutations were applied, and each listener is invoked sequentially.
+// Listeners may read the set, which may already reflect later mutations,
+// but must not modify it: doing so deadlocks.
+//
+// Use NewObservable to create an Observable; the zero value is not ready to use.
+type Observable[E comparable] struct {
+	mu   sync.RWMutex
+	s    Set[E]
+	subs []*subscription[E]
+
+	// delivered is closed once the latest change has been delivered. Every change replaces it
+	// while mu is held and waits for the previous one before delivery, so events arrive
+	// in mutation order without holding mu during delivery.
+	delivered chan struct{}
+}
+
+type subscription[E comparable] struct {
+	f      func(Change[E])
+	active atomic.Bool
+}
+
+// NewObservable creates a new empty Observable with the specified initial capacity.
+//
+// Time complexity: O(1). Space complexity: O(n). n is the passed capacity.
+func NewObservable[E comparable](capacity int) *Observable[E] {
+	o := &Observable[E]{s: New[E](capacity), delivered: make(chan struct{})}
+	close(o.delivered)
+	return o
+}
+
+// Subscribe registers f to be called with every subsequent change of o.
+// The returned function unsubscribes f; after it returns, f is not invoked again
+// unless an invocation is already in progress. Calling it more than once is a no-op.
+//
+// Time complexity: O(1). Space complexity: O(1).
+func (o *Observable[E]) Subscribe(f func(Change[E])) (unsubscribe func()) {
+	sub := &subscription[E]{f: f}
+	sub.active.Store(true)
+
+	o.mu.Lock()
+	o.subs = append(o.subs, sub)
+	o.mu.Unlock()
+
+	return func() {
+		if !sub.active.Swap(false) {
+			return
+		}
+		o.mu.Lock()
+		defer o.mu.Unlock()
+		// Copy on write: deliveries in progress keep iterating over the old slice.
+		subs := make([]*subscription[E], 0, len(o.subs))
+		for _, s := range o.subs {
+			if s != sub {
+				subs = append(subs, s)
+			}
+		}
+		o.subs = subs
+	}
+}
+
+// Notify registers ch to receive every subsequent change of o.
+// Sends are blocking, so a slow receiver delays all mutations of o;
+// use a buffered channel to absorb bursts. The returned function unsubscribes ch.
+//
+// Time complexity: O(1). Space complexity: O(1).
+func (o *Observable[E]) Notify(ch chan<- Change[E]) (unsubscribe func()) {
+	return o.Subscribe(func(c Change[E]) { ch <- c })
+}
+
+// Insert inserts the given elements into the set.
+// Elements already present in the set are ignored.
+//
+// Time complexity: O(len(v)). Space complexity: O(len(v)).
+func (o *Observable[E]) Insert(v ...E) {
+	o.update(func() Change[E] {
+		c := newChange[E]()
+		for _, e := range v {
+			if _, ok := o.s[e]; !ok {
+				o.s[e] = struct{}{}
+				c.Added[e] = struct{}{}
+			}
+		}
+		return c
+	})
+}
+
+// Delete deletes the specified elements from the set.
+// Elements not present in the set are ignored.
+//
+// Time complexity: O(len(v)). Space complexity: O(len(v)).
+func (o *Observable[E]) Delete(v ...E) {
+	o.update(func() Change[E] {
+		c := newChange[E]()
+		for _, e := range v {
+			if _, ok := o.s[e]; ok {
+				delete(o.s, e)
+				c.Removed[e] = struct{}{}
+			}
+		}
+		return c
+	})
+}
+
+// DeleteFunc deletes any elements from the set for which del returns true.
+// del must not access o. If del panics, the set is not modified.
+//
+// Time complexity: O(len(o)). Space complexity: O(len(o)).
+func (o *Observable[E]) DeleteFunc(del func(E) bool) {
+	o.update(func() Change[E] {
+		c := newChange[E]()
+		for e := range o.s {
+			if del(e) {
+				c.Removed[e] = struct{}{}
+			}
+		}
+		for e := range c.Removed {
+			delete(o.s, e)
+		}
+		return c
+	})
+}
+
+// Replace replaces old with new in the set. If old is not present, Replace is a no-op.
+//
+// Time complexity: O(1). Space complexity: O(1).
+func (o *Observable[E]) Replace(old, new E) { //nolint:revive // 'new' follows stdlib pattern (see strings.Replace)
+	o.update(func() Change[E] {
+		c := newChange[E]()
+		if _, ok := o.s[old]; ok && old != new {
+			delete(o.s, old)
+			c.Removed[old] = struct{}{}
+			if _, ok := o.s[new]; !ok {
+				o.s[new] = struct{}{}
+				c.Added[new] = struct{}{}
+			}
+		}
+		return c
+	})
+}
+
+// ReplaceFunc replaces each element e in the set with f(e).
+// Since f may map multiple elements to the same value, the resulting set may be smaller.
+// f must not access o. If f panics, the set is not modified.
+//
+// Time complexity: O(len(o)). Space complexity: O(len(o)).
+func (o *Observable[E]) ReplaceFunc(f func(E) E) {
+	o.update(func() Change[E] {
+		next := New[E](len(o.s))
+		for e := range o.s {
+			next[f(e)] = struct{}{}
+		}
+		c := Change[E]{
+			Added:   Difference(next, o.s),
+			Removed: Difference(o.s, next),
+		}
+		o.s = next
+		return c
+	})
+}
+
+// Clear deletes all elements from the set.
+//
+// Time complexity: O(len(o)). Space complexity: O(len(o)).
+func (o *Observable[E]) Clear() {
+	o.update(func() Change[E] {
+		c := Change[E]{Added: New[E](0), Removed: o.s}
+		o.s = New[E](0)
+		return c
+	})
+}
+
+// Contains reports whether v is present in the set.
+//
+// Time complexity: O(1). Space complexity: O(1).
+func (o *Observable[E]) Contains(v E) bool {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return Contains(o.s, v)
+}
+
+// Len returns the number of elements in the set.
+//
+// Time complexity: O(1). Space complexity: O(1).
+func (o *Observable[E]) Len() int {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return len(o.s)
+}
+
+// Clone returns a snapshot of the current elements as a new Set.
+//
+// Time complexity: O(len(o)). Space complexity: O(len(o)).
+func (o *Observable[E]) Clone() Set[E] {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return Union(o.s)
+}
+
+// All returns an iterator over a snapshot of the elements of the set.
+// The iteration order is not specified. The set may be modified during iteration.
+//
+// Creation: O(1) time, O(1) space.
+// Iteration: O(len(o)) time, O(len(o)) space.
+func (o *Observable[E]) All() iter.Seq[E] {
+	return func(yield func(E) bool) {
+		o.mu.RLock()
+		elements := ToSlice(o.s)
+		o.mu.RUnlock()
+		for _, e := range elements {
+			if !yield(e) {
+				return
+			}
+		}
+	}
+}
+
+func newChange[E comparable]() Change[E] {
+	return Change[E]{Added: New[E](0), Removed: New[E](0)}
+}
+
+// update applies the mutation made by f with o.mu held for writing, and delivers the change
+// returned by f to the current subscribers after releasing o.mu. o.mu is released even if f panics.
+func (o *Observable[E]) update(f func() Change[E]) {
+	var prev, done chan struct{}
+	var subs []*subscription[E]
+	c := func() Change[E] {
+		o.mu.Lock()
+		defer o.mu.Unlock()
+		c := f()
+		if !c.Empty() {
+			prev, done, subs = o.delivered, make(chan struct{}), o.subs
+			o.delivered = done
+		}
+		return c
+	}()
+	if c.Empty() {
+		return
+	}
+
+	<-prev
+	defer close(done)
+	for _, sub := range subs {
+		if sub.active.Load() {
+			sub.f(c)
+		}
+	}
+}
@@ -0,0 +1,482 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sets
+
+import (
+	"runtime"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestObservableMutations(t *testing.T) {
+	tests := []struct {
+		name        string
+		initial     []int
+		mutate      func(o *Observable[int])
+		want        Set[int]
+		wantAdded   Set[int]
+		wantRemoved Set[int]
+		wantEvent   bool
+	}{
+		{
+			name:        "insert new elements",
+			initial:     []int{1},
+			mutate:      func(o *Observable[int]) { o.Insert(1, 2, 3) },
+			want:        From(1, 2, 3),
+			wantAdded:   From(2, 3),
+			wantRemoved: From[int](),
+			wantEvent:   true,
+		},
+		{
+			name:      "insert present elements",
+			initial:   []int{1, 2},
+			mutate:    func(o *Observable[int]) { o.Insert(1, 2) },
+			want:      From(1, 2),
+			wantEvent: false,
+		},
+		{
+			name:        "delete",
+			initial:     []int{1, 2, 3},
+			mutate:      func(o *Observable[int]) { o.Delete(2, 4) },
+			want:        From(1, 3),
+			wantAdded:   From[int](),
+			wantRemoved: From(2),
+			wantEvent:   true,
+		},
+		{
+			name:      "delete absent elements",
+			initial:   []int{1},
+			mutate:    func(o *Observable[int]) { o.Delete(2, 3) },
+			want:      From(1),
+			wantEvent: false,
+		},
+		{
+			name:        "delete func",
+			initial:     []int{1, 2, 3, 4},
+			mutate:      func(o *Observable[int]) { o.DeleteFunc(func(e int) bool { return e%2 == 0 }) },
+			want:        From(1, 3),
+			wantAdded:   From[int](),
+			wantRemoved: From(2, 4),
+			wantEvent:   true,
+		},
+		{
+			name:        "replace",
+			initial:     []int{1, 2},
+			mutate:      func(o *Observable[int]) { o.Replace(1, 3) },
+			want:        From(2, 3),
+			wantAdded:   From(3),
+			wantRemoved: From(1),
+			wantEvent:   true,
+		},
+		{
+			name:        "replace with present element",
+			initial:     []int{1, 2},
+			mutate:      func(o *Observable[int]) { o.Replace(1, 2) },
+			want:        From(2),
+			wantAdded:   From[int](),
+			wantRemoved: From(1),
+			wantEvent:   true,
+		},
+		{
+			name:      "replace absent element",
+			initial:   []int{1, 2},
+			mutate:    func(o *Observable[int]) { o.Replace(3, 4) },
+			want:      From(1, 2),
+			wantEvent: false,
+		},
+		{
+			name:      "replace with itself",
+			initial:   []int{1, 2},
+			mutate:    func(o *Observable[int]) { o.Replace(1, 1) },
+			want:      From(1, 2),
+			wantEvent: false,
+		},
+		{
+			name:        "replace func",
+			initial:     []int{1, 2, 3, 4},
+			mutate:      func(o *Observable[int]) { o.ReplaceFunc(func(e int) int { return e / 2 }) },
+			want:        From(0, 1, 2),
+			wantAdded:   From(0),
+			wantRemoved: From(3, 4),
+			wantEvent:   true,
+		},
+		{
+			name:      "replace func identity",
+			initial:   []int{1, 2},
+			mutate:    func(o *Observable[int]) { o.ReplaceFunc(func(e int) int { return e }) },
+			want:      From(1, 2),
+			wantEvent: false,
+		},
+		{
+			name:        "clear",
+			initial:     []int{1, 2},
+			mutate:      func(o *Observable[int]) { o.Clear() },
+			want:        From[int](),
+			wantAdded:   From[int](),
+			wantRemoved: From(1, 2),
+			wantEvent:   true,
+		},
+		{
+			name:      "clear empty",
+			initial:   nil,
+			mutate:    func(o *Observable[int]) { o.Clear() },
+			want:      From[int](),
+			wantEvent: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := NewObservable[int](0)
+			o.Insert(tt.initial...)
+
+			var events []Change[int]
+			o.Subscribe(func(c Change[int]) { events = append(events, c) })
+			tt.mutate(o)
+
+			if got := o.Clone(); !Equal(got, tt.want) {
+				t.Errorf("\nwant: %v\ngot : %v", tt.want, got)
+			}
+			if !tt.wantEvent {
+				if len(events) != 0 {
+					t.Errorf("expected no events, got %v", events)
+				}
+				return
+			}
+			if len(events) != 1 {
+				t.Fatalf("expected 1 event, got %d", len(events))
+			}
+			if !Equal(events[0].Added, tt.wantAdded) {
+				t.Errorf("\nwant added: %v\ngot added : %v", tt.wantAdded, events[0].Added)
+			}
+			if !Equal(events[0].Removed, tt.wantRemoved) {
+				t.Errorf("\nwant removed: %v\ngot removed : %v", tt.wantRemoved, events[0].Removed)
+			}
+		})
+	}
+}
+
+func TestObservableReads(t *testing.T) {
+	o := NewObservable[int](3)
+	o.Insert(1, 2, 3)
+
+	if !o.Contains(2) || o.Contains(4) {
+		t.Errorf("Contains() returned wrong result")
+	}
+	if got := o.Len(); got != 3 {
+		t.Errorf("\nwant: %v\ngot : %v", 3, got)
+	}
+
+	got := slices.Sorted(o.All())
+	if want := []int{1, 2, 3}; !slices.Equal(got, want) {
+		t.Errorf("\nwant: %v\ngot : %v", want, got)
+	}
+
+	clone := o.Clone()
+	Insert(clone, 4)
+	if o.Contains(4) {
+		t.Errorf("Clone() shares storage with the observable set")
+	}
+}
+
+func TestObservableAllEarlyTermination(t *testing.T) {
+	o := NewObservable[int](0)
+	o.Insert(1, 2, 3)
+	count := 0
+	for e := range o.All() {
+		count++
+		// Mutating during iteration must not deadlock.
+		o.Delete(e)
+		if count == 2 {
+			break
+		}
+	}
+	if count != 2 {
+		t.Errorf("expected to iterate 2 times, got %d", count)
+	}
+	if got := o.Len(); got != 1 {
+		t.Errorf("\nwant: %v\ngot : %v", 1, got)
+	}
+}
+
+func TestObservableUnsubscribe(t *testing.T) {
+	o := NewObservable[int](0)
+	var first, second int
+	unsubscribe := o.Subscribe(func(Change[int]) { first++ })
+	o.Subscribe(func(Change[int]) { second++ })
+
+	o.Insert(1)
+	unsubscribe()
+	unsubscribe()
+	o.Insert(2)
+
+	if first != 1 {
+		t.Errorf("\nwant: %v\ngot : %v", 1, first)
+	}
+	if second != 2 {
+		t.Errorf("\nwant: %v\ngot : %v", 2, second)
+	}
+}
+
+func TestObservableUnsubscribeDuringDelivery(t *testing.T) {
+	o := NewObservable[int](0)
+	var calls int
+	var unsubscribe func()
+	o.Subscribe(func(Change[int]) { unsubscribe() })
+	unsubscribe = o.Subscribe(func(Change[int]) { calls++ })
+
+	o.Insert(1)
+	o.Insert(2)
+
+	if calls != 0 {
+		t.Errorf("\nwant: %v\ngot : %v", 0, calls)
+	}
+}
+
+func TestObservableListenerReads(t *testing.T) {
+	o := NewObservable[int](0)
+	var seen bool
+	o.Subscribe(func(Change[int]) { seen = o.Contains(1) })
+	o.Insert(1)
+	if !seen {
+		t.Errorf("listener did not observe the applied change")
+	}
+}
+
+func TestObservableNotify(t *testing.T) {
+	o := NewObservable[string](0)
+	ch := make(chan Change[string], 2)
+	unsubscribe := o.Notify(ch)
+
+	o.Insert("a", "b")
+	o.Delete("a")
+	unsubscribe()
+	o.Insert("c")
+	close(ch)
+
+	var got []Change[string]
+	for c := range ch {
+		got = append(got, c)
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(got))
+	}
+	if !Equal(got[0].Added, From("a", "b")) || !Equal(got[1].Removed, From("a")) {
+		t.Errorf("unexpected events: %v", got)
+	}
+}
+
+func TestObservableConcurrentOrdering(t *testing.T) {
+	const (
+		workers = 8
+		rounds  = 200
+	)
+	o := NewObservable[int](0)
+
+	replica := New[int](0)
+	var inconsistent int
+	o.Subscribe(func(c Change[int]) {
+		// Events applied in delivery order must be consistent with the replica.
+		for e := range c.Added {
+			if Contains(replica, e) {
+				inconsistent++
+			}
+		}
+		for e := range c.Removed {
+			if !Contains(replica, e) {
+				inconsistent++
+			}
+		}
+		Copy(replica, c.Added)
+		DeleteFunc(replica, func(e int) bool { return Contains(c.Removed, e) })
+	})
+
+	var wg sync.WaitGroup
+	for w := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range rounds {
+				e := (w*rounds + i) % 50
+				switch i % 4 {
+				case 0, 1:
+					o.Insert(e, e+1)
+				case 2:
+					o.Delete(e)
+				default:
+					o.Replace(e, e+2)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if inconsistent != 0 {
+		t.Errorf("received %d out-of-order changes", inconsistent)
+	}
+	if got := o.Clone(); !Equal(got, replica) {
+		t.Errorf("\nwant: %v\ngot : %v", got, replica)
+	}
+}
+
+func TestObservableConcurrentListenerReads(t *testing.T) {
+	const (
+		workers = 8
+		rounds  = 200
+	)
+	o := NewObservable[int](0)
+	o.Subscribe(func(c Change[int]) {
+		for e := range c.Added {
+			_ = o.Contains(e)
+		}
+		_ = o.Len()
+	})
+	ch := make(chan Change[int])
+	unsubscribe := o.Notify(ch)
+	received := make(chan struct{})
+	go func() {
+		defer close(received)
+		for range ch {
+			_ = o.Len()
+		}
+	}()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		var wg sync.WaitGroup
+		for w := range workers {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := range rounds {
+					o.Insert(w*rounds + i)
+					o.Delete(w*rounds + i)
+				}
+			}()
+		}
+		wg.Wait()
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("mutations deadlocked with listeners reading the set")
+	}
+	unsubscribe()
+	close(ch)
+	<-received
+}
+
+func TestObservableDeliveryWaitsForEarlierChanges(t *testing.T) {
+	o := NewObservable[int](0)
+	release := make(chan struct{})
+	delivered := make(chan Change[int], 2)
+	o.Subscribe(func(c Change[int]) {
+		if Contains(c.Added, 1) {
+			<-release
+		}
+		delivered <- c
+	})
+
+	// A change whose delivery is blocked does not block later mutations,
+	// but holds back their delivery.
+	go o.Insert(1)
+	for !o.Contains(1) {
+		runtime.Gosched()
+	}
+	go o.Insert(2)
+	for !o.Contains(2) {
+		runtime.Gosched()
+	}
+	close(release)
+	for _, want := range []Set[int]{From(1), From(2)} {
+		if c := <-delivered; !Equal(c.Added, want) {
+			t.Errorf("\nwant: %v\ngot : %v", want, c.Added)
+		}
+	}
+}
+
+func TestObservablePanics(t *testing.T) {
+	o := NewObservable[int](0)
+	o.Insert(1, 2)
+	var changes []Change[int]
+	o.Subscribe(func(c Change[int]) {
+		if Contains(c.Added, 3) {
+			panic("listener")
+		}
+		changes = append(changes, c)
+	})
+	for name, f := range map[string]func(){
+		"DeleteFunc":  func() { o.DeleteFunc(func(e int) bool { panic(e) }) },
+		"ReplaceFunc": func() { o.ReplaceFunc(func(e int) int { panic(e) }) },
+		"listener":    func() { o.Insert(3) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s did not panic", name)
+				}
+			}()
+			f()
+		}()
+	}
+	// A panicking callback modifies nothing, and a panicking listener does not block later changes.
+	o.Delete(1)
+	if got, want := o.Clone(), From(2, 3); !Equal(got, want) {
+		t.Errorf("\nwant: %v\ngot : %v", want, got)
+	}
+	if len(changes) != 1 || !Equal(changes[0].Removed, From(1)) {
+		t.Errorf("wrong changes: %v", changes)
+	}
+}
+
+func TestChangeEmpty(t *testing.T) {
+	tests := []struct {
+		name string
+		c    Change[int]
+		want bool
+	}{
+		{
+			name: "zero",
+			c:    Change[int]{},
+			want: true,
+		},
+		{
+			name: "added",
+			c:    Change[int]{Added: From(1)},
+			want: false,
+		},
+		{
+			name: "removed",
+			c:    Change[int]{Removed: From(1)},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.c.Empty(); got != tt.want {
+				t.Errorf("\nwant: %v\ngot : %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package reconcile_test
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/kkhmel/sets"
+	"github.com/kkhmel/sets/reconcile"
+)
+
+func ExampleSketch_Decode() {
+	local := sets.From[uint64](1, 2, 3, 4, 5, 6)
+	remote := sets.From[uint64](2, 3, 4, 5, 6, 7, 8)
+
+	// The remote peer sends its sketch, which is much smaller than its set if the sets are large.
+	data, _ := reconcile.NewSketch(10, remote).MarshalBinary()
+
+	var sketch reconcile.Sketch
+	if err := sketch.UnmarshalBinary(data); err != nil {
+		panic(err)
+	}
+	diff, err := reconcile.NewSketch(10, local).Subtract(&sketch)
+	if err != nil {
+		panic(err)
+	}
+	onlyLocal, onlyRemote, err := diff.Decode()
+	fmt.Println(slices.Sorted(sets.All(onlyLocal)), slices.Sorted(sets.All(onlyRemote)), err)
+
+	// Output:
+	// [1] [7 8] <nil>
+}
@@ -0,0 +1,250 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package reconcile finds the symmetric difference of two sets of uint64 held by different peers
+// while transferring data proportional to the size of the difference rather than of the sets.
+//
+// Each peer builds a Sketch of its set, an Invertible Bloom Lookup Table, with the same capacity.
+// One peer sends its sketch to the other, which subtracts it from its own sketch and decodes
+// the difference into the elements that only it has and the elements that only the sender has:
+//
+//	local := reconcile.NewSketch(1000, mine)
+//	diff, err := local.Subtract(remote)
+//	if err != nil { ... }
+//	onlyMine, onlyTheirs, err := diff.Decode()
+//	if errors.Is(err, reconcile.ErrCapacityExceeded) {
+//		// Retry with a larger capacity or transfer the whole set.
+//	}
+//
+// Decoding succeeds with high probability if the difference has at most capacity elements.
+// A larger difference is reported with ErrCapacityExceeded instead of a wrong result.
+package reconcile
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/kkhmel/sets"
+)
+
+var (
+	// ErrCapacityExceeded is returned by Decode when the difference is too large for the sketch capacity.
+	ErrCapacityExceeded = errors.New("reconcile: difference exceeds sketch capacity")
+	// ErrMismatch is returned by Subtract when the sketches were built with different capacities.
+	ErrMismatch = errors.New("reconcile: sketches have different sizes")
+)
+
+// hashCount is the number of cells every element is added to, one in each subtable.
+const hashCount = 4
+
+// cellSize is the size of an encoded cell in bytes.
+const cellSize = 24
+
+// maxCells limits the size of sketches decoded by UnmarshalBinary.
+const maxCells = 1 << 24
+
+// Sketch is an Invertible Bloom Lookup Table summarizing a set of uint64.
+// Sketches are only comparable if they were created with the same capacity.
+// Create sketches with NewSketch or UnmarshalBinary; the zero value is not ready to use.
+type Sketch struct {
+	cells []cell
+}
+
+// cell holds the sums of the elements hashed to it.
+type cell struct {
+	count   int64  // number of elements added minus the number subtracted
+	keySum  uint64 // XOR of the elements
+	hashSum uint64 // XOR of the checksums of the elements
+}
+
+// NewSketch creates a sketch of the elements of s that can decode a difference of up to
+// capacity elements. If capacity is less than 1, NewSketch panics.
+//
+// Time complexity: O(capacity + len(s)). Space complexity: O(capacity).
+func NewSketch(capacity int, s sets.Set[uint64]) *Sketch {
+	if capacity < 1 {
+		panic("cannot be less than 1")
+	}
+	// About 1.3 cells per element are needed to decode large differences. Small ones need
+	// extra cells, mostly to make it unlikely that two elements share all of their cells.
+	n := (capacity*3/2 + 16*hashCount + hashCount - 1) / hashCount * hashCount
+	r := &Sketch{cells: make([]cell, n)}
+	for e := range s {
+		r.Insert(e)
+	}
+	return r
+}
+
+// Insert adds the given elements to the sketch.
+// Inserting an element that has already been inserted breaks decoding.
+// Insert panics if s was not created by NewSketch or UnmarshalBinary.
+//
+// Time complexity: O(len(v)). Space complexity: O(1).
+func (s *Sketch) Insert(v ...uint64) {
+	for _, e := range v {
+		s.update(e, 1)
+	}
+}
+
+// Delete removes the given previously inserted elements from the sketch.
+// Delete panics if s was not created by NewSketch or UnmarshalBinary.
+//
+// Time complexity: O(len(v)). Space complexity: O(1).
+func (s *Sketch) Delete(v ...uint64) {
+	for _, e := range v {
+		s.update(e, -1)
+	}
+}
+
+// Subtract returns a new sketch of the difference between the sets summarized by s and other,
+// which can be decoded with Decode. If the sketches have different sizes, the error is ErrMismatch.
+//
+// Time complexity: O(capacity). Space complexity: O(capacity).
+func (s *Sketch) Subtract(other *Sketch) (*Sketch, error) {
+	if len(s.cells) != len(other.cells) {
+		return nil, ErrMismatch
+	}
+	r := &Sketch{cells: make([]cell, len(s.cells))}
+	for i, c := range s.cells {
+		o := other.cells[i]
+		r.cells[i] = cell{count: c.count - o.count, keySum: c.keySum ^ o.keySum, hashSum: c.hashSum ^ o.hashSum}
+	}
+	return r, nil
+}
+
+// Decode lists the elements of a sketch returned by Subtract: those that were only inserted into
+// the receiver of Subtract and those that were only inserted into its argument.
+// If the difference is too large to be decoded, the error is ErrCapacityExceeded.
+// Decode does not modify s.
+//
+// Time complexity: O(capacity). Space complexity: O(capacity).
+func (s *Sketch) Decode() (onlyLocal, onlyRemote sets.Set[uint64], err error) {
+	cells := append([]cell(nil), s.cells...)
+	onlyLocal, onlyRemote = sets.New[uint64](0), sets.New[uint64](0)
+	pure := make([]int, 0, len(cells))
+	for i := range cells {
+		pure = append(pure, i)
+	}
+	for len(pure) > 0 {
+		i := pure[len(pure)-1]
+		pure = pure[:len(pure)-1]
+		c := cells[i]
+		idx := s.indexes(c.keySum)
+		if (c.count != 1 && c.count != -1) || c.hashSum != checksum(c.keySum) || idx[i/(len(cells)/hashCount)] != i {
+			continue
+		}
+		found := onlyLocal
+		if c.count < 0 {
+			found = onlyRemote
+		}
+		// Each element of a consistent sketch is found once and empties a cell for good,
+		// so there cannot be more elements than cells.
+		if sets.Contains(found, c.keySum) || len(onlyLocal)+len(onlyRemote) == len(cells) {
+			return nil, nil, ErrCapacityExceeded
+		}
+		sets.Insert(found, c.keySum)
+		for _, j := range idx {
+			cells[j].count -= c.count
+			cells[j].keySum ^= c.keySum
+			cells[j].hashSum ^= c.hashSum
+			pure = append(pure, j)
+		}
+	}
+	for _, c := range cells {
+		if c != (cell{}) {
+			return nil, nil, ErrCapacityExceeded
+		}
+	}
+	return onlyLocal, onlyRemote, nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// The encoding is the number of cells as a uvarint followed by the cells.
+func (s *Sketch) MarshalBinary() ([]byte, error) {
+	data := binary.AppendUvarint(make([]byte, 0, binary.MaxVarintLen64+len(s.cells)*cellSize), uint64(len(s.cells)))
+	for _, c := range s.cells {
+		data = binary.LittleEndian.AppendUint64(data, uint64(c.count))
+		data = binary.LittleEndian.AppendUint64(data, c.keySum)
+		data = binary.LittleEndian.AppendUint64(data, c.hashSum)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. It replaces the content of s.
+func (s *Sketch) UnmarshalBinary(data []byte) error {
+	n, size := binary.Uvarint(data)
+	if size <= 0 || n == 0 || n%hashCount != 0 || n > maxCells {
+		return errors.New("reconcile: invalid sketch size")
+	}
+	data = data[size:]
+	if uint64(len(data)) != n*cellSize {
+		return fmt.Errorf("reconcile: invalid sketch length %d for %d cells", len(data), n)
+	}
+	cells := make([]cell, n)
+	for i := range cells {
+		c := data[i*cellSize:]
+		cells[i] = cell{
+			count:   int64(binary.LittleEndian.Uint64(c)),
+			keySum:  binary.LittleEndian.Uint64(c[8:]),
+			hashSum: binary.LittleEndian.Uint64(c[16:]),
+		}
+	}
+	s.cells = cells
+	return nil
+}
+
+func (s *Sketch) update(e uint64, delta int64) {
+	if len(s.cells) == 0 {
+		panic("sketch is not initialized")
+	}
+	h := checksum(e)
+	for _, i := range s.indexes(e) {
+		c := &s.cells[i]
+		c.count += delta
+		c.keySum ^= e
+		c.hashSum ^= h
+	}
+}
+
+// indexes returns the cells of e, one in each subtable.
+func (s *Sketch) indexes(e uint64) [hashCount]int {
+	m := uint64(len(s.cells) / hashCount)
+	var r [hashCount]int
+	for i := range r {
+		r[i] = i*int(m) + int(mix(e+uint64(i+1)*0x9e3779b97f4a7c15)%m)
+	}
+	return r
+}
+
+// checksum returns a hash of e independent of the cell indexes, which identifies pure cells.
+func checksum(e uint64) uint64 {
+	return mix(e ^ 0xc2b2ae3d27d4eb4f)
+}
+
+// mix is the finalizer of SplitMix64. It is fixed so that sketches built by different processes agree.
+func mix(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
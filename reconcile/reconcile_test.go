@@ -0,0 +1,359 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package reconcile
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/kkhmel/sets"
+)
+
+// randomSets returns two sets sharing common elements, with onlyA and onlyB elements of their own.
+func randomSets(rng *rand.Rand, common, onlyA, onlyB int) (a, b sets.Set[uint64]) {
+	a, b = sets.New[uint64](common+onlyA), sets.New[uint64](common+onlyB)
+	for len(a) < common {
+		e := rng.Uint64()
+		sets.Insert(a, e)
+		sets.Insert(b, e)
+	}
+	for len(a) < common+onlyA {
+		sets.Insert(a, rng.Uint64())
+	}
+	for len(b) < common+onlyB {
+		if e := rng.Uint64(); !sets.Contains(a, e) {
+			sets.Insert(b, e)
+		}
+	}
+	return a, b
+}
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name                 string
+		capacity             int
+		common, onlyA, onlyB int
+	}{
+		{name: "equal sets", capacity: 10, common: 1000},
+		{name: "empty sets", capacity: 1},
+		{name: "one side", capacity: 10, common: 500, onlyA: 10},
+		{name: "other side", capacity: 10, common: 500, onlyB: 10},
+		{name: "both sides", capacity: 50, common: 5000, onlyA: 20, onlyB: 30},
+		{name: "disjoint", capacity: 300, onlyA: 150, onlyB: 150},
+		{name: "large", capacity: 2000, common: 20000, onlyA: 900, onlyB: 1100},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rng := rand.New(rand.NewPCG(1, uint64(tt.capacity)))
+			a, b := randomSets(rng, tt.common, tt.onlyA, tt.onlyB)
+			diff, err := NewSketch(tt.capacity, a).Subtract(NewSketch(tt.capacity, b))
+			if err != nil {
+				t.Fatal(err)
+			}
+			onlyA, onlyB, err := diff.Decode()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := sets.Difference(a, b); !sets.Equal(onlyA, want) {
+				t.Errorf("\nwant: %v\ngot : %v", want, onlyA)
+			}
+			if want := sets.Difference(b, a); !sets.Equal(onlyB, want) {
+				t.Errorf("\nwant: %v\ngot : %v", want, onlyB)
+			}
+		})
+	}
+}
+
+func TestDecodeSuccessRate(t *testing.T) {
+	// Differences up to the capacity are decoded with high probability.
+	failures := 0
+	for seed := range uint64(500) {
+		rng := rand.New(rand.NewPCG(seed, 2))
+		capacity := 1 + rng.IntN(100)
+		a, b := randomSets(rng, 100, rng.IntN(capacity+1), 0)
+		sets.Insert(b, sets.ToSlice(randomSetOf(rng, capacity-(len(a)-100)))...)
+		diff, _ := NewSketch(capacity, a).Subtract(NewSketch(capacity, b))
+		if _, _, err := diff.Decode(); err != nil {
+			failures++
+		}
+	}
+	if failures > 5 {
+		t.Errorf("%d of 500 differences up to the capacity could not be decoded", failures)
+	}
+}
+
+func randomSetOf(rng *rand.Rand, n int) sets.Set[uint64] {
+	s := sets.New[uint64](n)
+	for len(s) < n {
+		sets.Insert(s, rng.Uint64())
+	}
+	return s
+}
+
+func TestCapacityExceeded(t *testing.T) {
+	rng := rand.New(rand.NewPCG(3, 4))
+	a, b := randomSets(rng, 100, 200, 200)
+	diff, err := NewSketch(20, a).Subtract(NewSketch(20, b))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := diff.Decode(); !errors.Is(err, ErrCapacityExceeded) {
+		t.Errorf("\nwant: %v\ngot : %v", ErrCapacityExceeded, err)
+	}
+}
+
+func TestDecodeInconsistent(t *testing.T) {
+	// A sketch whose cells do not result from inserting and deleting elements,
+	// e.g. because it was corrupted, must not make Decode loop or return wrong elements.
+	s := NewSketch(1, nil)
+	const k = 42
+	idx := s.indexes(k)
+	s.cells[idx[0]] = cell{count: 1, keySum: k, hashSum: checksum(k)}
+	s.cells[idx[1]] = cell{count: 1, keySum: k, hashSum: checksum(k)}
+	if _, _, err := s.Decode(); !errors.Is(err, ErrCapacityExceeded) {
+		t.Errorf("\nwant: %v\ngot : %v", ErrCapacityExceeded, err)
+	}
+
+	// A cell that looks pure but is not one of the cells of its element is ignored.
+	s = NewSketch(1, nil)
+	i := 0
+	if idx := s.indexes(k); idx[0] == 0 {
+		i = 1
+	}
+	s.cells[i] = cell{count: 1, keySum: k, hashSum: checksum(k)}
+	if _, _, err := s.Decode(); !errors.Is(err, ErrCapacityExceeded) {
+		t.Errorf("\nwant: %v\ngot : %v", ErrCapacityExceeded, err)
+	}
+}
+
+func TestInsertDelete(t *testing.T) {
+	s := NewSketch(5, sets.From[uint64](1, 2))
+	s.Insert(3, 4)
+	s.Delete(1, 4)
+	diff, err := s.Subtract(NewSketch(5, sets.From[uint64](2, 3)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a, b, err := diff.Decode(); err != nil || len(a) != 0 || len(b) != 0 {
+		t.Errorf("Decode() = %v, %v, %v", a, b, err)
+	}
+}
+
+func TestSubtractMismatch(t *testing.T) {
+	if _, err := NewSketch(10, nil).Subtract(NewSketch(100, nil)); !errors.Is(err, ErrMismatch) {
+		t.Errorf("\nwant: %v\ngot : %v", ErrMismatch, err)
+	}
+}
+
+func TestNewSketchPanics(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("NewSketch() did not panic")
+		}
+	}()
+	NewSketch(0, nil)
+}
+
+func TestZeroSketchPanics(t *testing.T) {
+	for name, f := range map[string]func(s *Sketch){
+		"Insert": func(s *Sketch) { s.Insert(1) },
+		"Delete": func(s *Sketch) { s.Delete(1) },
+	} {
+		func() {
+			defer func() {
+				if r := recover(); r != "sketch is not initialized" {
+					t.Errorf("%s() on a zero Sketch: want panic %q, got %v", name, "sketch is not initialized", r)
+				}
+			}()
+			f(&Sketch{})
+		}()
+	}
+}
+
+func TestEncoding(t *testing.T) {
+	s := NewSketch(10, sets.From[uint64](1, 2, 3))
+	data, err := s.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got Sketch
+	if err := got.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	diff, err := got.Subtract(NewSketch(10, sets.From[uint64](2, 3, 4)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, b, err := diff.Decode()
+	if err != nil || !sets.Equal(a, sets.From[uint64](1)) || !sets.Equal(b, sets.From[uint64](4)) {
+		t.Errorf("Decode() = %v, %v, %v", a, b, err)
+	}
+
+	invalid := map[string][]byte{
+		"empty":          nil,
+		"zero cells":     {0},
+		"not a multiple": {4},
+		"too many cells": {0x80, 0x80, 0x80, 0x10},
+		"truncated":      data[:len(data)-1],
+		"trailing data":  append(data[:len(data):len(data)], 0),
+		"invalid varint": {0x80},
+	}
+	for name, data := range invalid {
+		if err := got.UnmarshalBinary(data); err == nil {
+			t.Errorf("%s: UnmarshalBinary() accepted invalid input", name)
+		}
+	}
+}
+
+// Message types of the protocol of the peer harness.
+const (
+	msgSketch   byte = iota // a sketch of the sender's set
+	msgRetry                // the sketch could not be decoded
+	msgElements             // elements the receiver is missing
+	msgFullSet              // the sender's whole set
+)
+
+// peer is one side of a reconciliation over an in-process connection.
+type peer struct {
+	set  sets.Set[uint64]
+	in   <-chan []byte
+	out  chan<- []byte
+	sent int // number of bytes sent
+}
+
+func (p *peer) send(typ byte, payload []byte) {
+	p.sent += 1 + len(payload)
+	p.out <- append([]byte{typ}, payload...)
+}
+
+func (p *peer) receive() (byte, []byte) {
+	msg := <-p.in
+	return msg[0], msg[1:]
+}
+
+// initiate sends sketches of growing capacity until the responder can decode the difference,
+// or the whole set once a sketch would not be smaller, and then adds the elements it is missing.
+func (p *peer) initiate(capacity int) {
+	for {
+		if capacity*cellSize*2 >= len(p.set)*8 {
+			p.send(msgFullSet, encodeElements(p.set))
+			break
+		}
+		data, _ := NewSketch(capacity, p.set).MarshalBinary()
+		p.send(msgSketch, append(binary.AppendUvarint(nil, uint64(capacity)), data...))
+		if typ, _ := p.receive(); typ == msgRetry {
+			capacity *= 2
+			continue
+		}
+		break
+	}
+	_, payload := p.receive()
+	sets.Insert(p.set, decodeElements(payload)...)
+}
+
+// respond decodes the difference from the initiator's sketch, sends the elements
+// the initiator is missing and adds the elements it is missing itself.
+func (p *peer) respond(t *testing.T) {
+	for {
+		typ, payload := p.receive()
+		if typ == msgFullSet {
+			remote := sets.FromSlice(decodeElements(payload))
+			p.send(msgElements, encodeElements(sets.Difference(p.set, remote)))
+			sets.Insert(p.set, sets.ToSlice(remote)...)
+			return
+		}
+		capacity, n := binary.Uvarint(payload)
+		var remote Sketch
+		if err := remote.UnmarshalBinary(payload[n:]); err != nil {
+			t.Error(err)
+			return
+		}
+		diff, err := NewSketch(int(capacity), p.set).Subtract(&remote)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		onlyLocal, onlyRemote, err := diff.Decode()
+		if errors.Is(err, ErrCapacityExceeded) {
+			p.send(msgRetry, nil)
+			continue
+		}
+		p.send(msgElements, nil) // acknowledges the sketch
+		p.send(msgElements, encodeElements(onlyLocal))
+		sets.Insert(p.set, sets.ToSlice(onlyRemote)...)
+		return
+	}
+}
+
+func encodeElements(s sets.Set[uint64]) []byte {
+	data := make([]byte, 0, len(s)*8)
+	for e := range s {
+		data = binary.LittleEndian.AppendUint64(data, e)
+	}
+	return data
+}
+
+func decodeElements(data []byte) []uint64 {
+	r := make([]uint64, 0, len(data)/8)
+	for ; len(data) >= 8; data = data[8:] {
+		r = append(r, binary.LittleEndian.Uint64(data))
+	}
+	return r
+}
+
+func TestPeers(t *testing.T) {
+	tests := []struct {
+		name                 string
+		common, onlyA, onlyB int
+		maxBytes             int
+	}{
+		{name: "small difference", common: 20000, onlyA: 40, onlyB: 60, maxBytes: 12000},
+		{name: "growing capacity", common: 50000, onlyA: 500, onlyB: 700, maxBytes: 100000},
+		{name: "equal sets", common: 20000, maxBytes: 3000},
+		{name: "full set fallback", common: 100, onlyA: 300, onlyB: 300, maxBytes: 16000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := randomSets(rand.New(rand.NewPCG(5, 6)), tt.common, tt.onlyA, tt.onlyB)
+			want := sets.Union(a, b)
+			toA, toB := make(chan []byte, 1), make(chan []byte, 1)
+			initiator := &peer{set: a, in: toA, out: toB}
+			responder := &peer{set: b, in: toB, out: toA}
+
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				responder.respond(t)
+			}()
+			initiator.initiate(16)
+			<-done
+
+			if !sets.Equal(a, want) || !sets.Equal(b, want) {
+				t.Errorf("peers did not converge: %d and %d elements, want %d", len(a), len(b), len(want))
+			}
+			if sent := initiator.sent + responder.sent; sent > tt.maxBytes {
+				t.Errorf("transferred %d bytes, want at most %d", sent, tt.maxBytes)
+			}
+		})
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package setexpr parses and evaluates set expressions over named sets of strings,
+// such as "(premium | trial) & active - banned".
+//
+// The operators, from lowest to highest precedence:
+//
+//	a | b, a - b, a ^ b   union, difference and symmetric difference, left-associative
+//	a & b                 intersection, left-associative
+//	!a                    complement relative to the universe
+//
+// Operands are set names made of letters, digits, '_' and '.', parenthesized expressions,
+// and set literals such as {a, b, "with spaces"}, whose elements are names or Go string literals.
+package setexpr
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Pos is a position in the source of an expression.
+type Pos struct {
+	Offset int // byte offset, starting at 0
+	Line   int // line number, starting at 1
+	Column int // column number in runes, starting at 1
+}
+
+// String returns the position in the format "line:column".
+func (p Pos) String() string {
+	return fmt.Sprintf("%d:%d", p.Line, p.Column)
+}
+
+// Expr is a node of a parsed expression: *Name, *Literal, *Complement or *Binary.
+type Expr interface {
+	// Position returns the position of the first character of the node.
+	Position() Pos
+	// String returns the expression in canonical form with minimal parentheses.
+	String() string
+}
+
+// Name refers to a named set.
+type Name struct {
+	Pos  Pos
+	Name string
+}
+
+// Literal is a set given by its elements.
+type Literal struct {
+	Pos      Pos
+	Elements []string
+}
+
+// Complement is the complement of X relative to the universe.
+type Complement struct {
+	Pos Pos
+	X   Expr
+}
+
+// Op is a binary set operator.
+type Op byte
+
+// Binary operators.
+const (
+	Union               Op = '|'
+	Difference          Op = '-'
+	SymmetricDifference Op = '^'
+	Intersection        Op = '&'
+)
+
+// String returns the operator symbol.
+func (op Op) String() string {
+	return string(op)
+}
+
+// precedence returns the binding strength of op. Higher binds tighter.
+func (op Op) precedence() int {
+	if op == Intersection {
+		return 2
+	}
+	return 1
+}
+
+// Binary is a binary operation X Op Y.
+type Binary struct {
+	OpPos Pos // position of the operator
+	Op    Op
+	X, Y  Expr
+}
+
+func (e *Name) Position() Pos       { return e.Pos }
+func (e *Literal) Position() Pos    { return e.Pos }
+func (e *Complement) Position() Pos { return e.Pos }
+func (e *Binary) Position() Pos     { return e.X.Position() }
+
+func (e *Name) String() string {
+	return e.Name
+}
+
+func (e *Literal) String() string {
+	elements := make([]string, len(e.Elements))
+	for i, el := range e.Elements {
+		if isName(el) {
+			elements[i] = el
+		} else {
+			elements[i] = strconv.Quote(el)
+		}
+	}
+	return "{" + strings.Join(elements, ", ") + "}"
+}
+
+func (e *Complement) String() string {
+	if _, ok := e.X.(*Binary); ok {
+		return "!(" + e.X.String() + ")"
+	}
+	return "!" + e.X.String()
+}
+
+func (e *Binary) String() string {
+	x, y := e.X.String(), e.Y.String()
+	if b, ok := e.X.(*Binary); ok && b.Op.precedence() < e.Op.precedence() {
+		x = "(" + x + ")"
+	}
+	if b, ok := e.Y.(*Binary); ok && b.Op.precedence() <= e.Op.precedence() {
+		y = "(" + y + ")"
+	}
+	return x + " " + e.Op.String() + " " + y
+}
+
+// isName reports whether s can be written as a bare name.
+func isName(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if !isNameRune(r) {
+			return false
+		}
+	}
+	return true
+}
+
+func isNameRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '.'
+}
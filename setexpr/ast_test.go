@@ -0,0 +1,85 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package setexpr
+
+import "testing"
+
+func TestExprString(t *testing.T) {
+	tests := []struct {
+		src  string
+		want string
+	}{
+		{src: "a", want: "a"},
+		{src: "(premium|trial)&active-banned", want: "(premium | trial) & active - banned"},
+		{src: "a | (b | c)", want: "a | (b | c)"},
+		{src: "(a | b) | c", want: "a | b | c"},
+		{src: "a - (b & c)", want: "a - b & c"},
+		{src: "(a & b) & (c ^ d)", want: "a & b & (c ^ d)"},
+		{src: "!a & !(b | c) & !!{}", want: "!a & !(b | c) & !!{}"},
+		{src: `{x, "two words", "", ` + "`raw`" + `}`, want: `{x, "two words", "", raw}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.src, func(t *testing.T) {
+			e, err := Parse(tt.src)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := e.String(); got != tt.want {
+				t.Errorf("\nwant: %v\ngot : %v", tt.want, got)
+			}
+			// The canonical form parses to the same expression.
+			again, err := Parse(e.String())
+			if err != nil || again.String() != tt.want {
+				t.Errorf("canonical form does not round-trip: %v, %v", again, err)
+			}
+		})
+	}
+}
+
+func TestExprPosition(t *testing.T) {
+	e, err := Parse("a |\n  !{x} & (b)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	union := e.(*Binary)
+	inter := union.Y.(*Binary)
+	tests := []struct {
+		name string
+		got  Pos
+		want Pos
+	}{
+		{name: "binary", got: union.Position(), want: Pos{Offset: 0, Line: 1, Column: 1}},
+		{name: "operator", got: union.OpPos, want: Pos{Offset: 2, Line: 1, Column: 3}},
+		{name: "complement", got: inter.X.Position(), want: Pos{Offset: 6, Line: 2, Column: 3}},
+		{name: "literal", got: inter.X.(*Complement).X.Position(), want: Pos{Offset: 7, Line: 2, Column: 4}},
+		{name: "name", got: inter.Y.Position(), want: Pos{Offset: 14, Line: 2, Column: 11}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("\nwant: %+v\ngot : %+v", tt.want, tt.got)
+			}
+		})
+	}
+	if got, want := (Pos{Line: 3, Column: 14}).String(), "3:14"; got != want {
+		t.Errorf("\nwant: %v\ngot : %v", want, got)
+	}
+}
@@ -0,0 +1,192 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package setexpr
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/kkhmel/sets"
+)
+
+// Env holds the sets an expression is evaluated against.
+type Env struct {
+	// Sets maps set names to their elements.
+	Sets map[string]sets.Set[string]
+	// Universe is the set complements are taken relative to.
+	// If nil, the union of all named sets is used.
+	Universe sets.Set[string]
+}
+
+// UndefinedError reports a set name that is not defined in the environment.
+type UndefinedError struct {
+	Pos  Pos
+	Name string
+}
+
+// Error returns the error message prefixed with the position, e.g. `1:7: undefined set "x"`.
+func (e *UndefinedError) Error() string {
+	return fmt.Sprintf("%v: undefined set %q", e.Pos, e.Name)
+}
+
+// Evaluate parses src and evaluates it against env.
+func Evaluate(src string, env Env) (sets.Set[string], error) {
+	e, err := Parse(src)
+	if err != nil {
+		return nil, err
+	}
+	return Eval(e, env)
+}
+
+// Eval evaluates e against env and returns a new set.
+// If e refers to a set that is not defined in env, the error is an *UndefinedError.
+//
+// Operands are evaluated in an order chosen by their estimated sizes: chains of intersections
+// start with the smallest operand, and evaluation stops early once an intersection or the
+// minuend of a difference is empty.
+func Eval(e Expr, env Env) (sets.Set[string], error) {
+	if err := resolve(e, env); err != nil {
+		return nil, err
+	}
+	ev := &evaluator{env: env}
+	r := ev.eval(e)
+	if _, ok := e.(*Name); ok {
+		// Do not return a set of the environment, which the caller may modify.
+		r = sets.Clone(r)
+	}
+	return r, nil
+}
+
+// resolve returns an error for the first undefined set name in e.
+func resolve(e Expr, env Env) error {
+	switch e := e.(type) {
+	case *Name:
+		if _, ok := env.Sets[e.Name]; !ok {
+			return &UndefinedError{Pos: e.Pos, Name: e.Name}
+		}
+	case *Complement:
+		return resolve(e.X, env)
+	case *Binary:
+		if err := resolve(e.X, env); err != nil {
+			return err
+		}
+		return resolve(e.Y, env)
+	}
+	return nil
+}
+
+type evaluator struct {
+	env      Env
+	universe sets.Set[string] // computed on first use
+}
+
+// eval returns the value of e. The result may be a set of the environment and must not be modified.
+func (ev *evaluator) eval(e Expr) sets.Set[string] {
+	switch e := e.(type) {
+	case *Name:
+		return ev.env.Sets[e.Name]
+	case *Literal:
+		return sets.FromSlice(e.Elements)
+	case *Complement:
+		return sets.Difference(ev.universeSet(), ev.eval(e.X))
+	}
+
+	b := e.(*Binary)
+	switch b.Op {
+	case Union:
+		operands := flatten(b, Union)
+		values := make([]sets.Set[string], len(operands))
+		for i, o := range operands {
+			values[i] = ev.eval(o)
+		}
+		return sets.Union(values...)
+	case Intersection:
+		operands := ev.bySize(flatten(b, Intersection))
+		r := ev.eval(operands[0])
+		for _, o := range operands[1:] {
+			if len(r) == 0 {
+				return sets.New[string](0)
+			}
+			r = sets.Intersection(r, ev.eval(o))
+		}
+		return r
+	case Difference:
+		x := ev.eval(b.X)
+		if len(x) == 0 {
+			return sets.New[string](0)
+		}
+		return sets.Difference(x, ev.eval(b.Y))
+	default:
+		return sets.SymmetricDifference(ev.eval(b.X), ev.eval(b.Y))
+	}
+}
+
+// universeSet returns the set complements are taken relative to.
+func (ev *evaluator) universeSet() sets.Set[string] {
+	if ev.universe == nil {
+		ev.universe = ev.env.Universe
+		if ev.universe == nil {
+			ev.universe = sets.Union(slices.Collect(maps.Values(ev.env.Sets))...)
+		}
+	}
+	return ev.universe
+}
+
+// bySize returns operands sorted by their estimated sizes, smallest first.
+func (ev *evaluator) bySize(operands []Expr) []Expr {
+	sizes := make(map[Expr]int, len(operands))
+	for _, o := range operands {
+		sizes[o] = ev.estimate(o)
+	}
+	slices.SortStableFunc(operands, func(a, b Expr) int { return sizes[a] - sizes[b] })
+	return operands
+}
+
+// estimate returns an upper bound of the size of the value of e without evaluating it.
+func (ev *evaluator) estimate(e Expr) int {
+	switch e := e.(type) {
+	case *Name:
+		return len(ev.env.Sets[e.Name])
+	case *Literal:
+		return len(e.Elements)
+	case *Complement:
+		return len(ev.universeSet())
+	}
+	b := e.(*Binary)
+	switch b.Op {
+	case Intersection:
+		return min(ev.estimate(b.X), ev.estimate(b.Y))
+	case Difference:
+		return ev.estimate(b.X)
+	default:
+		return ev.estimate(b.X) + ev.estimate(b.Y)
+	}
+}
+
+// flatten returns the operands of the chain of op operations rooted at e,
+// e.g. a, b and c for (a & b) & c.
+func flatten(e Expr, op Op) []Expr {
+	if b, ok := e.(*Binary); ok && b.Op == op {
+		return append(flatten(b.X, op), flatten(b.Y, op)...)
+	}
+	return []Expr{e}
+}
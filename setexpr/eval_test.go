@@ -0,0 +1,153 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package setexpr
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kkhmel/sets"
+)
+
+func testEnv() Env {
+	return Env{Sets: map[string]sets.Set[string]{
+		"premium": sets.From("ann", "bob"),
+		"trial":   sets.From("cid", "dan"),
+		"active":  sets.From("ann", "cid", "eve"),
+		"banned":  sets.From("cid"),
+		"empty":   sets.New[string](0),
+	}}
+}
+
+func TestEvaluate(t *testing.T) {
+	tests := []struct {
+		name     string
+		src      string
+		universe sets.Set[string]
+		want     sets.Set[string]
+	}{
+		{name: "audience", src: "(premium | trial) & active - banned", want: sets.From("ann")},
+		{name: "name", src: "trial", want: sets.From("cid", "dan")},
+		{name: "union", src: "premium | trial | banned", want: sets.From("ann", "bob", "cid", "dan")},
+		{name: "intersection chain", src: "active & (premium | trial) & !banned", want: sets.From("ann")},
+		{name: "intersection with empty", src: "active & empty & premium", want: sets.New[string](0)},
+		{name: "difference", src: "active - banned - premium", want: sets.From("eve")},
+		{name: "difference of empty", src: "empty - active", want: sets.New[string](0)},
+		{name: "symmetric difference", src: "premium ^ active", want: sets.From("bob", "cid", "eve")},
+		{name: "complement in union of sets", src: "!active", want: sets.From("bob", "dan")},
+		{name: "complement in universe", src: "!(active | premium)", universe: sets.From("ann", "zed"), want: sets.From("zed")},
+		{name: "double complement", src: "!!trial", want: sets.From("cid", "dan")},
+		{name: "literal", src: `active & {eve, "ann", nobody}`, want: sets.From("ann", "eve")},
+		{name: "empty literal", src: "{} | {}", want: sets.New[string](0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := testEnv()
+			env.Universe = tt.universe
+			got, err := Evaluate(tt.src, env)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !sets.Equal(got, tt.want) {
+				t.Errorf("\nwant: %v\ngot : %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestEvaluateDoesNotAliasEnv(t *testing.T) {
+	env := testEnv()
+	for _, src := range []string{"trial", "empty & trial", "trial & active"} {
+		got, err := Evaluate(src, env)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sets.Insert(got, "mallory")
+	}
+	for name, s := range env.Sets {
+		if sets.Contains(s, "mallory") {
+			t.Errorf("set %q was modified through a result", name)
+		}
+	}
+}
+
+func TestEvaluateErrors(t *testing.T) {
+	tests := []struct {
+		src  string
+		want string
+	}{
+		{src: "premium &", want: "1:10: unexpected end of expression, expected set name, '(', '{' or '!'"},
+		{src: "premium | gold", want: `1:11: undefined set "gold"`},
+		{src: "gold | premium", want: `1:1: undefined set "gold"`},
+		{src: "!(trial ^ x)", want: `1:11: undefined set "x"`},
+		// Undefined names are reported even where evaluation would stop early.
+		{src: "empty & (trial - y)", want: `1:18: undefined set "y"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.src, func(t *testing.T) {
+			_, err := Evaluate(tt.src, testEnv())
+			if err == nil || err.Error() != tt.want {
+				t.Errorf("\nwant: %v\ngot : %v", tt.want, err)
+			}
+		})
+	}
+
+	_, err := Evaluate("gold", testEnv())
+	var undefined *UndefinedError
+	if !errors.As(err, &undefined) || undefined.Name != "gold" {
+		t.Errorf("expected *UndefinedError, got %v", err)
+	}
+}
+
+func TestEvaluationOrder(t *testing.T) {
+	e, err := Parse("!premium & (premium | trial) & active & {a} & (trial - x) & (active ^ trial) & (active & trial)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	env := testEnv()
+	env.Sets["x"] = sets.From("a", "b", "c", "d", "e", "f")
+	ev := &evaluator{env: env}
+	var got []string
+	for _, o := range ev.bySize(flatten(e, Intersection)) {
+		got = append(got, o.String())
+	}
+	// The parenthesized intersection is flattened into the chain. Estimates: {a} 1, trial - x 2, trial 2,
+	// active 3, premium | trial 4, active ^ trial 5, !premium 11 (the size of the universe).
+	// Operands with equal estimates keep their order.
+	want := []string{"{a}", "trial - x", "trial", "active", "active", "premium | trial", "active ^ trial", "!premium"}
+	if len(got) != len(want) {
+		t.Fatalf("\nwant: %v\ngot : %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("\nwant: %v\ngot : %v", want, got)
+			break
+		}
+	}
+
+	nested, err := Parse("(premium & active) | {b}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := ev.estimate(nested); got != 3 {
+		t.Errorf("\nwant: %v\ngot : %v", 3, got)
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package setexpr_test
+
+import (
+	"fmt"
+
+	"github.com/kkhmel/sets"
+	"github.com/kkhmel/sets/setexpr"
+)
+
+func ExampleEvaluate() {
+	env := setexpr.Env{Sets: map[string]sets.Set[string]{
+		"premium": sets.From("ann", "bob"),
+		"trial":   sets.From("cid", "dan"),
+		"active":  sets.From("ann", "cid", "eve"),
+		"banned":  sets.From("cid"),
+	}}
+
+	audience, err := setexpr.Evaluate("(premium | trial) & active - banned", env)
+	fmt.Println(audience, err)
+
+	_, err = setexpr.Evaluate("premium & (trial | ", env)
+	fmt.Println(err)
+
+	// Output:
+	// {ann} <nil>
+	// 1:20: unexpected end of expression, expected set name, '(', '{' or '!'
+}
@@ -0,0 +1,262 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package setexpr
+
+import (
+	"fmt"
+	"strconv"
+	"unicode"
+	"unicode/utf8"
+)
+
+// SyntaxError reports an invalid expression.
+type SyntaxError struct {
+	Pos Pos
+	Msg string
+}
+
+// Error returns the error message prefixed with the position, e.g. "1:7: expected ')'".
+func (e *SyntaxError) Error() string {
+	return e.Pos.String() + ": " + e.Msg
+}
+
+// MaxDepth is the maximum nesting depth of parentheses and complements accepted by Parse.
+const MaxDepth = 1000
+
+// Parse parses a set expression.
+// If the expression is invalid or nested more than MaxDepth levels deep, the error is a *SyntaxError.
+//
+// Time complexity: O(len(src)).
+func Parse(src string) (Expr, error) {
+	p := &parser{src: src, last: Pos{Line: 1, Column: 1}}
+	e, err := p.expr()
+	if err != nil {
+		return nil, err
+	}
+	if p.skipSpace(); p.offset < len(p.src) {
+		return nil, p.unexpected("expected operator")
+	}
+	return e, nil
+}
+
+type parser struct {
+	src    string
+	offset int
+	last   Pos // position of the last call to pos, from which the next one is computed
+	depth  int // number of enclosing parentheses and complements
+}
+
+// expr parses a chain of operators with the lowest precedence.
+func (p *parser) expr() (Expr, error) {
+	x, err := p.term()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		p.skipSpace()
+		op := Op(p.peek())
+		if op != Union && op != Difference && op != SymmetricDifference {
+			return x, nil
+		}
+		pos := p.pos()
+		p.offset++
+		y, err := p.term()
+		if err != nil {
+			return nil, err
+		}
+		x = &Binary{OpPos: pos, Op: op, X: x, Y: y}
+	}
+}
+
+// term parses a chain of intersections.
+func (p *parser) term() (Expr, error) {
+	x, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		p.skipSpace()
+		if Op(p.peek()) != Intersection {
+			return x, nil
+		}
+		pos := p.pos()
+		p.offset++
+		y, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		x = &Binary{OpPos: pos, Op: Intersection, X: x, Y: y}
+	}
+}
+
+func (p *parser) unary() (Expr, error) {
+	p.skipSpace()
+	if p.peek() != '!' {
+		return p.operand()
+	}
+	pos := p.pos()
+	if err := p.enter(pos); err != nil {
+		return nil, err
+	}
+	defer p.leave()
+	p.offset++
+	x, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	return &Complement{Pos: pos, X: x}, nil
+}
+
+func (p *parser) operand() (Expr, error) {
+	pos := p.pos()
+	switch r := p.peek(); {
+	case r == '(':
+		if err := p.enter(pos); err != nil {
+			return nil, err
+		}
+		defer p.leave()
+		p.offset++
+		e, err := p.expr()
+		if err != nil {
+			return nil, err
+		}
+		if p.skipSpace(); p.peek() != ')' {
+			return nil, p.unexpected(fmt.Sprintf("expected ')' to close '(' at %v", pos))
+		}
+		p.offset++
+		return e, nil
+	case r == '{':
+		p.offset++
+		return p.literal(pos)
+	case isNameRune(r):
+		return &Name{Pos: pos, Name: p.name()}, nil
+	default:
+		return nil, p.unexpected("expected set name, '(', '{' or '!'")
+	}
+}
+
+// literal parses the elements of a set literal after the opening brace.
+func (p *parser) literal(pos Pos) (Expr, error) {
+	lit := &Literal{Pos: pos}
+	if p.skipSpace(); p.peek() == '}' {
+		p.offset++
+		return lit, nil
+	}
+	for {
+		p.skipSpace()
+		switch r := p.peek(); {
+		case r == '"' || r == '`':
+			s, err := p.string()
+			if err != nil {
+				return nil, err
+			}
+			lit.Elements = append(lit.Elements, s)
+		case isNameRune(r):
+			lit.Elements = append(lit.Elements, p.name())
+		default:
+			return nil, p.unexpected("expected element")
+		}
+		p.skipSpace()
+		switch p.peek() {
+		case ',':
+			p.offset++
+		case '}':
+			p.offset++
+			return lit, nil
+		default:
+			return nil, p.unexpected("expected ',' or '}'")
+		}
+	}
+}
+
+func (p *parser) name() string {
+	start := p.offset
+	for isNameRune(p.peek()) {
+		_, size := utf8.DecodeRuneInString(p.src[p.offset:])
+		p.offset += size
+	}
+	return p.src[start:p.offset]
+}
+
+func (p *parser) string() (string, error) {
+	quoted, err := strconv.QuotedPrefix(p.src[p.offset:])
+	if err != nil {
+		return "", &SyntaxError{Pos: p.pos(), Msg: "invalid or unterminated string literal"}
+	}
+	p.offset += len(quoted)
+	s, _ := strconv.Unquote(quoted) // QuotedPrefix has validated the literal
+	return s, nil
+}
+
+// peek returns the rune at the current offset, or -1 at the end of the source.
+func (p *parser) peek() rune {
+	if p.offset == len(p.src) {
+		return -1
+	}
+	r, _ := utf8.DecodeRuneInString(p.src[p.offset:])
+	return r
+}
+
+func (p *parser) skipSpace() {
+	for r := p.peek(); unicode.IsSpace(r); r = p.peek() {
+		p.offset += utf8.RuneLen(r)
+	}
+}
+
+// unexpected returns a syntax error describing the token at the current offset.
+func (p *parser) unexpected(expected string) error {
+	found := "end of expression"
+	if r := p.peek(); r >= 0 {
+		found = strconv.QuoteRune(r)
+	}
+	return &SyntaxError{Pos: p.pos(), Msg: fmt.Sprintf("unexpected %s, %s", found, expected)}
+}
+
+// enter increments the nesting depth for a parenthesis or complement at pos,
+// so that deeply nested input fails instead of exhausting the stack.
+func (p *parser) enter(pos Pos) error {
+	if p.depth == MaxDepth {
+		return &SyntaxError{Pos: pos, Msg: fmt.Sprintf("expression is nested more than %d levels deep", MaxDepth)}
+	}
+	p.depth++
+	return nil
+}
+
+func (p *parser) leave() {
+	p.depth--
+}
+
+// pos returns the position of the current offset. The parser only moves forward,
+// so pos continues from the previous position, which keeps parsing linear.
+func (p *parser) pos() Pos {
+	pos := p.last
+	for _, r := range p.src[pos.Offset:p.offset] {
+		if r == '\n' {
+			pos.Line++
+			pos.Column = 1
+		} else {
+			pos.Column++
+		}
+	}
+	pos.Offset = p.offset
+	p.last = pos
+	return pos
+}
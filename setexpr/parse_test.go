@@ -0,0 +1,118 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package setexpr
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	e, err := Parse(" a-b^ c & d | é.1_x ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Operators of the lowest precedence associate to the left, and & binds tighter.
+	union := e.(*Binary)
+	sym := union.X.(*Binary)
+	if union.Op != Union || sym.Op != SymmetricDifference || sym.X.(*Binary).Op != Difference || sym.Y.(*Binary).Op != Intersection {
+		t.Errorf("wrong tree: %v", e)
+	}
+	if got := union.Y.(*Name).Name; got != "é.1_x" {
+		t.Errorf("\nwant: %v\ngot : %v", "é.1_x", got)
+	}
+
+	lit, err := Parse(`{ b , "a\tb" ,c}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := lit.(*Literal).Elements; len(got) != 3 || got[1] != "a\tb" {
+		t.Errorf("wrong elements: %q", got)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		src  string
+		want string
+	}{
+		{src: "", want: "1:1: unexpected end of expression, expected set name, '(', '{' or '!'"},
+		{src: "a b", want: "1:3: unexpected 'b', expected operator"},
+		{src: "a & ", want: "1:5: unexpected end of expression, expected set name, '(', '{' or '!'"},
+		{src: "a | ", want: "1:5: unexpected end of expression, expected set name, '(', '{' or '!'"},
+		{src: "!", want: "1:2: unexpected end of expression, expected set name, '(', '{' or '!'"},
+		{src: "a |\n (b & c", want: "2:8: unexpected end of expression, expected ')' to close '(' at 2:2"},
+		{src: "(a | )", want: "1:6: unexpected ')', expected set name, '(', '{' or '!'"},
+		{src: "a)", want: "1:2: unexpected ')', expected operator"},
+		{src: "{a b}", want: "1:4: unexpected 'b', expected ',' or '}'"},
+		{src: "{a,}", want: "1:4: unexpected '}', expected element"},
+		{src: "{a", want: "1:3: unexpected end of expression, expected ',' or '}'"},
+		{src: `{"x}`, want: "1:2: invalid or unterminated string literal"},
+		{src: `{"\q"}`, want: "1:2: invalid or unterminated string literal"},
+		{src: "ünïcode # x", want: "1:9: unexpected '#', expected operator"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.src, func(t *testing.T) {
+			_, err := Parse(tt.src)
+			var syntaxErr *SyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("expected *SyntaxError, got %v", err)
+			}
+			if got := err.Error(); got != tt.want {
+				t.Errorf("\nwant: %v\ngot : %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestParseDepth(t *testing.T) {
+	nested := func(open, close string, depth int) string {
+		return strings.Repeat(open, depth) + "a" + strings.Repeat(close, depth)
+	}
+	for _, src := range []string{nested("(", ")", MaxDepth), nested("!", "", MaxDepth), nested("(!", ")", MaxDepth/2)} {
+		if _, err := Parse(src); err != nil {
+			t.Errorf("Parse() = %v", err)
+		}
+	}
+	tests := []struct {
+		src  string
+		want string
+	}{
+		{src: nested("(", ")", MaxDepth+1), want: "1:1001: expression is nested more than 1000 levels deep"},
+		{src: nested("!", "", MaxDepth+1), want: "1:1001: expression is nested more than 1000 levels deep"},
+		{src: nested("(\n!", ")", MaxDepth), want: "501:2: expression is nested more than 1000 levels deep"},
+	}
+	for _, tt := range tests {
+		if _, err := Parse(tt.src); err == nil || err.Error() != tt.want {
+			t.Errorf("\nwant: %v\ngot : %v", tt.want, err)
+		}
+	}
+}
+
+func TestParseLongInput(t *testing.T) {
+	// Positions are computed incrementally, so long inputs are parsed in linear time.
+	src := strings.Repeat("a |\n", 100000) + "b c"
+	_, err := Parse(src)
+	if want := "100001:3: unexpected 'c', expected operator"; err == nil || err.Error() != want {
+		t.Errorf("\nwant: %v\ngot : %v", want, err)
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package settest
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/kkhmel/sets"
+)
+
+// maxListed is the maximum number of elements listed in a failure message.
+// The remaining elements are only counted.
+const maxListed = 20
+
+// AssertEqual reports an error listing the missing and unexpected elements if got and want differ.
+// It returns whether the sets are equal.
+func AssertEqual[S1, S2 ~map[E]struct{}, E comparable](t testing.TB, got S1, want S2) bool {
+	t.Helper()
+	missing := sets.Difference(sets.Set[E](want), sets.Set[E](got))
+	unexpected := sets.Difference(sets.Set[E](got), sets.Set[E](want))
+	if len(missing) == 0 && len(unexpected) == 0 {
+		return true
+	}
+	t.Errorf("sets are not equal%s%s", list("missing", missing), list("unexpected", unexpected))
+	return false
+}
+
+// AssertSubset reports an error listing the elements of subset that are missing from superset.
+// It returns whether subset is a subset of superset.
+func AssertSubset[S1, S2 ~map[E]struct{}, E comparable](t testing.TB, subset S1, superset S2) bool {
+	t.Helper()
+	missing := sets.Difference(sets.Set[E](subset), sets.Set[E](superset))
+	if len(missing) == 0 {
+		return true
+	}
+	t.Errorf("set is not a subset%s", list("missing from superset", missing))
+	return false
+}
+
+// AssertContains reports an error listing the elements of v that are missing from s.
+// It returns whether s contains all of v.
+func AssertContains[S ~map[E]struct{}, E comparable](t testing.TB, s S, v ...E) bool {
+	t.Helper()
+	missing := sets.New[E](0)
+	for _, e := range v {
+		if !sets.Contains(s, e) {
+			sets.Insert(missing, e)
+		}
+	}
+	if len(missing) == 0 {
+		return true
+	}
+	t.Errorf("set does not contain all elements%s", list("missing", missing))
+	return false
+}
+
+// AssertDisjoint reports an error listing the elements that s1 and s2 have in common.
+// It returns whether the sets are disjoint.
+func AssertDisjoint[S1, S2 ~map[E]struct{}, E comparable](t testing.TB, s1 S1, s2 S2) bool {
+	t.Helper()
+	common := sets.Intersection(sets.Set[E](s1), sets.Set[E](s2))
+	if len(common) == 0 {
+		return true
+	}
+	t.Errorf("sets are not disjoint%s", list("common", common))
+	return false
+}
+
+// list returns a line with the elements of s sorted by their string representation,
+// or an empty string if s is empty. At most maxListed elements are listed.
+func list[E comparable](label string, s sets.Set[E]) string {
+	if len(s) == 0 {
+		return ""
+	}
+	elements := sets.ToSliceFunc(s, func(e E) string { return fmt.Sprintf("%v", e) })
+	sort.Strings(elements)
+	more := ""
+	if len(elements) > maxListed {
+		more = fmt.Sprintf(", ... (%d more)", len(elements)-maxListed)
+		elements = elements[:maxListed]
+	}
+	return fmt.Sprintf("\n%s (%d): [%s%s]", label, len(s), strings.Join(elements, ", "), more)
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package settest
+
+import (
+	"testing"
+
+	"github.com/kkhmel/sets"
+)
+
+type names map[string]struct{}
+
+func TestAssert(t *testing.T) {
+	large := sets.New[int](0)
+	for i := range 25 {
+		sets.Insert(large, i+100)
+	}
+	tests := []struct {
+		name   string
+		assert func(t testing.TB) bool
+		want   string
+	}{
+		{
+			name:   "equal",
+			assert: func(t testing.TB) bool { return AssertEqual(t, sets.From("a", "b"), names{"b": {}, "a": {}}) },
+		},
+		{
+			name:   "not equal",
+			assert: func(t testing.TB) bool { return AssertEqual(t, sets.From("a", "c", "d"), names{"b": {}, "a": {}}) },
+			want:   "sets are not equal\nmissing (1): [b]\nunexpected (2): [c, d]",
+		},
+		{
+			name:   "only missing",
+			assert: func(t testing.TB) bool { return AssertEqual(t, sets.From(1), sets.From(3, 1, 2)) },
+			want:   "sets are not equal\nmissing (2): [2, 3]",
+		},
+		{
+			name:   "truncated",
+			assert: func(t testing.TB) bool { return AssertEqual(t, large, sets.New[int](0)) },
+			want: "sets are not equal\nunexpected (25): [100, 101, 102, 103, 104, 105, 106, 107, 108, 109, " +
+				"110, 111, 112, 113, 114, 115, 116, 117, 118, 119, ... (5 more)]",
+		},
+		{
+			name:   "subset",
+			assert: func(t testing.TB) bool { return AssertSubset(t, names{"a": {}}, sets.From("a", "b")) },
+		},
+		{
+			name:   "not subset",
+			assert: func(t testing.TB) bool { return AssertSubset(t, sets.From("z", "a", "y"), names{"a": {}}) },
+			want:   "set is not a subset\nmissing from superset (2): [y, z]",
+		},
+		{
+			name:   "contains",
+			assert: func(t testing.TB) bool { return AssertContains(t, sets.From(1, 2, 3), 3, 1) },
+		},
+		{
+			name:   "does not contain",
+			assert: func(t testing.TB) bool { return AssertContains(t, sets.From(1, 2, 3), 5, 1, 4, 5) },
+			want:   "set does not contain all elements\nmissing (2): [4, 5]",
+		},
+		{
+			name:   "disjoint",
+			assert: func(t testing.TB) bool { return AssertDisjoint(t, sets.From(1, 2), sets.From(3)) },
+		},
+		{
+			name:   "not disjoint",
+			assert: func(t testing.TB) bool { return AssertDisjoint(t, sets.From(1, 2, 3), sets.From(3, 2, 4)) },
+			want:   "sets are not disjoint\ncommon (2): [2, 3]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &recorder{TB: t}
+			ok := tt.assert(r)
+			if ok != (tt.want == "") {
+				t.Errorf("returned %v", ok)
+			}
+			got := ""
+			if len(r.errors) > 0 {
+				got = r.errors[0]
+			}
+			if len(r.errors) > 1 || got != tt.want {
+				t.Errorf("\nwant: %q\ngot : %q", tt.want, r.errors)
+			}
+		})
+	}
+}
@@ -0,0 +1,235 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package settest
+
+import "github.com/kkhmel/sets"
+
+// checks returns all checks: the laws of set algebra followed by the comparisons with the reference functions.
+func checks[S sets.ReadOnly[E], E comparable]() []check[S, E] {
+	return append(laws[S, E](), references[S, E]()...)
+}
+
+func laws[S sets.ReadOnly[E], E comparable]() []check[S, E] {
+	hasSymmetricDifference := func(impl Impl[S, E]) bool { return impl.SymmetricDifference != nil }
+	hasSubset := func(impl Impl[S, E]) bool { return impl.Subset != nil }
+	return []check[S, E]{
+		{
+			name: "union is commutative", arity: 2,
+			holds: func(x env[S, E], in inputs) bool {
+				a, b := x.set(in[0]), x.set(in[1])
+				return x.same(x.impl.Union(a, b), x.impl.Union(b, a))
+			},
+		},
+		{
+			name: "intersection is commutative", arity: 2,
+			holds: func(x env[S, E], in inputs) bool {
+				a, b := x.set(in[0]), x.set(in[1])
+				return x.same(x.impl.Intersection(a, b), x.impl.Intersection(b, a))
+			},
+		},
+		{
+			name: "symmetric difference is commutative", arity: 2, needs: hasSymmetricDifference,
+			holds: func(x env[S, E], in inputs) bool {
+				a, b := x.set(in[0]), x.set(in[1])
+				return x.same(x.impl.SymmetricDifference(a, b), x.impl.SymmetricDifference(b, a))
+			},
+		},
+		{
+			name: "union is associative", arity: 3,
+			holds: func(x env[S, E], in inputs) bool {
+				a, b, c := x.set(in[0]), x.set(in[1]), x.set(in[2])
+				u := x.impl.Union
+				return x.same(u(u(a, b), c), u(a, u(b, c)))
+			},
+		},
+		{
+			name: "intersection is associative", arity: 3,
+			holds: func(x env[S, E], in inputs) bool {
+				a, b, c := x.set(in[0]), x.set(in[1]), x.set(in[2])
+				i := x.impl.Intersection
+				return x.same(i(i(a, b), c), i(a, i(b, c)))
+			},
+		},
+		{
+			name: "union distributes over intersection", arity: 3,
+			holds: func(x env[S, E], in inputs) bool {
+				a, b, c := x.set(in[0]), x.set(in[1]), x.set(in[2])
+				u, i := x.impl.Union, x.impl.Intersection
+				return x.same(u(a, i(b, c)), i(u(a, b), u(a, c)))
+			},
+		},
+		{
+			name: "intersection distributes over union", arity: 3,
+			holds: func(x env[S, E], in inputs) bool {
+				a, b, c := x.set(in[0]), x.set(in[1]), x.set(in[2])
+				u, i := x.impl.Union, x.impl.Intersection
+				return x.same(i(a, u(b, c)), u(i(a, b), i(a, c)))
+			},
+		},
+		{
+			name: "De Morgan for union", arity: 3,
+			holds: func(x env[S, E], in inputs) bool {
+				a, b, c := x.set(in[0]), x.set(in[1]), x.set(in[2])
+				u, i, d := x.impl.Union, x.impl.Intersection, x.impl.Difference
+				return x.same(d(a, u(b, c)), i(d(a, b), d(a, c)))
+			},
+		},
+		{
+			name: "De Morgan for intersection", arity: 3,
+			holds: func(x env[S, E], in inputs) bool {
+				a, b, c := x.set(in[0]), x.set(in[1]), x.set(in[2])
+				u, i, d := x.impl.Union, x.impl.Intersection, x.impl.Difference
+				return x.same(d(a, i(b, c)), u(d(a, b), d(a, c)))
+			},
+		},
+		{
+			name: "union is idempotent", arity: 1,
+			holds: func(x env[S, E], in inputs) bool {
+				a := x.set(in[0])
+				return x.same(x.impl.Union(a, a), a)
+			},
+		},
+		{
+			name: "intersection is idempotent", arity: 1,
+			holds: func(x env[S, E], in inputs) bool {
+				a := x.set(in[0])
+				return x.same(x.impl.Intersection(a, a), a)
+			},
+		},
+		{
+			name: "union absorbs intersection", arity: 2,
+			holds: func(x env[S, E], in inputs) bool {
+				a, b := x.set(in[0]), x.set(in[1])
+				return x.same(x.impl.Union(a, x.impl.Intersection(a, b)), a)
+			},
+		},
+		{
+			name: "intersection absorbs union", arity: 2,
+			holds: func(x env[S, E], in inputs) bool {
+				a, b := x.set(in[0]), x.set(in[1])
+				return x.same(x.impl.Intersection(a, x.impl.Union(a, b)), a)
+			},
+		},
+		{
+			name: "difference of a set with itself is empty", arity: 1,
+			holds: func(x env[S, E], in inputs) bool {
+				a := x.set(in[0])
+				return x.impl.Difference(a, a).Len() == 0
+			},
+		},
+		{
+			name: "symmetric difference is the union of differences", arity: 2, needs: hasSymmetricDifference,
+			holds: func(x env[S, E], in inputs) bool {
+				a, b := x.set(in[0]), x.set(in[1])
+				d := x.impl.Difference
+				return x.same(x.impl.SymmetricDifference(a, b), x.impl.Union(d(a, b), d(b, a)))
+			},
+		},
+		{
+			name: "equal is mutual subset", arity: 2,
+			needs: func(impl Impl[S, E]) bool { return impl.Equal != nil && impl.Subset != nil },
+			holds: func(x env[S, E], in inputs) bool {
+				a, b := x.set(in[0]), x.set(in[1])
+				return x.impl.Equal(a, b) == (x.impl.Subset(a, b) && x.impl.Subset(b, a))
+			},
+		},
+		{
+			name: "operands are subsets of their union", arity: 2, needs: hasSubset,
+			holds: func(x env[S, E], in inputs) bool {
+				a, b := x.set(in[0]), x.set(in[1])
+				u := x.impl.Union(a, b)
+				return x.impl.Subset(a, u) && x.impl.Subset(b, u)
+			},
+		},
+		{
+			name: "intersection is a subset of its operands", arity: 2, needs: hasSubset,
+			holds: func(x env[S, E], in inputs) bool {
+				a, b := x.set(in[0]), x.set(in[1])
+				i := x.impl.Intersection(a, b)
+				return x.impl.Subset(i, a) && x.impl.Subset(i, b)
+			},
+		},
+	}
+}
+
+func references[S sets.ReadOnly[E], E comparable]() []check[S, E] {
+	return []check[S, E]{
+		{
+			name: "new matches reference", arity: 1,
+			holds: func(x env[S, E], in inputs) bool {
+				return x.matches(x.set(in[0]), x.ref(in[0]))
+			},
+		},
+		{
+			name: "union matches reference", arity: 2,
+			holds: func(x env[S, E], in inputs) bool {
+				return x.matches(x.impl.Union(x.set(in[0]), x.set(in[1])), sets.Union(x.ref(in[0]), x.ref(in[1])))
+			},
+		},
+		{
+			name: "intersection matches reference", arity: 2,
+			holds: func(x env[S, E], in inputs) bool {
+				return x.matches(x.impl.Intersection(x.set(in[0]), x.set(in[1])), sets.Intersection(x.ref(in[0]), x.ref(in[1])))
+			},
+		},
+		{
+			name: "difference matches reference", arity: 2,
+			holds: func(x env[S, E], in inputs) bool {
+				return x.matches(x.impl.Difference(x.set(in[0]), x.set(in[1])), sets.Difference(x.ref(in[0]), x.ref(in[1])))
+			},
+		},
+		{
+			name: "symmetric difference matches reference", arity: 2,
+			needs: func(impl Impl[S, E]) bool { return impl.SymmetricDifference != nil },
+			holds: func(x env[S, E], in inputs) bool {
+				got := x.impl.SymmetricDifference(x.set(in[0]), x.set(in[1]))
+				return x.matches(got, sets.SymmetricDifference(x.ref(in[0]), x.ref(in[1])))
+			},
+		},
+		{
+			name: "equal matches reference", arity: 2,
+			needs: func(impl Impl[S, E]) bool { return impl.Equal != nil },
+			holds: func(x env[S, E], in inputs) bool {
+				return x.impl.Equal(x.set(in[0]), x.set(in[1])) == sets.Equal(x.ref(in[0]), x.ref(in[1]))
+			},
+		},
+		{
+			name: "subset matches reference", arity: 2,
+			needs: func(impl Impl[S, E]) bool { return impl.Subset != nil },
+			holds: func(x env[S, E], in inputs) bool {
+				return x.impl.Subset(x.set(in[0]), x.set(in[1])) == sets.Subset(x.ref(in[0]), x.ref(in[1]))
+			},
+		},
+		{
+			name: "operations leave operands unchanged", arity: 2,
+			holds: func(x env[S, E], in inputs) bool {
+				a, b := x.set(in[0]), x.set(in[1])
+				x.impl.Union(a, b)
+				x.impl.Intersection(a, b)
+				x.impl.Difference(a, b)
+				if x.impl.SymmetricDifference != nil {
+					x.impl.SymmetricDifference(a, b)
+				}
+				return x.matches(a, x.ref(in[0])) && x.matches(b, x.ref(in[1]))
+			},
+		},
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package settest
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/kkhmel/sets"
+)
+
+func TestLawsDetectBugs(t *testing.T) {
+	type hs = *sets.HashSet[int]
+	tests := []struct {
+		name string
+		bug  func(impl *Impl[hs, int])
+		want string
+	}{
+		{
+			name: "asymmetric union",
+			bug:  func(impl *Impl[hs, int]) { impl.Union = func(a, _ hs) hs { return a.Clone() } },
+			want: "union is commutative",
+		},
+		{
+			name: "intersection keeping the first element",
+			bug: func(impl *Impl[hs, int]) {
+				impl.Intersection = func(a, b hs) hs { return a.Intersection(b).Union(newHashSet(a.ToSlice()[:min(1, a.Len())]...)) }
+			},
+			want: "intersection is commutative",
+		},
+		{
+			name: "union that drops zero",
+			bug:  func(impl *Impl[hs, int]) { impl.Union = func(a, b hs) hs { r := a.Union(b); r.Delete(0); return r } },
+			want: "union is idempotent",
+		},
+		{
+			name: "equal ignoring sizes",
+			bug:  func(impl *Impl[hs, int]) { impl.Equal = func(a, b hs) bool { return a.Subset(b) } },
+			want: "equal is mutual subset",
+		},
+		{
+			name: "strict subset",
+			bug:  func(impl *Impl[hs, int]) { impl.Subset = func(a, b hs) bool { return a.ProperSubset(b) } },
+			want: "operands are subsets of their union",
+		},
+		{
+			name: "union modifying its operand",
+			bug: func(impl *Impl[hs, int]) {
+				impl.Union = func(a, b hs) hs { a.Insert(b.ToSlice()...); return a.Clone() }
+			},
+			want: "operations leave operands unchanged",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			impl := hashSetImpl()
+			tt.bug(&impl)
+			failures := Check(impl, Config{Seed: 1})
+			if !slices.ContainsFunc(failures, func(f Failure[int]) bool { return f.Check == tt.want }) {
+				t.Errorf("%q was not violated: %v", tt.want, failures)
+			}
+		})
+	}
+}
@@ -0,0 +1,275 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package settest provides a conformance test kit for set implementations.
+//
+// Given the constructor and operations of an implementation, Run checks on randomly generated
+// sets that the implementation obeys the laws of set algebra (commutativity, associativity,
+// distributivity, De Morgan, idempotence, absorption, Subset/Equal consistency) and produces
+// the same results as the reference functions of package sets.
+//
+// A failing check reports the seed that reproduces it and the inputs shrunk to minimal sets:
+// removing any further element from them makes the check pass.
+//
+// The Assert functions compare sets in regular tests and report the differing elements in sorted order.
+package settest
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/kkhmel/sets"
+)
+
+// Impl describes a set implementation under test.
+// Operations must return new sets and leave their operands unchanged.
+type Impl[S sets.ReadOnly[E], E comparable] struct {
+	// New returns a set containing elems. Elements may repeat. Required.
+	New func(elems ...E) S
+	// Elem maps a non-negative integer to an element; distinct integers must map to distinct elements.
+	// Required.
+	Elem func(n int) E
+
+	// Union, Intersection and Difference return the result of the operation on a and b. Required.
+	Union, Intersection, Difference func(a, b S) S
+
+	// SymmetricDifference, Equal and Subset are optional.
+	// Checks involving an operation that is nil are skipped.
+	SymmetricDifference func(a, b S) S
+	Equal, Subset       func(a, b S) bool
+}
+
+// Config controls the generation of test inputs. The zero value is ready to use.
+type Config struct {
+	// Seed of the random generator. If zero, a random seed is chosen and reported on failure.
+	Seed uint64
+	// Iterations is the number of random inputs per check. If zero, 100 is used.
+	Iterations int
+	// MaxLen is the maximum number of elements per generated set. If zero, 8 is used.
+	MaxLen int
+}
+
+// Failure describes a violated check together with the shrunk inputs that violate it.
+type Failure[E comparable] struct {
+	Check string
+	Seed  uint64
+	// Sets are the inputs of the check, in order. Checks take from one to three sets.
+	Sets []sets.Set[E]
+	// Panic is the value the implementation panicked with, if it did.
+	Panic any
+}
+
+// String returns a description of the failure that includes the seed to reproduce it.
+func (f Failure[E]) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s: violated", f.Check)
+	if f.Panic != nil {
+		fmt.Fprintf(&b, " (panic: %v)", f.Panic)
+	}
+	for i, s := range f.Sets {
+		fmt.Fprintf(&b, "\n%c: %v", 'A'+i, s)
+	}
+	fmt.Fprintf(&b, "\nreproduce with settest.Config{Seed: %d}", f.Seed)
+	return b.String()
+}
+
+// Run runs every check and reports each failure as an error of t.
+// Checks that need an operation missing from impl are skipped.
+func Run[S sets.ReadOnly[E], E comparable](t testing.TB, impl Impl[S, E], cfg Config) {
+	t.Helper()
+	for _, f := range Check(impl, cfg) {
+		t.Error(f)
+	}
+}
+
+// Check runs every applicable check and returns the failures.
+func Check[S sets.ReadOnly[E], E comparable](impl Impl[S, E], cfg Config) []Failure[E] {
+	impl.validate()
+	cfg = cfg.withDefaults()
+	var failures []Failure[E]
+	for _, c := range checks[S, E]() {
+		if !c.applies(impl) {
+			continue
+		}
+		if f, ok := c.run(impl, cfg); !ok {
+			failures = append(failures, f)
+		}
+	}
+	return failures
+}
+
+func (impl Impl[S, E]) validate() {
+	if impl.New == nil || impl.Elem == nil || impl.Union == nil || impl.Intersection == nil || impl.Difference == nil {
+		panic("New, Elem, Union, Intersection and Difference cannot be nil")
+	}
+}
+
+func (cfg Config) withDefaults() Config {
+	if cfg.Seed == 0 {
+		cfg.Seed = rand.Uint64() | 1
+	}
+	if cfg.Iterations == 0 {
+		cfg.Iterations = 100
+	}
+	if cfg.MaxLen == 0 {
+		cfg.MaxLen = 8
+	}
+	return cfg
+}
+
+// inputs holds the generated sets of a check as integers that are mapped to elements with Impl.Elem.
+type inputs [3][]int
+
+// check is a property verified on random inputs.
+type check[S sets.ReadOnly[E], E comparable] struct {
+	name  string
+	arity int
+	// needs returns false if impl lacks an operation used by the check. nil means always applicable.
+	needs func(impl Impl[S, E]) bool
+	holds func(x env[S, E], in inputs) bool
+}
+
+func (c check[S, E]) applies(impl Impl[S, E]) bool {
+	return c.needs == nil || c.needs(impl)
+}
+
+// run verifies c on cfg.Iterations random inputs and returns the failure with shrunk inputs, if any.
+// Every check uses its own generator seeded with cfg.Seed, so that a failure reproduces in isolation.
+func (c check[S, E]) run(impl Impl[S, E], cfg Config) (Failure[E], bool) {
+	x := env[S, E]{impl: impl, universe: 2 * cfg.MaxLen}
+	r := rand.New(rand.NewPCG(cfg.Seed, cfg.Seed))
+	for range cfg.Iterations {
+		in := generate(r, cfg.MaxLen)
+		if _, ok := c.try(x, in); ok {
+			continue
+		}
+		in = shrink(in, c.arity, func(in inputs) bool {
+			_, ok := c.try(x, in)
+			return !ok
+		})
+		p, _ := c.try(x, in)
+		f := Failure[E]{Check: c.name, Seed: cfg.Seed, Panic: p}
+		for _, s := range in[:c.arity] {
+			f.Sets = append(f.Sets, x.ref(s))
+		}
+		return f, false
+	}
+	return Failure[E]{}, true
+}
+
+// try reports whether c holds for in. A panic of the implementation counts as a violation.
+func (c check[S, E]) try(x env[S, E], in inputs) (p any, ok bool) {
+	defer func() {
+		if p = recover(); p != nil {
+			ok = false
+		}
+	}()
+	return nil, c.holds(x, in)
+}
+
+// generate returns random inputs. The second set is often derived from the first,
+// so that equal sets and subsets, which are rare among independent random sets, are covered.
+func generate(r *rand.Rand, maxLen int) inputs {
+	universe := 2 * maxLen
+	random := func() []int {
+		s := make([]int, r.IntN(maxLen+1))
+		for i := range s {
+			s[i] = r.IntN(universe)
+		}
+		return s
+	}
+	var in inputs
+	in[0] = random()
+	switch r.IntN(4) {
+	case 0:
+		in[1] = slices.Clone(in[0])
+	case 1:
+		in[1] = slices.DeleteFunc(slices.Clone(in[0]), func(int) bool { return r.IntN(2) == 0 })
+	default:
+		in[1] = random()
+	}
+	in[2] = random()
+	return in
+}
+
+// shrink removes elements from the first arity sets of in one at a time
+// for as long as fails keeps reporting a failure.
+func shrink(in inputs, arity int, fails func(inputs) bool) inputs {
+	for changed := true; changed; {
+		changed = false
+		for i := range arity {
+			for j := 0; j < len(in[i]); {
+				candidate := in
+				candidate[i] = slices.Delete(slices.Clone(in[i]), j, j+1)
+				if fails(candidate) {
+					in, changed = candidate, true
+				} else {
+					j++
+				}
+			}
+		}
+	}
+	return in
+}
+
+// env provides checks with conversions between inputs, implementation sets and reference sets.
+type env[S sets.ReadOnly[E], E comparable] struct {
+	impl     Impl[S, E]
+	universe int // generated integers are in [0, universe)
+}
+
+func (x env[S, E]) set(in []int) S {
+	return x.impl.New(x.elems(in)...)
+}
+
+func (x env[S, E]) ref(in []int) sets.Set[E] {
+	return sets.FromSlice(x.elems(in))
+}
+
+func (x env[S, E]) elems(in []int) []E {
+	elems := make([]E, len(in))
+	for i, n := range in {
+		elems[i] = x.impl.Elem(n)
+	}
+	return elems
+}
+
+// matches reports whether s holds exactly the elements of want,
+// as observed through each of Len, All and Contains.
+func (x env[S, E]) matches(s S, want sets.Set[E]) bool {
+	if s.Len() != len(want) || !sets.Equal(sets.Collect(s.All()), want) {
+		return false
+	}
+	for n := range x.universe {
+		e := x.impl.Elem(n)
+		if s.Contains(e) != sets.Contains(want, e) {
+			return false
+		}
+	}
+	return true
+}
+
+// same reports whether a and b hold the same elements.
+func (x env[S, E]) same(a, b S) bool {
+	return x.matches(a, sets.Collect(b.All()))
+}
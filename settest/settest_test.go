@@ -0,0 +1,221 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package settest
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/kkhmel/sets"
+)
+
+func newHashSet(elems ...int) *sets.HashSet[int] {
+	return sets.NewHashSet(func(e int) uint64 { return uint64(e) }, func(a, b int) bool { return a == b }, elems...)
+}
+
+// hashSetImpl returns a correct implementation based on sets.HashSet.
+func hashSetImpl() Impl[*sets.HashSet[int], int] {
+	type hs = *sets.HashSet[int]
+	return Impl[hs, int]{
+		New:                 newHashSet,
+		Elem:                func(n int) int { return n },
+		Union:               func(a, b hs) hs { return a.Union(b) },
+		Intersection:        func(a, b hs) hs { return a.Intersection(b) },
+		Difference:          func(a, b hs) hs { return a.Difference(b) },
+		SymmetricDifference: func(a, b hs) hs { return a.SymmetricDifference(b) },
+		Equal:               func(a, b hs) bool { return a.Equal(b) },
+		Subset:              func(a, b hs) bool { return a.Subset(b) },
+	}
+}
+
+func TestRun(t *testing.T) {
+	Run(t, hashSetImpl(), Config{})
+	Run(t, Impl[*sets.TrieSet, string]{
+		New:          sets.NewTrieSet,
+		Elem:         strconv.Itoa,
+		Union:        func(a, b *sets.TrieSet) *sets.TrieSet { return a.Union(b) },
+		Intersection: func(a, b *sets.TrieSet) *sets.TrieSet { return a.Intersection(b) },
+		Difference:   func(a, b *sets.TrieSet) *sets.TrieSet { return a.Difference(b) },
+		Equal:        func(a, b *sets.TrieSet) bool { return a.Equal(b) },
+	}, Config{Seed: 1, Iterations: 20, MaxLen: 4})
+}
+
+func TestCheck(t *testing.T) {
+	if got := Check(hashSetImpl(), Config{Seed: 7}); len(got) != 0 {
+		t.Errorf("correct implementation failed: %v", got)
+	}
+
+	// Union loses the element 3 whenever it is present in the second operand.
+	impl := hashSetImpl()
+	impl.Union = func(a, b *sets.HashSet[int]) *sets.HashSet[int] {
+		r := a.Union(b)
+		if b.Contains(3) {
+			r.Delete(3)
+		}
+		return r
+	}
+	failures := Check(impl, Config{Seed: 7})
+	i := slices.IndexFunc(failures, func(f Failure[int]) bool { return f.Check == "union matches reference" })
+	if i < 0 {
+		t.Fatalf("bug was not detected: %v", failures)
+	}
+	f := failures[i]
+	if f.Seed != 7 || len(f.Sets) != 2 {
+		t.Errorf("unexpected failure: %v", f)
+	}
+	if !sets.Equal(f.Sets[0], sets.New[int](0)) || !sets.Equal(f.Sets[1], sets.From(3)) {
+		t.Errorf("inputs were not shrunk\nwant: A: {}, B: {3}\ngot : A: %v, B: %v", f.Sets[0], f.Sets[1])
+	}
+	want := "union matches reference: violated\nA: {}\nB: {3}\nreproduce with settest.Config{Seed: 7}"
+	if got := f.String(); got != want {
+		t.Errorf("\nwant: %v\ngot : %v", want, got)
+	}
+}
+
+func TestCheckPanic(t *testing.T) {
+	impl := hashSetImpl()
+	impl.Intersection = func(a, b *sets.HashSet[int]) *sets.HashSet[int] {
+		if a.Len() > 1 {
+			panic("too large")
+		}
+		return a.Intersection(b)
+	}
+	failures := Check(impl, Config{Seed: 3})
+	if len(failures) == 0 {
+		t.Fatalf("panic was not reported")
+	}
+	f := failures[0]
+	if f.Panic != "too large" || len(f.Sets[0])+len(f.Sets[1]) != 2 {
+		t.Errorf("unexpected failure: %v", f)
+	}
+	if got := f.String(); !strings.Contains(got, "(panic: too large)") {
+		t.Errorf("panic is missing from %q", got)
+	}
+}
+
+// recorder is a testing.TB that records reported errors.
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Error(args ...any) {
+	r.errors = append(r.errors, fmt.Sprint(args...))
+}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestRunReportsFailures(t *testing.T) {
+	impl := hashSetImpl()
+	impl.Difference = func(a, _ *sets.HashSet[int]) *sets.HashSet[int] { return a.Clone() }
+	r := &recorder{TB: t}
+	Run(r, impl, Config{Seed: 11})
+	if len(r.errors) == 0 {
+		t.Fatalf("wrong difference was not reported")
+	}
+	if got := r.errors[0]; !strings.HasPrefix(got, "difference of a set with itself is empty: violated") || !strings.Contains(got, "Seed: 11") {
+		t.Errorf("unexpected report: %v", got)
+	}
+}
+
+// lyingSet reports that it does not contain 0.
+type lyingSet struct {
+	*sets.HashSet[int]
+}
+
+func (s lyingSet) Contains(v int) bool {
+	return v != 0 && s.HashSet.Contains(v)
+}
+
+func TestCheckObservesContains(t *testing.T) {
+	wrap := func(s *sets.HashSet[int]) lyingSet { return lyingSet{s} }
+	impl := Impl[lyingSet, int]{
+		New:          func(elems ...int) lyingSet { return wrap(newHashSet(elems...)) },
+		Elem:         func(n int) int { return n },
+		Union:        func(a, b lyingSet) lyingSet { return wrap(a.Union(b.HashSet)) },
+		Intersection: func(a, b lyingSet) lyingSet { return wrap(a.Intersection(b.HashSet)) },
+		Difference:   func(a, b lyingSet) lyingSet { return wrap(a.Difference(b.HashSet)) },
+	}
+	failures := Check(impl, Config{Seed: 2})
+	i := slices.IndexFunc(failures, func(f Failure[int]) bool { return f.Check == "new matches reference" })
+	if i < 0 {
+		t.Fatalf("wrong Contains was not detected: %v", failures)
+	}
+	if got, want := failures[i].Sets[0], sets.From(0); !sets.Equal(got, want) {
+		t.Errorf("\nwant: %v\ngot : %v", want, got)
+	}
+}
+
+func TestCheckSkipsMissingOperations(t *testing.T) {
+	impl := hashSetImpl()
+	impl.SymmetricDifference = func(a, b *sets.HashSet[int]) *sets.HashSet[int] { return a.Union(b) }
+	if got := Check(impl, Config{Seed: 5}); len(got) == 0 {
+		t.Errorf("wrong symmetric difference was not detected")
+	}
+	impl.SymmetricDifference, impl.Equal, impl.Subset = nil, nil, nil
+	if got := Check(impl, Config{Seed: 5}); len(got) != 0 {
+		t.Errorf("checks of missing operations were run: %v", got)
+	}
+}
+
+func TestConfigDefaults(t *testing.T) {
+	cfg := Config{}.withDefaults()
+	if cfg.Seed == 0 || cfg.Iterations != 100 || cfg.MaxLen != 8 {
+		t.Errorf("unexpected defaults: %+v", cfg)
+	}
+	cfg = Config{Seed: 1, Iterations: 2, MaxLen: 3}.withDefaults()
+	if cfg != (Config{Seed: 1, Iterations: 2, MaxLen: 3}) {
+		t.Errorf("explicit values were overridden: %+v", cfg)
+	}
+}
+
+func TestShrink(t *testing.T) {
+	// The failure needs 2 in the first set and 5 in the third one.
+	fails := func(in inputs) bool { return slices.Contains(in[0], 2) && slices.Contains(in[2], 5) }
+	got := shrink(inputs{{1, 2, 2, 3}, {4}, {5, 6, 5}}, 3, fails)
+	want := inputs{{2}, {}, {5}}
+	for i := range got {
+		if !slices.Equal(got[i], want[i]) {
+			t.Errorf("\nwant: %v\ngot : %v", want, got)
+		}
+	}
+	if got := shrink(inputs{{1}, {2}, {3}}, 1, func(in inputs) bool { return len(in[1]) == 1 }); len(got[0]) != 0 || len(got[1]) != 1 {
+		t.Errorf("sets beyond arity were shrunk: %v", got)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected panic")
+		}
+	}()
+	impl := hashSetImpl()
+	impl.Difference = nil
+	Check(impl, Config{})
+}
@@ -0,0 +1,313 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sets
+
+import (
+	"cmp"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var (
+	_ sql.Scanner   = (*IntSet[int])(nil)
+	_ driver.Valuer = IntSet[int]{}
+	_ sql.Scanner   = (*StringSet)(nil)
+	_ driver.Valuer = StringSet{}
+)
+
+// Value returns s as a Postgres array literal, such as {1,2,3}, for storing it in a database column.
+// A nil set is stored as NULL and an empty one as {}. Use AsJSON to store s in a JSON column.
+//
+// Time complexity: O(len(s) * log(len(s))). Space complexity: O(len(s)).
+func (s IntSet[E]) Value() (driver.Value, error) {
+	if s == nil {
+		return nil, nil
+	}
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, e := range slices.Sorted(All(s)) {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		if isSigned[E]() {
+			b.WriteString(strconv.FormatInt(int64(e), 10))
+		} else {
+			b.WriteString(strconv.FormatUint(uint64(e), 10))
+		}
+	}
+	b.WriteByte('}')
+	return b.String(), nil
+}
+
+// Scan replaces the elements of s with those of a database column value, which is
+// a Postgres array literal, such as {1,2,3}, or a JSON array, such as [1,2,3], as a string or []byte.
+// NULL sets s to nil, while an empty array sets it to an empty set.
+// Since Set has the same underlying type as IntSet, a pointer to a Set can be converted to scan into it:
+//
+//	err := row.Scan((*sets.IntSet[int64])(&ids))
+//
+// Time complexity: O(len(src)). Space complexity: O(len(src)).
+func (s *IntSet[E]) Scan(src any) error {
+	vals, err := scanElements(src, s, func(v string) (E, error) {
+		var (
+			e  E
+			ok bool
+		)
+		if isSigned[E]() {
+			x, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				return e, err
+			}
+			e, ok = fromInt64[E](x)
+		} else {
+			x, err := strconv.ParseUint(v, 10, 64)
+			if err != nil {
+				return e, err
+			}
+			e, ok = fromUint64[E](x)
+		}
+		if !ok {
+			return e, fmt.Errorf("%s does not fit in %T", v, e)
+		}
+		return e, nil
+	})
+	if err != nil {
+		return err
+	}
+	*s = IntSet[E](vals)
+	return nil
+}
+
+// Value returns s as a Postgres array literal, such as {a,b,"c d"}, for storing it in a database column.
+// Elements are quoted and escaped as needed. A nil set is stored as NULL and an empty one as {}.
+// Use AsJSON to store s in a JSON column.
+//
+// Time complexity: O(L * log(len(s))). Space complexity: O(L). L is the total length of the strings.
+func (s StringSet) Value() (driver.Value, error) {
+	if s == nil {
+		return nil, nil
+	}
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, e := range slices.Sorted(All(s)) {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		if !needsQuotes(e) {
+			b.WriteString(e)
+			continue
+		}
+		b.WriteByte('"')
+		for j := range len(e) {
+			if e[j] == '"' || e[j] == '\\' {
+				b.WriteByte('\\')
+			}
+			b.WriteByte(e[j])
+		}
+		b.WriteByte('"')
+	}
+	b.WriteByte('}')
+	return b.String(), nil
+}
+
+// Scan replaces the elements of s with those of a database column value, which is
+// a Postgres array literal, such as {a,b,"c d"}, or a JSON array, such as ["a","b","c d"], as a string or []byte.
+// NULL sets s to nil, while an empty array sets it to an empty set.
+// Since Set[string] has the same underlying type as StringSet, a pointer to a Set[string] can be converted to scan into it:
+//
+//	err := row.Scan((*sets.StringSet)(&tags))
+//
+// Time complexity: O(len(src)). Space complexity: O(len(src)).
+func (s *StringSet) Scan(src any) error {
+	vals, err := scanElements(src, s, func(v string) (string, error) { return v, nil })
+	if err != nil {
+		return err
+	}
+	*s = StringSet(vals)
+	return nil
+}
+
+// AsJSON returns a driver.Valuer that stores s in a database column as a sorted JSON array, such as ["a","b"].
+// A nil set is stored as NULL and an empty one as [].
+//
+// Time complexity: O(len(s) * log(len(s))). Space complexity: O(len(s)).
+func AsJSON[S ~map[E]struct{}, E cmp.Ordered](s S) driver.Valuer {
+	return jsonValuer[E](s)
+}
+
+type jsonValuer[E cmp.Ordered] map[E]struct{}
+
+func (s jsonValuer[E]) Value() (driver.Value, error) {
+	if s == nil {
+		return nil, nil
+	}
+	vals := slices.AppendSeq(make([]E, 0, len(s)), All(s)) // non-nil, so that an empty set encodes as []
+	slices.Sort(vals)
+	data, err := json.Marshal(vals)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// scanElements returns the set of elements of src, a column value scanned into dst, or nil if src is NULL.
+// Elements of Postgres arrays are converted by parse, while those of JSON arrays are decoded into E.
+func scanElements[E comparable](src, dst any, parse func(string) (E, error)) (Set[E], error) {
+	var text string
+	switch src := src.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		text = src
+	case []byte:
+		text = string(src)
+	default:
+		return nil, fmt.Errorf("sets: cannot scan %T into %T", src, dst)
+	}
+
+	text = strings.TrimSpace(text)
+	if strings.HasPrefix(text, "[") || text == "null" {
+		var vals []*E
+		if err := json.Unmarshal([]byte(text), &vals); err != nil {
+			return nil, fmt.Errorf("sets: invalid JSON array: %w", err)
+		}
+		if vals == nil {
+			return nil, nil
+		}
+		r := New[E](len(vals))
+		for _, v := range vals {
+			if v == nil {
+				return nil, errors.New("sets: invalid JSON array: null element")
+			}
+			r[*v] = struct{}{}
+		}
+		return r, nil
+	}
+
+	elems, err := parseArray(text)
+	if err != nil {
+		return nil, err
+	}
+	r := New[E](len(elems))
+	for _, v := range elems {
+		e, err := parse(v)
+		if err != nil {
+			return nil, fmt.Errorf("sets: invalid array element: %w", err)
+		}
+		r[e] = struct{}{}
+	}
+	return r, nil
+}
+
+// parseArray returns the elements of a one-dimensional Postgres array literal such as {a,"b c",d}.
+// Unquoted elements have surrounding whitespace removed; in both quoted and unquoted elements,
+// a backslash escapes the next character. NULL elements are not allowed in sets.
+func parseArray(s string) ([]string, error) {
+	if len(s) < 2 || s[0] != '{' || s[len(s)-1] != '}' {
+		return nil, fmt.Errorf("sets: invalid array literal %q", s)
+	}
+	body := s[1 : len(s)-1]
+	if strings.Trim(body, arraySpace) == "" {
+		return []string{}, nil
+	}
+
+	var (
+		elems []string
+		b     []byte
+	)
+	for i := 0; ; i++ {
+		i = skipSpace(body, i)
+		b = b[:0]
+		if i < len(body) && body[i] == '"' {
+			for i++; ; i++ {
+				if i < len(body) && body[i] == '\\' {
+					i++
+				} else if i < len(body) && body[i] == '"' {
+					break
+				}
+				if i == len(body) {
+					return nil, fmt.Errorf("sets: invalid array literal %q: unterminated quoted element", s)
+				}
+				b = append(b, body[i])
+			}
+			i = skipSpace(body, i+1)
+		} else {
+			// keep is the length of the element without trailing unescaped whitespace.
+			keep, escaped := 0, false
+			for ; i < len(body) && body[i] != ','; i++ {
+				switch c := body[i]; {
+				case c == '\\' && i+1 < len(body):
+					i++
+					b = append(b, body[i])
+					keep, escaped = len(b), true
+				case c == '{' || c == '}' || c == '"' || c == '\\':
+					return nil, fmt.Errorf("sets: invalid array literal %q: unexpected %q", s, c)
+				default:
+					b = append(b, c)
+					if !isSpace(c) {
+						keep = len(b)
+					}
+				}
+			}
+			if keep == 0 {
+				return nil, fmt.Errorf("sets: invalid array literal %q: empty element", s)
+			}
+			if !escaped && strings.EqualFold(string(b[:keep]), "NULL") {
+				return nil, fmt.Errorf("sets: invalid array literal %q: NULL element", s)
+			}
+			b = b[:keep]
+		}
+		elems = append(elems, string(b))
+
+		if i == len(body) {
+			return elems, nil
+		}
+		if body[i] != ',' {
+			return nil, fmt.Errorf("sets: invalid array literal %q: unexpected %q", s, body[i])
+		}
+	}
+}
+
+// arraySpace is the whitespace around array elements.
+const arraySpace = " \t\n\r\v\f"
+
+func isSpace(c byte) bool {
+	return strings.IndexByte(arraySpace, c) >= 0
+}
+
+// skipSpace returns the index of the first non-whitespace character of s at or after i.
+func skipSpace(s string, i int) int {
+	for i < len(s) && isSpace(s[i]) {
+		i++
+	}
+	return i
+}
+
+// needsQuotes reports whether the array element e must be quoted.
+func needsQuotes(e string) bool {
+	return e == "" || strings.EqualFold(e, "NULL") || strings.ContainsAny(e, `{}",\`+arraySpace)
+}
@@ -0,0 +1,278 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sets
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"math"
+	"sync"
+	"testing"
+)
+
+func TestStringSetValue(t *testing.T) {
+	tests := []struct {
+		name string
+		s    StringSet
+		want driver.Value
+	}{
+		{"nil", nil, nil},
+		{"empty", StringSet{}, "{}"},
+		{"plain", StringSet{"b": {}, "a": {}, "go-1.23": {}}, "{a,b,go-1.23}"},
+		{"quoted", StringSet{"c d": {}, "": {}, "NULL": {}, "null": {}, "x,y": {}, "{}": {}, "\t": {}}, "{\"\",\"\t\",\"NULL\",\"c d\",\"null\",\"x,y\",\"{}\"}"},
+		{"escaped", StringSet{`say "hi"`: {}, `C:\dir`: {}}, `{"C:\\dir","say \"hi\""}`},
+	}
+	for _, tt := range tests {
+		got, err := tt.s.Value()
+		if err != nil || got != tt.want {
+			t.Errorf("%s\nwant: %#v\ngot : %#v, %v", tt.name, tt.want, got, err)
+		}
+	}
+}
+
+func TestStringSetScan(t *testing.T) {
+	tests := []struct {
+		name string
+		src  any
+		want StringSet
+	}{
+		{"NULL", nil, nil},
+		{"empty", "{}", StringSet{}},
+		{"empty with spaces", " { \n} ", StringSet{}},
+		{"plain", "{a,b,a}", StringSet{"a": {}, "b": {}}},
+		{"bytes", []byte("{a,b}"), StringSet{"a": {}, "b": {}}},
+		{"spaces", `{ a , "b" ,c d }`, StringSet{"a": {}, "b": {}, "c d": {}}},
+		{"quoted", `{"c d","","NULL","x,y","{}"}`, StringSet{"c d": {}, "": {}, "NULL": {}, "x,y": {}, "{}": {}}},
+		{"escaped quoted", `{"say \"hi\"","C:\\dir"}`, StringSet{`say "hi"`: {}, `C:\dir`: {}}},
+		{"escaped unquoted", `{a\,b,\NULL,\ x\ }`, StringSet{"a,b": {}, "NULL": {}, " x ": {}}},
+		{"JSON", `["a", "c d", "a"]`, StringSet{"a": {}, "c d": {}}},
+		{"empty JSON", "[]", StringSet{}},
+		{"JSON null", "null", nil},
+	}
+	for _, tt := range tests {
+		got := StringSet{"stale": {}}
+		if err := got.Scan(tt.src); err != nil || !Equal(got, tt.want) || (got == nil) != (tt.want == nil) {
+			t.Errorf("%s\nwant: %#v\ngot : %#v, %v", tt.name, tt.want, got, err)
+		}
+	}
+}
+
+func TestStringSetScanInvalid(t *testing.T) {
+	for _, src := range []any{
+		42,
+		"",
+		"a,b",
+		"{",
+		"[1:2]={a,b}",
+		"{NULL}",
+		"{a, null }",
+		"{a,}",
+		"{,a}",
+		"{a,,b}",
+		"{{a}}",
+		`{"a}`,
+		`{"a\"}`,
+		`{"a"b}`,
+		`{a"b"}`,
+		`{a\}`,
+		"[1]",
+		"[null]",
+		`["a"`,
+	} {
+		s := StringSet{"x": {}}
+		if err := s.Scan(src); err == nil {
+			t.Errorf("Scan(%#v) succeeded: %v", src, s)
+		}
+		if !Equal(s, StringSet{"x": {}}) {
+			t.Errorf("a failed Scan(%#v) modified the set: %v", src, s)
+		}
+	}
+}
+
+func TestIntSetValueScan(t *testing.T) {
+	v, err := IntSet[int64]{3: {}, -20: {}, 100: {}}.Value()
+	if want := "{-20,3,100}"; err != nil || v != want {
+		t.Errorf("\nwant: %v\ngot : %v, %v", want, v, err)
+	}
+	v, err = IntSet[uint64]{1 << 63: {}, 0: {}}.Value()
+	if want := "{0,9223372036854775808}"; err != nil || v != want {
+		t.Errorf("\nwant: %v\ngot : %v, %v", want, v, err)
+	}
+	if v, err := IntSet[int](nil).Value(); v != nil || err != nil {
+		t.Errorf("\nwant: %v\ngot : %v, %v", nil, v, err)
+	}
+
+	var i8 IntSet[int8]
+	if err := i8.Scan("{ -128, 1 ,127}"); err != nil || !Equal(i8, IntSet[int8]{-128: {}, 1: {}, 127: {}}) {
+		t.Errorf("{-128,1,127}: %v, %v", i8, err)
+	}
+	var u16 IntSet[uint16]
+	if err := u16.Scan([]byte("[1, 65535]")); err != nil || !Equal(u16, IntSet[uint16]{1: {}, 65535: {}}) {
+		t.Errorf("[1, 65535]: %v, %v", u16, err)
+	}
+	if err := u16.Scan(nil); err != nil || u16 != nil {
+		t.Errorf("NULL: %v, %v", u16, err)
+	}
+
+	for _, tt := range []struct {
+		name string
+		scan func() error
+	}{
+		{"too large", func() error { return new(IntSet[int8]).Scan("{128}") }},
+		{"negative", func() error { return new(IntSet[uint]).Scan("{-1}") }},
+		{"too large for uint8", func() error { return new(IntSet[uint8]).Scan("{256}") }},
+		{"not a number", func() error { return new(IntSet[int]).Scan("{1,x}") }},
+		{"not an unsigned number", func() error { return new(IntSet[uint]).Scan("{x}") }},
+		{"NULL element", func() error { return new(IntSet[int]).Scan("{1,NULL}") }},
+		{"JSON out of range", func() error { return new(IntSet[int8]).Scan("[1000]") }},
+		{"JSON string", func() error { return new(IntSet[int]).Scan(`["1"]`) }},
+		{"unsupported type", func() error { return new(IntSet[int]).Scan(int64(1)) }},
+	} {
+		if err := tt.scan(); err == nil {
+			t.Errorf("%s: Scan() succeeded", tt.name)
+		}
+	}
+}
+
+func TestAsJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		v    driver.Valuer
+		want driver.Value
+	}{
+		{"nil", AsJSON(Set[string](nil)), nil},
+		{"empty", AsJSON(New[string](0)), "[]"},
+		{"strings", AsJSON(From("b", `"q"`, "a")), `["\"q\"","a","b"]`},
+		{"integers", AsJSON(IntSet[int]{3: {}, -1: {}}), "[-1,3]"},
+	}
+	for _, tt := range tests {
+		got, err := tt.v.Value()
+		if err != nil || got != tt.want {
+			t.Errorf("%s\nwant: %#v\ngot : %#v, %v", tt.name, tt.want, got, err)
+		}
+	}
+	if _, err := AsJSON(From(math.NaN())).Value(); err == nil {
+		t.Errorf("Value() encoded NaN")
+	}
+}
+
+// fakeDriver is a database/sql driver that stores the value of every "INSERT" statement under its key
+// and returns it from "SELECT" queries, with strings as []byte like the drivers of most databases.
+type fakeDriver struct {
+	mu     sync.Mutex
+	values map[string]driver.Value
+}
+
+func (d *fakeDriver) Open(string) (driver.Conn, error)             { return fakeConn{d}, nil }
+func (d *fakeDriver) Connect(context.Context) (driver.Conn, error) { return fakeConn{d}, nil }
+func (d *fakeDriver) Driver() driver.Driver                        { return d }
+
+type fakeConn struct{ d *fakeDriver }
+
+func (c fakeConn) Prepare(query string) (driver.Stmt, error) { return fakeStmt{c.d, query}, nil }
+func (fakeConn) Close() error                                { return nil }
+func (fakeConn) Begin() (driver.Tx, error)                   { return nil, errors.New("not supported") }
+
+type fakeStmt struct {
+	d     *fakeDriver
+	query string
+}
+
+func (fakeStmt) Close() error  { return nil }
+func (fakeStmt) NumInput() int { return -1 }
+
+func (s fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.d.mu.Lock()
+	defer s.d.mu.Unlock()
+	s.d.values[args[0].(string)] = args[1]
+	return driver.RowsAffected(1), nil
+}
+
+func (s fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.d.mu.Lock()
+	defer s.d.mu.Unlock()
+	v := s.d.values[args[0].(string)]
+	if text, ok := v.(string); ok {
+		v = []byte(text)
+	}
+	return &fakeRows{value: v}, nil
+}
+
+type fakeRows struct {
+	value driver.Value
+	done  bool
+}
+
+func (*fakeRows) Columns() []string { return []string{"value"} }
+func (*fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	dest[0], r.done = r.value, true
+	return nil
+}
+
+func TestSQLRoundTrip(t *testing.T) {
+	db := sql.OpenDB(&fakeDriver{values: map[string]driver.Value{}})
+	defer db.Close()
+
+	store := func(key string, v any) {
+		t.Helper()
+		if _, err := db.Exec("INSERT", key, v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	load := func(key string, dst any) {
+		t.Helper()
+		if err := db.QueryRow("SELECT", key).Scan(dst); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tags := From("go", "sql", "c d", "", "NULL", `"quoted"`, `back\slash`, "{braces}", "a,b", " spaced ")
+	for name, s := range map[string]Set[string]{"tags": tags, "empty": New[string](0), "null": nil} {
+		store(name, StringSet(s))
+		store(name+".json", AsJSON(s))
+		for _, key := range []string{name, name + ".json"} {
+			got := From("stale")
+			load(key, (*StringSet)(&got))
+			if !Equal(got, s) || (got == nil) != (s == nil) {
+				t.Errorf("%s\nwant: %#v\ngot : %#v", key, s, got)
+			}
+		}
+	}
+
+	ids := From[int64](-5, 0, 42)
+	store("ids", IntSet[int64](ids))
+	store("ids.json", AsJSON(ids))
+	for _, key := range []string{"ids", "ids.json"} {
+		var got Set[int64]
+		load(key, (*IntSet[int64])(&got))
+		if !Equal(got, ids) {
+			t.Errorf("%s\nwant: %v\ngot : %v", key, ids, got)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package stream_test
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/kkhmel/sets"
+	"github.com/kkhmel/sets/stream"
+)
+
+func ExampleSort() {
+	visitors := sets.From("eve", "bob", "ann", "dan", "cid")
+
+	// Sort the set in chunks of 2 elements, so that at most 2 elements are in memory at a time.
+	sorted, err := stream.Sort("", sets.Chunk(visitors, 2))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer sorted.Close()
+
+	customers := slices.Values([]string{"bob", "dan", "fay"})
+	for name := range stream.Difference(sorted.All(), customers) {
+		fmt.Println(name)
+	}
+	fmt.Println(sorted.Err())
+
+	// Output:
+	// ann
+	// cid
+	// eve
+	// <nil>
+}
+
+func ExampleUnion() {
+	a := slices.Values([]int{1, 3, 5})
+	b := slices.Values([]int{2, 3, 4})
+
+	fmt.Println(slices.Collect(stream.Union(a, b)))
+	fmt.Println(slices.Collect(stream.Intersection(a, b)))
+	fmt.Println(slices.Collect(stream.SymmetricDifference(a, b)))
+
+	// Output:
+	// [1 2 3 4 5]
+	// [3]
+	// [1 2 4 5]
+}
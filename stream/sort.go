@@ -0,0 +1,222 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package stream
+
+import (
+	"bufio"
+	"cmp"
+	"encoding/gob"
+	"errors"
+	"io"
+	"io/fs"
+	"iter"
+	"os"
+	"slices"
+
+	"github.com/kkhmel/sets"
+)
+
+// MaxRuns is the number of sorted files that Sort merges at once.
+// Files are merged in tiers: when the last MaxRuns files were all merged from the same number
+// of chunks, Sort merges them into one, so every element is rewritten once per tier and
+// at most MaxRuns-1 files per tier are left open during merging.
+const MaxRuns = 64
+
+// Sorted is a sorted sequence of unique elements stored in temporary files.
+// The files are removed by Close.
+//
+// A Sorted is not safe for concurrent use.
+type Sorted[E cmp.Ordered] struct {
+	dir    string
+	runs   []string
+	levels []int // tier of each run: a run of tier i holds MaxRuns^i chunks merged
+	err    error
+}
+
+// Sort sorts the elements of the sets produced by chunks using temporary files in dir,
+// or in the default directory for temporary files if dir is empty.
+// Only one set of chunks is held in memory at a time: each is sorted and written to its own file.
+// Elements must be encodable with encoding/gob.
+//
+// Time complexity: O(N * log(N)). Space complexity: O(n) in memory and O(N) on disk.
+// N is the total number of elements, n is the size of the largest set.
+func Sort[E cmp.Ordered](dir string, chunks iter.Seq[sets.Set[E]]) (*Sorted[E], error) {
+	s := &Sorted[E]{dir: dir}
+	for chunk := range chunks {
+		if len(chunk) == 0 {
+			continue
+		}
+		err := s.spill(0, slices.Values(slices.Sorted(sets.All(chunk))))
+		if err == nil {
+			err = s.compact()
+		}
+		if err != nil {
+			_ = s.Close()
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// All returns an iterator over the elements in ascending order.
+// If reading the files fails, the iteration stops early and Err returns the error.
+//
+// Creation: O(1) time, O(1) space.
+// Iteration: O(N * log(k)) time, O(k) space. N is the number of elements, k is the number of files.
+func (s *Sorted[E]) All() iter.Seq[E] {
+	return func(yield func(E) bool) {
+		seqs := make([]iter.Seq[E], len(s.runs))
+		for i, name := range s.runs {
+			seqs[i] = s.read(name)
+		}
+		for e := range Union(seqs...) {
+			if s.err != nil || !yield(e) {
+				return
+			}
+		}
+	}
+}
+
+// Err returns the first error encountered while reading the files, if any.
+//
+// Time complexity: O(1). Space complexity: O(1).
+func (s *Sorted[E]) Err() error {
+	return s.err
+}
+
+// Close removes the temporary files. Files that cannot be removed are kept,
+// so that calling Close again retries them.
+//
+// Time complexity: O(k). Space complexity: O(1). k is the number of files.
+func (s *Sorted[E]) Close() error {
+	var errs []error
+	var runs []string
+	var levels []int
+	for i, name := range s.runs {
+		if err := removeFile(name); err != nil {
+			errs = append(errs, err)
+			if !errors.Is(err, fs.ErrNotExist) {
+				runs, levels = append(runs, name), append(levels, s.levels[i])
+			}
+		}
+	}
+	s.runs, s.levels = runs, levels
+	return errors.Join(errs...)
+}
+
+// compact merges the last MaxRuns files into one of the next tier while they are of the same tier.
+// The tiers of the files never increase, so the last MaxRuns files are of the same tier
+// if the first and the last of them are.
+func (s *Sorted[E]) compact() error {
+	for n := len(s.runs); n >= MaxRuns && s.levels[n-MaxRuns] == s.levels[n-1]; n = len(s.runs) {
+		// The merged files stay tracked until they have been replaced by the new file,
+		// so that Close removes them if merging fails.
+		merged := &Sorted[E]{runs: slices.Clone(s.runs[n-MaxRuns:]), levels: slices.Clone(s.levels[n-MaxRuns:])}
+		name, err := s.create(merged.All())
+		if err == nil {
+			err = merged.err
+		}
+		if err != nil {
+			if name != "" {
+				_ = removeFile(name)
+			}
+			return err
+		}
+		s.runs, s.levels = append(s.runs[:n-MaxRuns], name), append(s.levels[:n-MaxRuns], s.levels[n-1]+1)
+		if err := merged.Close(); err != nil {
+			// Keep the files that could not be removed, so that Close retries them.
+			s.runs, s.levels = append(s.runs, merged.runs...), append(s.levels, merged.levels...)
+			return err
+		}
+	}
+	return nil
+}
+
+// spill writes the sorted elements of seq to a new file of the given tier.
+func (s *Sorted[E]) spill(level int, seq iter.Seq[E]) error {
+	name, err := s.create(seq)
+	if name != "" {
+		// Keep the file even if writing failed, so that Close removes it.
+		s.runs, s.levels = append(s.runs, name), append(s.levels, level)
+	}
+	return err
+}
+
+// create writes the sorted elements of seq to a new file. It returns the name of the file
+// if it has been created, even if writing failed.
+func (s *Sorted[E]) create(seq iter.Seq[E]) (string, error) {
+	f, err := os.CreateTemp(s.dir, "run-*")
+	if err != nil {
+		return "", err
+	}
+	err = writeRun(f, seq)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	return f.Name(), err
+}
+
+// read returns an iterator over the elements of a file written by spill,
+// which records errors in s.err.
+func (s *Sorted[E]) read(name string) iter.Seq[E] {
+	return func(yield func(E) bool) {
+		f, err := os.Open(name)
+		if err != nil {
+			s.fail(err)
+			return
+		}
+		defer func() { _ = f.Close() }()
+		dec := gob.NewDecoder(bufio.NewReader(f))
+		for {
+			var e E
+			if err := dec.Decode(&e); err != nil {
+				if !errors.Is(err, io.EOF) {
+					s.fail(err)
+				}
+				return
+			}
+			if !yield(e) {
+				return
+			}
+		}
+	}
+}
+
+// removeFile removes a file. It is a variable, so that tests can make it fail.
+var removeFile = os.Remove
+
+func (s *Sorted[E]) fail(err error) {
+	if s.err == nil {
+		s.err = err
+	}
+}
+
+// writeRun writes the elements of seq to w with encoding/gob.
+func writeRun[E any](w io.Writer, seq iter.Seq[E]) error {
+	bw := bufio.NewWriter(w)
+	enc := gob.NewEncoder(bw)
+	for e := range seq {
+		if err := enc.Encode(e); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,248 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package stream
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/kkhmel/sets"
+)
+
+func sortSet(t *testing.T, dir string, s sets.Set[int], n int) *Sorted[int] {
+	t.Helper()
+	sorted, err := Sort(dir, sets.Chunk(s, n))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sorted
+}
+
+func files(t *testing.T, dir string) int {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return len(entries)
+}
+
+func TestSort(t *testing.T) {
+	r := rand.New(rand.NewPCG(3, 4))
+	for _, tt := range []struct{ size, chunk int }{
+		{0, 10},
+		{1, 10},
+		{100, 1000},
+		{1000, 7},
+		{MaxRuns * 10, 10},
+		{MaxRuns * MaxRuns, 4},
+	} {
+		t.Run(fmt.Sprint(tt.size, "/", tt.chunk), func(t *testing.T) {
+			dir := t.TempDir()
+			s := randomSet(r, tt.size, 1<<30)
+			sorted := sortSet(t, dir, s, tt.chunk)
+			if n := files(t, dir); n > MaxRuns {
+				t.Errorf("%d files were left", n)
+			}
+			want := slices.Sorted(sets.All(s))
+			for range 2 {
+				if got := slices.Collect(sorted.All()); !slices.Equal(got, want) || sorted.Err() != nil {
+					t.Errorf("All() returned %d elements, want %d; Err() = %v", len(got), len(want), sorted.Err())
+				}
+			}
+			if err := sorted.Close(); err != nil {
+				t.Fatal(err)
+			}
+			if n := files(t, dir); n != 0 {
+				t.Errorf("%d files were not removed", n)
+			}
+		})
+	}
+}
+
+func TestSortTiers(t *testing.T) {
+	// Files are only merged with files holding as many chunks, so every element is rewritten
+	// once per tier instead of once per merge.
+	dir := t.TempDir()
+	chunks := func(yield func(sets.Set[int]) bool) {
+		for i := range MaxRuns*MaxRuns + 2*MaxRuns + 1 {
+			if !yield(sets.From(i)) {
+				return
+			}
+		}
+	}
+	sorted, err := Sort(dir, chunks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sorted.Close()
+	if want := []int{2, 1, 1, 0}; !slices.Equal(sorted.levels, want) {
+		t.Errorf("\nwant: %v\ngot : %v", want, sorted.levels)
+	}
+	if got, want := len(slices.Collect(sorted.All())), MaxRuns*MaxRuns+2*MaxRuns+1; got != want {
+		t.Errorf("\nwant: %v\ngot : %v", want, got)
+	}
+}
+
+func TestSortDuplicateChunks(t *testing.T) {
+	dir := t.TempDir()
+	chunks := slices.Values([]sets.Set[string]{sets.From("b", "a"), {}, sets.From("c", "b")})
+	sorted, err := Sort(dir, chunks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sorted.Close()
+	if got, want := slices.Collect(sorted.All()), []string{"a", "b", "c"}; !slices.Equal(got, want) {
+		t.Errorf("\nwant: %v\ngot : %v", want, got)
+	}
+	for e := range Intersection(sorted.All(), slices.Values([]string{"b", "d"})) {
+		if e != "b" {
+			t.Errorf("\nwant: %v\ngot : %v", "b", e)
+		}
+	}
+}
+
+func TestSortErrors(t *testing.T) {
+	missing := filepath.Join(t.TempDir(), "missing")
+	if _, err := Sort(missing, sets.Chunk(sets.From(1, 2), 1)); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("\nwant: %v\ngot : %v", fs.ErrNotExist, err)
+	}
+
+	// Writing fails once the directory is removed, after some files have been written.
+	dir := t.TempDir()
+	chunks := func(yield func(sets.Set[int]) bool) {
+		for i := range 2 * MaxRuns {
+			if i == MaxRuns-1 {
+				_ = os.RemoveAll(dir)
+			}
+			if !yield(sets.From(i)) {
+				return
+			}
+		}
+	}
+	if _, err := Sort(dir, chunks); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("\nwant: %v\ngot : %v", fs.ErrNotExist, err)
+	}
+
+	// Reading fails while merging the files.
+	dir = t.TempDir()
+	chunks = func(yield func(sets.Set[int]) bool) {
+		for i := range MaxRuns {
+			if i == MaxRuns-1 {
+				entries, _ := os.ReadDir(dir)
+				_ = os.WriteFile(filepath.Join(dir, entries[0].Name()), []byte("garbage"), 0o600)
+			}
+			if !yield(sets.From(i)) {
+				return
+			}
+		}
+	}
+	if _, err := Sort(dir, chunks); err == nil {
+		t.Errorf("Sort() succeeded with a corrupt file")
+	}
+	if n := files(t, dir); n != 0 {
+		t.Errorf("%d files were not removed", n)
+	}
+
+	if err := writeRun(failingWriter{}, slices.Values([]int{1})); !errors.Is(err, errWrite) {
+		t.Errorf("\nwant: %v\ngot : %v", errWrite, err)
+	}
+	if err := writeRun(failingWriter{}, slices.Values([]string{string(make([]byte, 5000))})); !errors.Is(err, errWrite) {
+		t.Errorf("\nwant: %v\ngot : %v", errWrite, err)
+	}
+}
+
+func TestSortRemoveErrors(t *testing.T) {
+	// Files that cannot be removed after merging stay tracked, so that Close retries them.
+	errRemove := errors.New("remove failed")
+	failures := 1
+	removeFile = func(name string) error {
+		if failures > 0 {
+			failures--
+			return errRemove
+		}
+		return os.Remove(name)
+	}
+	defer func() { removeFile = os.Remove }()
+
+	dir := t.TempDir()
+	if _, err := Sort(dir, sets.Chunk(sets.From(rangeInts(MaxRuns)...), 1)); !errors.Is(err, errRemove) {
+		t.Errorf("\nwant: %v\ngot : %v", errRemove, err)
+	}
+	if n := files(t, dir); n != 0 {
+		t.Errorf("%d files were not removed", n)
+	}
+
+	failures = 1
+	sorted := sortSet(t, dir, sets.From(1, 2), 1)
+	if err := sorted.Close(); !errors.Is(err, errRemove) || len(sorted.runs) != 1 {
+		t.Errorf("Close() = %v; %d files are tracked", err, len(sorted.runs))
+	}
+	if err := sorted.Close(); err != nil || files(t, dir) != 0 {
+		t.Errorf("Close() = %v; %d files were not removed", err, files(t, dir))
+	}
+}
+
+func rangeInts(n int) []int {
+	r := make([]int, n)
+	for i := range r {
+		r[i] = i
+	}
+	return r
+}
+
+var errWrite = errors.New("write failed")
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errWrite }
+
+func TestSortedReadErrors(t *testing.T) {
+	dir := t.TempDir()
+	sorted := sortSet(t, dir, sets.From(1, 2, 3, 4), 2)
+	if err := os.Remove(sorted.runs[0]); err != nil {
+		t.Fatal(err)
+	}
+	_ = slices.Collect(sorted.All())
+	if !errors.Is(sorted.Err(), fs.ErrNotExist) {
+		t.Errorf("\nwant: %v\ngot : %v", fs.ErrNotExist, sorted.Err())
+	}
+	// The first error is kept, and iteration stops at once.
+	_ = os.WriteFile(sorted.runs[1], []byte("garbage"), 0o600)
+	if got := slices.Collect(sorted.All()); got != nil || !errors.Is(sorted.Err(), fs.ErrNotExist) {
+		t.Errorf("All() = %v; Err() = %v", got, sorted.Err())
+	}
+	if err := sorted.Close(); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("\nwant: %v\ngot : %v", fs.ErrNotExist, err)
+	}
+
+	sorted = sortSet(t, dir, sets.From(1, 2, 3, 4), 2)
+	defer sorted.Close()
+	for range sorted.All() {
+		break
+	}
+}
@@ -0,0 +1,178 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package stream provides set operations on sorted sequences and an external sorter,
+// for sets that do not fit in memory.
+//
+// The operations merge their inputs in a single pass and hold one element of each input at a time.
+// Inputs must be sorted in ascending order. Repeated elements within an input are treated
+// as a single element, and every operation yields each element only once, in ascending order,
+// so results can be fed into further operations.
+//
+// Sort turns unsorted input, such as the subsets produced by sets.Chunk, into a sorted sequence
+// by writing every subset sorted to a temporary file and merging the files.
+package stream
+
+import (
+	"cmp"
+	"container/heap"
+	"iter"
+)
+
+// Union returns an iterator over the elements present in any of the sorted sequences.
+// Union panics during iteration if a sequence is not sorted.
+//
+// Creation: O(1) time, O(1) space.
+// Iteration: O(N * log(k)) time, O(k) space. N is the total number of elements, k is the number of sequences.
+func Union[E cmp.Ordered](seqs ...iter.Seq[E]) iter.Seq[E] {
+	return func(yield func(E) bool) {
+		merge(seqs, nil, func(e E, _ int, _ bool) bool {
+			return yield(e)
+		})
+	}
+}
+
+// Intersection returns an iterator over the elements present in all of the sorted sequences.
+// If no sequences are provided, the sequence is empty. Iteration ends once any sequence
+// is exhausted, without reading the rest of the others.
+// Intersection panics during iteration if a sequence is not sorted.
+//
+// Creation: O(1) time, O(1) space.
+// Iteration: O(N * log(k)) time, O(k) space. N is the total number of elements, k is the number of sequences.
+func Intersection[E cmp.Ordered](seqs ...iter.Seq[E]) iter.Seq[E] {
+	return func(yield func(E) bool) {
+		merge(seqs, func(int) bool { return true }, func(e E, n int, _ bool) bool {
+			return n < len(seqs) || yield(e)
+		})
+	}
+}
+
+// Difference returns an iterator over the elements of the sorted minuend
+// that are not present in any of the sorted subtrahends.
+// Iteration ends once the minuend is exhausted, without reading the rest of the subtrahends.
+// Difference panics during iteration if a sequence is not sorted.
+//
+// Creation: O(1) time, O(1) space.
+// Iteration: O(N * log(k)) time, O(k) space. N is the total number of elements, k is the number of sequences.
+func Difference[E cmp.Ordered](minuend iter.Seq[E], subtrahends ...iter.Seq[E]) iter.Seq[E] {
+	seqs := append([]iter.Seq[E]{minuend}, subtrahends...)
+	return func(yield func(E) bool) {
+		merge(seqs, func(i int) bool { return i == 0 }, func(e E, n int, first bool) bool {
+			return !first || n > 1 || yield(e)
+		})
+	}
+}
+
+// SymmetricDifference returns an iterator over the elements present
+// in an odd number of the sorted sequences (i.e., the n-ary XOR of the sequences).
+// SymmetricDifference panics during iteration if a sequence is not sorted.
+//
+// Creation: O(1) time, O(1) space.
+// Iteration: O(N * log(k)) time, O(k) space. N is the total number of elements, k is the number of sequences.
+func SymmetricDifference[E cmp.Ordered](seqs ...iter.Seq[E]) iter.Seq[E] {
+	return func(yield func(E) bool) {
+		merge(seqs, nil, func(e E, n int, _ bool) bool {
+			return n%2 == 0 || yield(e)
+		})
+	}
+}
+
+// merge calls f in ascending order with every distinct element of the sorted sequences,
+// the number of sequences containing it and whether the first sequence contains it,
+// until f returns false. If ends is not nil, merge also stops once a sequence i
+// for which ends(i) is true is exhausted, after the last element of that sequence.
+func merge[E cmp.Ordered](seqs []iter.Seq[E], ends func(i int) bool, f func(e E, n int, first bool) bool) {
+	ended := func(c *cursor[E]) bool {
+		return ends != nil && ends(c.index)
+	}
+	h := make(cursors[E], 0, len(seqs))
+	for i, seq := range seqs {
+		next, stop := iter.Pull(seq)
+		defer stop()
+		c := &cursor[E]{next: next, index: i}
+		if c.advance() {
+			h = append(h, c)
+		} else if ended(c) {
+			return
+		}
+	}
+	heap.Init(&h)
+
+	for done := false; len(h) > 0 && !done; {
+		e, n, first := h[0].value, 0, false
+		for len(h) > 0 && cmp.Compare(h[0].value, e) == 0 {
+			c := h[0]
+			n++
+			first = first || c.index == 0
+			if c.advance() {
+				heap.Fix(&h, 0)
+			} else {
+				heap.Pop(&h)
+				done = done || ended(c)
+			}
+		}
+		if !f(e, n, first) {
+			return
+		}
+	}
+}
+
+// cursor is the current element of a sequence being merged.
+type cursor[E cmp.Ordered] struct {
+	next    func() (E, bool)
+	value   E
+	index   int
+	started bool
+}
+
+// advance moves c to the next distinct element and reports whether there is one.
+func (c *cursor[E]) advance() bool {
+	for {
+		v, ok := c.next()
+		if !ok {
+			return false
+		}
+		if c.started {
+			switch cmp.Compare(v, c.value) {
+			case -1:
+				panic("sequence is not sorted")
+			case 0:
+				continue
+			}
+		}
+		c.value, c.started = v, true
+		return true
+	}
+}
+
+// cursors is a min-heap of cursors ordered by their current elements.
+type cursors[E cmp.Ordered] []*cursor[E]
+
+func (h cursors[E]) Len() int           { return len(h) }
+func (h cursors[E]) Less(i, j int) bool { return cmp.Less(h[i].value, h[j].value) }
+func (h cursors[E]) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *cursors[E]) Push(x any)        { *h = append(*h, x.(*cursor[E])) }
+
+func (h *cursors[E]) Pop() any {
+	old := *h
+	c := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return c
+}
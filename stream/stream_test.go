@@ -0,0 +1,171 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package stream
+
+import (
+	"container/heap"
+	"iter"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/kkhmel/sets"
+)
+
+// sorted returns a sorted sequence of the elements of s, with every element repeated.
+func sorted(s sets.Set[int]) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for _, e := range slices.Sorted(sets.All(s)) {
+			if !yield(e) || !yield(e) {
+				return
+			}
+		}
+	}
+}
+
+func randomSet(r *rand.Rand, n, max int) sets.Set[int] {
+	s := sets.New[int](n)
+	for range n {
+		sets.Insert(s, r.IntN(max))
+	}
+	return s
+}
+
+func TestOperations(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	tests := map[string]struct {
+		stream func(seqs ...iter.Seq[int]) iter.Seq[int]
+		want   func(ss ...sets.Set[int]) sets.Set[int]
+	}{
+		"Union": {Union[int], sets.Union[sets.Set[int]]},
+		"Intersection": {Intersection[int], func(ss ...sets.Set[int]) sets.Set[int] {
+			return sets.Intersection(ss...)
+		}},
+		"Difference": {
+			func(seqs ...iter.Seq[int]) iter.Seq[int] { return Difference(seqs[0], seqs[1:]...) },
+			func(ss ...sets.Set[int]) sets.Set[int] { return sets.Difference(ss[0], ss[1:]...) },
+		},
+		"SymmetricDifference": {SymmetricDifference[int], sets.SymmetricDifference[sets.Set[int]]},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			for k := range 6 {
+				ss := make([]sets.Set[int], k)
+				seqs := make([]iter.Seq[int], k)
+				for i := range ss {
+					ss[i] = randomSet(r, r.IntN(200), 300)
+					seqs[i] = sorted(ss[i])
+				}
+				if name == "Difference" && k == 0 {
+					continue
+				}
+				got := slices.Collect(tt.stream(seqs...))
+				want := tt.want(ss...)
+				if !slices.IsSorted(got) || !sets.Equal(sets.FromSlice(got), want) || len(got) != len(want) {
+					t.Errorf("%d sequences\nwant: %v\ngot : %v", k, want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestOperationsEdgeCases(t *testing.T) {
+	a, b := sorted(sets.From(1, 2, 3)), sorted(sets.From(2, 3, 4))
+	tests := []struct {
+		name string
+		got  iter.Seq[int]
+		want []int
+	}{
+		{"empty union", Union[int](), nil},
+		{"empty intersection", Intersection[int](), nil},
+		{"empty symmetric difference", SymmetricDifference[int](), nil},
+		{"difference without subtrahends", Difference(a), []int{1, 2, 3}},
+		{"empty minuend", Difference(slices.Values([]int{}), a), nil},
+		{"nested", Difference(Union(a, b), Intersection(a, b)), []int{1, 4}},
+	}
+	for _, tt := range tests {
+		if got := slices.Collect(tt.got); !slices.Equal(got, tt.want) {
+			t.Errorf("%s\nwant: %v\ngot : %v", tt.name, tt.want, got)
+		}
+	}
+
+	// Stopping early stops all inputs.
+	for range Union(a, b) {
+		break
+	}
+
+	var h cursors[int]
+	heap.Push(&h, &cursor[int]{value: 1})
+	if h.Len() != 1 {
+		t.Errorf("Push() did not add the cursor")
+	}
+}
+
+// counted returns a sequence of the integers from 0 to n-1 that counts the elements read in *read.
+func counted(n int, read *int) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for i := range n {
+			*read++
+			if !yield(i) {
+				return
+			}
+		}
+	}
+}
+
+func TestOperationsStopEarly(t *testing.T) {
+	short := slices.Values([]int{1, 2})
+	tests := []struct {
+		name string
+		got  func(long iter.Seq[int]) iter.Seq[int]
+		want []int
+	}{
+		{"intersection", func(long iter.Seq[int]) iter.Seq[int] { return Intersection(short, long) }, []int{1, 2}},
+		{"intersection with empty", func(long iter.Seq[int]) iter.Seq[int] {
+			return Intersection(long, slices.Values([]int{}))
+		}, nil},
+		{"difference", func(long iter.Seq[int]) iter.Seq[int] { return Difference(short, long) }, nil},
+		{"difference of empty", func(long iter.Seq[int]) iter.Seq[int] {
+			return Difference(slices.Values([]int{}), long)
+		}, nil},
+	}
+	for _, tt := range tests {
+		read := 0
+		if got := slices.Collect(tt.got(counted(1000, &read))); !slices.Equal(got, tt.want) || read > 4 {
+			t.Errorf("%s: %d elements read\nwant: %v\ngot : %v", tt.name, read, tt.want, got)
+		}
+	}
+
+	// The union reads everything.
+	read := 0
+	if got := slices.Collect(Union(short, counted(1000, &read))); len(got) != 1000 || read != 1000 {
+		t.Errorf("Union() returned %d elements after reading %d", len(got), read)
+	}
+}
+
+func TestOperationsUnsorted(t *testing.T) {
+	defer func() {
+		if r := recover(); r != "sequence is not sorted" {
+			t.Errorf("\nwant: %v\ngot : %v", "sequence is not sorted", r)
+		}
+	}()
+	_ = slices.Collect(Union(slices.Values([]int{1, 3, 2})))
+}
@@ -0,0 +1,300 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sets
+
+import (
+	"errors"
+	"iter"
+	"slices"
+	"sync"
+)
+
+var (
+	// ErrConflict is returned by Tx.Commit when a transaction committed after the start of
+	// the transaction has modified an element that the transaction has read or written.
+	ErrConflict = errors.New("sets: transaction conflicts with a concurrent commit")
+	// ErrTxDone is returned by Tx.Commit and Tx.Rollback when the transaction
+	// has already been committed or rolled back.
+	ErrTxDone = errors.New("sets: transaction has already been committed or rolled back")
+)
+
+// Transactional is a set that is modified through transactions, which apply all of their
+// changes atomically or none of them.
+//
+// Transactions use optimistic concurrency control: they do not lock the set while in progress,
+// and Commit fails with ErrConflict if another transaction committed after Begin has modified
+// an element that the committing transaction has read with Contains or written with Insert or
+// Delete. Iterating or calling Len reads all elements, so any concurrent commit conflicts.
+// A transaction that fails to commit can be retried from the start.
+//
+// Transactional is safe for concurrent use; a single Tx is not.
+// Every transaction must end with Commit or Rollback, since the set keeps the changes
+// of commits for as long as transactions that started before them are in progress.
+//
+// Use NewTransactional to create a Transactional set; the zero value is not ready to use.
+type Transactional[E comparable] struct {
+	mu      sync.RWMutex
+	s       Set[E]
+	version uint64         // number of commits that changed the set
+	commits []txCommit[E]  // changes needed to validate the transactions in progress
+	active  map[uint64]int // number of transactions in progress by start version
+}
+
+// txCommit records the elements modified by the commit that produced version.
+type txCommit[E comparable] struct {
+	version uint64
+	written Set[E]
+}
+
+// Tx is a transaction on a Transactional set. Its reads reflect its own pending changes
+// on top of the current elements of the set.
+type Tx[E comparable] struct {
+	t                 *Transactional[E]
+	start             uint64
+	inserted, deleted Set[E] // pending changes; an element is in at most one of them
+	read              Set[E]
+	readAll           bool
+	done              bool
+}
+
+// NewTransactional creates a new Transactional set containing vals.
+//
+// Time complexity: O(len(vals)). Space complexity: O(len(vals)).
+func NewTransactional[E comparable](vals ...E) *Transactional[E] {
+	return &Transactional[E]{s: From(vals...), active: make(map[uint64]int)}
+}
+
+// Contains reports whether v is present in the set.
+//
+// Time complexity: O(1). Space complexity: O(1).
+func (t *Transactional[E]) Contains(v E) bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return Contains(t.s, v)
+}
+
+// Len returns the number of elements in the set.
+//
+// Time complexity: O(1). Space complexity: O(1).
+func (t *Transactional[E]) Len() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return len(t.s)
+}
+
+// All returns an iterator over a snapshot of the elements of the set taken at the start of iteration.
+// The iteration order is not specified.
+//
+// Creation: O(1) time, O(1) space.
+// Iteration: O(len(t)) time, O(len(t)) space.
+func (t *Transactional[E]) All() iter.Seq[E] {
+	return func(yield func(E) bool) {
+		t.mu.RLock()
+		elements := ToSlice(t.s)
+		t.mu.RUnlock()
+		for _, e := range elements {
+			if !yield(e) {
+				return
+			}
+		}
+	}
+}
+
+// Begin starts a new transaction.
+//
+// Time complexity: O(1). Space complexity: O(1).
+func (t *Transactional[E]) Begin() *Tx[E] {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.active[t.version]++
+	return &Tx[E]{t: t, start: t.version, inserted: New[E](0), deleted: New[E](0), read: New[E](0)}
+}
+
+// Insert inserts the given elements into the set when the transaction commits.
+// If the transaction is done, Insert panics.
+//
+// Time complexity: O(len(v)). Space complexity: O(len(v)).
+func (tx *Tx[E]) Insert(v ...E) {
+	tx.check()
+	for _, e := range v {
+		delete(tx.deleted, e)
+		tx.inserted[e] = struct{}{}
+	}
+}
+
+// Delete deletes the given elements from the set when the transaction commits.
+// If the transaction is done, Delete panics.
+//
+// Time complexity: O(len(v)). Space complexity: O(len(v)).
+func (tx *Tx[E]) Delete(v ...E) {
+	tx.check()
+	for _, e := range v {
+		delete(tx.inserted, e)
+		tx.deleted[e] = struct{}{}
+	}
+}
+
+// Contains reports whether v is present in the set with the pending changes applied.
+// If the transaction is done, Contains panics.
+//
+// Time complexity: O(1). Space complexity: O(1).
+func (tx *Tx[E]) Contains(v E) bool {
+	tx.check()
+	if Contains(tx.inserted, v) {
+		return true
+	}
+	if Contains(tx.deleted, v) {
+		return false
+	}
+	tx.read[v] = struct{}{}
+	return tx.t.Contains(v)
+}
+
+// Len returns the number of elements in the set with the pending changes applied.
+// If the transaction is done, Len panics.
+//
+// Time complexity: O(len(tx)). Space complexity: O(1).
+func (tx *Tx[E]) Len() int {
+	n := 0
+	for range tx.All() {
+		n++
+	}
+	return n
+}
+
+// All returns an iterator over the elements of the set with the pending changes applied.
+// The set is read at the start of iteration; changes made by the transaction
+// during iteration may or may not be reflected. If the transaction is done, All panics.
+// The iteration order is not specified.
+//
+// Creation: O(1) time, O(1) space.
+// Iteration: O(len(tx)) time, O(len(tx)) space.
+func (tx *Tx[E]) All() iter.Seq[E] {
+	tx.check()
+	return func(yield func(E) bool) {
+		tx.readAll = true
+		tx.t.mu.RLock()
+		elements := make([]E, 0, len(tx.t.s)+len(tx.inserted))
+		for e := range tx.t.s {
+			if !Contains(tx.deleted, e) && !Contains(tx.inserted, e) {
+				elements = append(elements, e)
+			}
+		}
+		tx.t.mu.RUnlock()
+		for _, e := range slices.Concat(elements, ToSlice(tx.inserted)) {
+			if !yield(e) {
+				return
+			}
+		}
+	}
+}
+
+// Commit applies the pending changes of the transaction to the set atomically and ends the
+// transaction. If a concurrent commit conflicts with the transaction, Commit discards the
+// changes and returns ErrConflict. If the transaction is done, Commit returns ErrTxDone.
+//
+// Time complexity: O(N + M). Space complexity: O(N). N is the number of pending changes
+// and M is the number of elements changed by conflicting candidate commits.
+func (tx *Tx[E]) Commit() error {
+	t := tx.t
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if tx.done {
+		return ErrTxDone
+	}
+	conflict := slices.ContainsFunc(t.commits, func(c txCommit[E]) bool {
+		return c.version > tx.start && tx.conflicts(c.written)
+	})
+	tx.end()
+	if conflict {
+		return ErrConflict
+	}
+
+	written := New[E](len(tx.inserted) + len(tx.deleted))
+	for e := range tx.inserted {
+		if !Contains(t.s, e) {
+			t.s[e] = struct{}{}
+			written[e] = struct{}{}
+		}
+	}
+	for e := range tx.deleted {
+		if Contains(t.s, e) {
+			delete(t.s, e)
+			written[e] = struct{}{}
+		}
+	}
+	if len(written) > 0 {
+		t.version++
+		if len(t.active) > 0 {
+			t.commits = append(t.commits, txCommit[E]{version: t.version, written: written})
+		}
+	}
+	return nil
+}
+
+// Rollback discards the pending changes and ends the transaction.
+// If the transaction is done, Rollback returns ErrTxDone,
+// so it can be deferred right after Begin to release an unfinished transaction.
+//
+// Time complexity: O(1). Space complexity: O(1).
+func (tx *Tx[E]) Rollback() error {
+	t := tx.t
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if tx.done {
+		return ErrTxDone
+	}
+	tx.end()
+	return nil
+}
+
+// check panics if the transaction is done.
+func (tx *Tx[E]) check() {
+	if tx.done {
+		panic("transaction is done")
+	}
+}
+
+// end marks the transaction as done and drops the commits that no transaction needs anymore.
+// It must be called with t.mu held.
+func (tx *Tx[E]) end() {
+	t := tx.t
+	tx.done = true
+	if t.active[tx.start]--; t.active[tx.start] == 0 {
+		delete(t.active, tx.start)
+	}
+	oldest := t.version
+	for v := range t.active {
+		oldest = min(oldest, v)
+	}
+	// Commits up to the start of the oldest transaction in progress cannot conflict with it.
+	i := 0
+	for i < len(t.commits) && t.commits[i].version <= oldest {
+		i++
+	}
+	t.commits = slices.Delete(t.commits, 0, i)
+}
+
+// conflicts reports whether the elements written by a concurrent commit
+// intersect the elements read or written by the transaction.
+func (tx *Tx[E]) conflicts(written Set[E]) bool {
+	return tx.readAll || Overlaps(written, tx.read) || Overlaps(written, tx.inserted) || Overlaps(written, tx.deleted)
+}
@@ -0,0 +1,249 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sets
+
+import (
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestTxView(t *testing.T) {
+	s := NewTransactional(1, 2, 3)
+	tx := s.Begin()
+	tx.Insert(4, 5)
+	tx.Delete(2, 5, 9)
+	tx.Insert(2)
+	tx.Delete(2)
+
+	tests := []struct {
+		name string
+		got  bool
+		want bool
+	}{
+		{name: "unchanged", got: tx.Contains(1), want: true},
+		{name: "inserted", got: tx.Contains(4), want: true},
+		{name: "inserted then deleted", got: tx.Contains(5), want: false},
+		{name: "deleted", got: tx.Contains(2), want: false},
+		{name: "absent", got: tx.Contains(7), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("\nwant: %v\ngot : %v", tt.want, tt.got)
+			}
+		})
+	}
+	if got, want := Collect(tx.All()), From(1, 3, 4); !Equal(got, want) {
+		t.Errorf("\nwant: %v\ngot : %v", want, got)
+	}
+	for range tx.All() {
+		break
+	}
+	if got := tx.Len(); got != 3 {
+		t.Errorf("\nwant: %v\ngot : %v", 3, got)
+	}
+
+	// Pending changes are not visible outside the transaction.
+	if got, want := Collect(s.All()), From(1, 2, 3); !Equal(got, want) {
+		t.Errorf("\nwant: %v\ngot : %v", want, got)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := Collect(s.All()), From(1, 3, 4); !Equal(got, want) || s.Len() != 3 || !s.Contains(4) {
+		t.Errorf("\nwant: %v\ngot : %v", want, got)
+	}
+	for range s.All() {
+		break
+	}
+}
+
+func TestTxRollback(t *testing.T) {
+	s := NewTransactional("a")
+	tx := s.Begin()
+	tx.Insert("b")
+	tx.Delete("a")
+	if err := tx.Rollback(); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := Collect(s.All()), From("a"); !Equal(got, want) {
+		t.Errorf("\nwant: %v\ngot : %v", want, got)
+	}
+	if err := tx.Rollback(); !errors.Is(err, ErrTxDone) {
+		t.Errorf("\nwant: %v\ngot : %v", ErrTxDone, err)
+	}
+	if err := tx.Commit(); !errors.Is(err, ErrTxDone) {
+		t.Errorf("\nwant: %v\ngot : %v", ErrTxDone, err)
+	}
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Insert() on a finished transaction did not panic")
+		}
+	}()
+	tx.Insert("c")
+}
+
+func TestTxConflicts(t *testing.T) {
+	tests := []struct {
+		name     string
+		tx       func(tx *Tx[int])
+		other    func(tx *Tx[int])
+		conflict bool
+	}{
+		{
+			name:     "read of a written element",
+			tx:       func(tx *Tx[int]) { tx.Contains(1); tx.Insert(10) },
+			other:    func(tx *Tx[int]) { tx.Delete(1) },
+			conflict: true,
+		},
+		{
+			name:     "read of an inserted element",
+			tx:       func(tx *Tx[int]) { tx.Contains(5) },
+			other:    func(tx *Tx[int]) { tx.Insert(5) },
+			conflict: true,
+		},
+		{
+			name:     "both write the same element",
+			tx:       func(tx *Tx[int]) { tx.Insert(5) },
+			other:    func(tx *Tx[int]) { tx.Insert(5) },
+			conflict: true,
+		},
+		{
+			name:     "both delete the same element",
+			tx:       func(tx *Tx[int]) { tx.Delete(2) },
+			other:    func(tx *Tx[int]) { tx.Delete(2) },
+			conflict: true,
+		},
+		{
+			name:     "iteration",
+			tx:       func(tx *Tx[int]) { tx.Len() },
+			other:    func(tx *Tx[int]) { tx.Insert(9) },
+			conflict: true,
+		},
+		{
+			name:  "disjoint elements",
+			tx:    func(tx *Tx[int]) { tx.Contains(1); tx.Delete(2) },
+			other: func(tx *Tx[int]) { tx.Contains(3); tx.Insert(4) },
+		},
+		{
+			name:  "other commit changes nothing",
+			tx:    func(tx *Tx[int]) { tx.Len(); tx.Insert(4) },
+			other: func(tx *Tx[int]) { tx.Insert(1); tx.Delete(7) },
+		},
+		{
+			name:  "other rolls back",
+			tx:    func(tx *Tx[int]) { tx.Contains(1) },
+			other: func(tx *Tx[int]) { tx.Delete(1); _ = tx.Rollback() },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewTransactional(1, 2, 3)
+			tx, other := s.Begin(), s.Begin()
+			tt.tx(tx)
+			tt.other(other)
+			_ = other.Commit()
+			want := Collect(s.All())
+			err := tx.Commit()
+			if tt.conflict {
+				if !errors.Is(err, ErrConflict) {
+					t.Errorf("\nwant: %v\ngot : %v", ErrConflict, err)
+				}
+				if got := Collect(s.All()); !Equal(got, want) {
+					t.Errorf("a conflicting commit changed the set\nwant: %v\ngot : %v", want, got)
+				}
+			} else if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if len(s.commits) != 0 || len(s.active) != 0 {
+				t.Errorf("finished transactions left %d commits and %d active versions", len(s.commits), len(s.active))
+			}
+		})
+	}
+}
+
+func TestTxCommitLog(t *testing.T) {
+	s := NewTransactional[int]()
+	old := s.Begin()
+	old.Contains(1)
+	for i := range 3 {
+		tx := s.Begin()
+		tx.Insert(10 + i)
+		if err := tx.Commit(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Transactions started before a commit see it as concurrent, later ones do not.
+	late := s.Begin()
+	late.Contains(10)
+	if got := len(s.commits); got != 3 {
+		t.Errorf("\nwant: %v\ngot : %v", 3, got)
+	}
+	if err := old.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if got := len(s.commits); got != 0 {
+		t.Errorf("commits before the oldest transaction were kept: %v", got)
+	}
+	if err := late.Commit(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTransactionalConcurrent(t *testing.T) {
+	// Concurrent transfers of tokens between two halves of a set keep their number constant.
+	const tokens = 10
+	s := NewTransactional[int]()
+	setup := s.Begin()
+	for i := range tokens {
+		setup.Insert(i)
+	}
+	if err := setup.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for w := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 200; {
+				tx := s.Begin()
+				e := (w + i) % tokens
+				if tx.Contains(e) {
+					tx.Delete(e)
+					tx.Insert(e + tokens)
+				} else {
+					tx.Delete(e + tokens)
+					tx.Insert(e)
+				}
+				if tx.Commit() == nil {
+					i++
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if got := s.Len(); got != tokens || len(s.commits) != 0 {
+		t.Errorf("\nwant: %v\ngot : %v", tokens, got)
+	}
+}
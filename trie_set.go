@@ -0,0 +1,398 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sets
+
+import (
+	"iter"
+	"sort"
+	"strings"
+)
+
+// TrieSet is a set of strings stored in a radix tree (a compressed prefix trie).
+// In addition to membership tests it answers prefix queries without scanning all elements,
+// and iterates over elements in ascending byte-wise order.
+// The zero value is an empty set ready to use. Read-only methods accept a nil *TrieSet.
+//
+// Time complexities below are expressed in terms of k, the length of the queried string.
+type TrieSet struct {
+	root trieNode
+	n    int
+}
+
+// trieNode is a node of the radix tree. Every node other than the root either holds an element
+// or has at least two children, which keeps the tree compressed.
+type trieNode struct {
+	label    string // edge label leading to this node
+	terminal bool   // whether the path to this node is an element of the set
+	children []*trieNode
+}
+
+// NewTrieSet creates a new TrieSet containing the provided vals.
+//
+// Time complexity: O(N). Space complexity: O(N). N is the total length of vals.
+func NewTrieSet(vals ...string) *TrieSet {
+	t := &TrieSet{}
+	t.Insert(vals...)
+	return t
+}
+
+// ToTrieSet returns a TrieSet containing the elements of s.
+//
+// Time complexity: O(N). Space complexity: O(N). N is the total length of the elements of s.
+func ToTrieSet[S ~map[string]struct{}](s S) *TrieSet {
+	t := &TrieSet{}
+	for e := range s {
+		t.Insert(e)
+	}
+	return t
+}
+
+// FromTrieSet returns a new Set containing the elements of t.
+//
+// Time complexity: O(N). Space complexity: O(N). N is the total length of the elements of t.
+func FromTrieSet(t *TrieSet) Set[string] {
+	r := New[string](t.Len())
+	for e := range t.All() {
+		r[e] = struct{}{}
+	}
+	return r
+}
+
+// Insert inserts the given elements into the set.
+// Elements already present in the set are ignored.
+//
+// Time complexity: O(k) per element. Space complexity: O(k) per element.
+func (t *TrieSet) Insert(v ...string) {
+	for _, e := range v {
+		if t.root.insert(e) {
+			t.n++
+		}
+	}
+}
+
+// Delete deletes the specified elements from the set.
+// Elements not present in the set are ignored.
+//
+// Time complexity: O(k) per element. Space complexity: O(1).
+func (t *TrieSet) Delete(v ...string) {
+	for _, e := range v {
+		if t.root.delete(e) {
+			t.n--
+		}
+	}
+}
+
+// Contains reports whether v is present in the set.
+//
+// Time complexity: O(k). Space complexity: O(1).
+func (t *TrieSet) Contains(v string) bool {
+	n := t.self().root.find(v)
+	return n != nil && n.terminal
+}
+
+// HasPrefix reports whether any element of the set begins with prefix.
+//
+// Time complexity: O(k). Space complexity: O(1).
+func (t *TrieSet) HasPrefix(prefix string) bool {
+	if prefix == "" {
+		return t.Len() > 0
+	}
+	_, n := t.self().root.locate(prefix)
+	return n != nil
+}
+
+// WithPrefix returns an iterator over the elements of the set that begin with prefix, in ascending order.
+//
+// Creation: O(1) time, O(1) space.
+// Iteration: O(k + N) time, O(d) space. N is the total length of the yielded elements. d is the tree depth.
+func (t *TrieSet) WithPrefix(prefix string) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		path, n := t.self().root.locate(prefix)
+		if n != nil {
+			n.walk([]byte(path), yield)
+		}
+	}
+}
+
+// LongestPrefixOf returns the longest element of the set that is a prefix of s.
+// The boolean is false if no element of the set is a prefix of s.
+//
+// Time complexity: O(k). Space complexity: O(1).
+func (t *TrieSet) LongestPrefixOf(s string) (string, bool) {
+	n := &t.self().root
+	longest, found := 0, n.terminal
+	for depth := 0; depth < len(s); {
+		c := n.child(s[depth])
+		if c == nil || !strings.HasPrefix(s[depth:], c.label) {
+			break
+		}
+		n = c
+		depth += len(c.label)
+		if n.terminal {
+			longest, found = depth, true
+		}
+	}
+	return s[:longest], found
+}
+
+// Len returns the number of elements in the set.
+//
+// Time complexity: O(1). Space complexity: O(1).
+func (t *TrieSet) Len() int {
+	return t.self().n
+}
+
+// All returns an iterator over the elements of the set in ascending order.
+//
+// Creation: O(1) time, O(1) space.
+// Iteration: O(N) time, O(d) space. N is the total length of the elements. d is the tree depth.
+func (t *TrieSet) All() iter.Seq[string] {
+	return t.WithPrefix("")
+}
+
+// Equal reports whether t and other contain the same elements.
+//
+// Time complexity: O(N). Space complexity: O(d). N is the total length of the elements. d is the tree depth.
+func (t *TrieSet) Equal(other *TrieSet) bool {
+	if t.Len() != other.Len() {
+		return false
+	}
+	for e := range t.All() {
+		if !other.Contains(e) {
+			return false
+		}
+	}
+	return true
+}
+
+// Union returns a new set containing all elements from t and all of the others.
+//
+// Time complexity: O(N). Space complexity: O(N). N is the total length of the elements of all sets.
+func (t *TrieSet) Union(others ...*TrieSet) *TrieSet {
+	r := t.Clone()
+	for _, o := range others {
+		for e := range o.All() {
+			r.Insert(e)
+		}
+	}
+	return r
+}
+
+// Intersection returns a new set containing only elements present in t and in all of the others.
+//
+// Time complexity: O(N). Space complexity: O(N). N is the total length of the elements of all sets.
+func (t *TrieSet) Intersection(others ...*TrieSet) *TrieSet {
+	r := &TrieSet{}
+elementsLoop:
+	for e := range t.All() {
+		for _, o := range others {
+			if !o.Contains(e) {
+				continue elementsLoop
+			}
+		}
+		r.Insert(e)
+	}
+	return r
+}
+
+// Difference returns a new set containing elements present in t but not in any of the others.
+//
+// Time complexity: O(N). Space complexity: O(N). N is the total length of the elements of all sets.
+func (t *TrieSet) Difference(others ...*TrieSet) *TrieSet {
+	r := &TrieSet{}
+elementsLoop:
+	for e := range t.All() {
+		for _, o := range others {
+			if o.Contains(e) {
+				continue elementsLoop
+			}
+		}
+		r.Insert(e)
+	}
+	return r
+}
+
+// Clone returns a copy of t.
+//
+// Time complexity: O(m). Space complexity: O(m). m is the number of nodes in the tree.
+func (t *TrieSet) Clone() *TrieSet {
+	src := t.self()
+	return &TrieSet{root: *src.root.clone(), n: src.n}
+}
+
+// String returns a string representation of the set in the format "{elem1, elem2, ...}".
+// Elements are listed in ascending order.
+//
+// Time complexity: O(N). Space complexity: O(N). N is the total length of the elements.
+func (t *TrieSet) String() string {
+	elements := make([]string, 0, t.Len())
+	for e := range t.All() {
+		elements = append(elements, e)
+	}
+	return "{" + strings.Join(elements, ", ") + "}"
+}
+
+func (t *TrieSet) self() *TrieSet {
+	if t == nil {
+		return &TrieSet{}
+	}
+	return t
+}
+
+// child returns the child whose label starts with b, or nil.
+func (n *trieNode) child(b byte) *trieNode {
+	if i, ok := n.search(b); ok {
+		return n.children[i]
+	}
+	return nil
+}
+
+// search returns the position of the child whose label starts with b
+// and whether such a child exists.
+func (n *trieNode) search(b byte) (int, bool) {
+	i := sort.Search(len(n.children), func(i int) bool { return n.children[i].label[0] >= b })
+	return i, i < len(n.children) && n.children[i].label[0] == b
+}
+
+// find returns the node whose path from n is exactly s, or nil.
+func (n *trieNode) find(s string) *trieNode {
+	for s != "" {
+		c := n.child(s[0])
+		if c == nil || !strings.HasPrefix(s, c.label) {
+			return nil
+		}
+		n, s = c, s[len(c.label):]
+	}
+	return n
+}
+
+// locate returns the topmost node whose path from n begins with prefix together with that path, or nil.
+func (n *trieNode) locate(prefix string) (string, *trieNode) {
+	path := ""
+	for depth := 0; depth < len(prefix); depth += len(n.label) {
+		c := n.child(prefix[depth])
+		if c == nil {
+			return "", nil
+		}
+		rest := prefix[depth:]
+		if !strings.HasPrefix(rest, c.label) && !strings.HasPrefix(c.label, rest) {
+			return "", nil
+		}
+		path = prefix[:depth] + c.label
+		n = c
+	}
+	return path, n
+}
+
+// walk yields the elements of the subtree rooted at n in ascending order.
+// path is the full path to n and is used as scratch space.
+func (n *trieNode) walk(path []byte, yield func(string) bool) bool {
+	if n.terminal && !yield(string(path)) {
+		return false
+	}
+	for _, c := range n.children {
+		if !c.walk(append(path, c.label...), yield) {
+			return false
+		}
+	}
+	return true
+}
+
+// insert adds s below n and reports whether it was absent.
+func (n *trieNode) insert(s string) bool {
+	for s != "" {
+		i, ok := n.search(s[0])
+		if !ok {
+			leaf := &trieNode{label: s, terminal: true}
+			n.children = append(n.children, nil)
+			copy(n.children[i+1:], n.children[i:])
+			n.children[i] = leaf
+			return true
+		}
+		c := n.children[i]
+		common := commonPrefixLen(c.label, s)
+		if common < len(c.label) {
+			// Split the edge so that the common part leads to a new intermediate node.
+			mid := &trieNode{label: c.label[:common], children: []*trieNode{c}}
+			c.label = c.label[common:]
+			n.children[i] = mid
+			c = mid
+		}
+		n, s = c, s[common:]
+	}
+	if n.terminal {
+		return false
+	}
+	n.terminal = true
+	return true
+}
+
+// delete removes s from below n and reports whether it was present.
+// Nodes that become redundant are removed or merged with their only child.
+func (n *trieNode) delete(s string) bool {
+	if s == "" {
+		if !n.terminal {
+			return false
+		}
+		n.terminal = false
+		return true
+	}
+	i, ok := n.search(s[0])
+	if !ok {
+		return false
+	}
+	c := n.children[i]
+	if !strings.HasPrefix(s, c.label) || !c.delete(s[len(c.label):]) {
+		return false
+	}
+	if !c.terminal {
+		switch len(c.children) {
+		case 0:
+			n.children = append(n.children[:i], n.children[i+1:]...)
+		case 1:
+			gc := c.children[0]
+			gc.label = c.label + gc.label
+			n.children[i] = gc
+		}
+	}
+	return true
+}
+
+func (n *trieNode) clone() *trieNode {
+	r := &trieNode{label: n.label, terminal: n.terminal}
+	if len(n.children) > 0 {
+		r.children = make([]*trieNode, len(n.children))
+		for i, c := range n.children {
+			r.children[i] = c.clone()
+		}
+	}
+	return r
+}
+
+func commonPrefixLen(a, b string) int {
+	n := min(len(a), len(b))
+	for i := range n {
+		if a[i] != b[i] {
+			return i
+		}
+	}
+	return n
+}
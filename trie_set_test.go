@@ -0,0 +1,377 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sets
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestTrieSetInsertDelete(t *testing.T) {
+	tests := []struct {
+		name    string
+		insert  []string
+		delete  []string
+		want    []string
+		wantLen int
+	}{
+		{
+			name:    "empty",
+			insert:  nil,
+			delete:  nil,
+			want:    nil,
+			wantLen: 0,
+		},
+		{
+			name:    "shared prefixes",
+			insert:  []string{"team", "tea", "ten", "t", "to", "toast"},
+			delete:  nil,
+			want:    []string{"t", "tea", "team", "ten", "to", "toast"},
+			wantLen: 6,
+		},
+		{
+			name:    "duplicates",
+			insert:  []string{"a", "ab", "a", "ab"},
+			delete:  nil,
+			want:    []string{"a", "ab"},
+			wantLen: 2,
+		},
+		{
+			name:    "empty string element",
+			insert:  []string{"", "a"},
+			delete:  nil,
+			want:    []string{"", "a"},
+			wantLen: 2,
+		},
+		{
+			name:    "delete leaf",
+			insert:  []string{"tea", "team", "ten"},
+			delete:  []string{"team"},
+			want:    []string{"tea", "ten"},
+			wantLen: 2,
+		},
+		{
+			name:    "delete inner element merges edges",
+			insert:  []string{"tea", "team"},
+			delete:  []string{"tea"},
+			want:    []string{"team"},
+			wantLen: 1,
+		},
+		{
+			name:    "delete leaf merges edges",
+			insert:  []string{"tea", "ten", "te"},
+			delete:  []string{"te", "ten"},
+			want:    []string{"tea"},
+			wantLen: 1,
+		},
+		{
+			name:    "delete absent",
+			insert:  []string{"tea", "team"},
+			delete:  []string{"te", "teams", "x", "", "tex"},
+			want:    []string{"tea", "team"},
+			wantLen: 2,
+		},
+		{
+			name:    "delete empty string element",
+			insert:  []string{"", "a"},
+			delete:  []string{""},
+			want:    []string{"a"},
+			wantLen: 1,
+		},
+		{
+			name:    "delete all",
+			insert:  []string{"a", "ab", "abc", "b"},
+			delete:  []string{"ab", "abc", "a", "b"},
+			want:    nil,
+			wantLen: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var s TrieSet
+			s.Insert(tt.insert...)
+			s.Delete(tt.delete...)
+			if got := slices.Collect(s.All()); !slices.Equal(got, tt.want) {
+				t.Errorf("\nwant: %v\ngot : %v", tt.want, got)
+			}
+			if got := s.Len(); got != tt.wantLen {
+				t.Errorf("Len()\nwant: %v\ngot : %v", tt.wantLen, got)
+			}
+			for _, e := range tt.want {
+				if !s.Contains(e) {
+					t.Errorf("Contains(%q) = false", e)
+				}
+			}
+		})
+	}
+}
+
+func TestTrieSetContains(t *testing.T) {
+	s := NewTrieSet("tea", "team", "ten")
+	tests := []struct {
+		name string
+		s    *TrieSet
+		v    string
+		want bool
+	}{
+		{name: "nil set", s: nil, v: "tea", want: false},
+		{name: "member", s: s, v: "team", want: true},
+		{name: "inner node", s: s, v: "te", want: false},
+		{name: "mid edge", s: s, v: "tem", want: false},
+		{name: "longer", s: s, v: "teams", want: false},
+		{name: "empty string", s: s, v: "", want: false},
+		{name: "no edge", s: s, v: "x", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.s.Contains(tt.v); got != tt.want {
+				t.Errorf("\nwant: %v\ngot : %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestTrieSetPrefixQueries(t *testing.T) {
+	s := NewTrieSet("/api/v1/users", "/api/v1/orders", "/api/v2/users", "/static", "/")
+	tests := []struct {
+		name       string
+		s          *TrieSet
+		prefix     string
+		wantHas    bool
+		wantWithin []string
+	}{
+		{
+			name:       "nil set",
+			s:          nil,
+			prefix:     "",
+			wantHas:    false,
+			wantWithin: nil,
+		},
+		{
+			name:       "empty prefix",
+			s:          s,
+			prefix:     "",
+			wantHas:    true,
+			wantWithin: []string{"/", "/api/v1/orders", "/api/v1/users", "/api/v2/users", "/static"},
+		},
+		{
+			name:       "prefix ending at node",
+			s:          s,
+			prefix:     "/api/v",
+			wantHas:    true,
+			wantWithin: []string{"/api/v1/orders", "/api/v1/users", "/api/v2/users"},
+		},
+		{
+			name:       "prefix ending mid edge",
+			s:          s,
+			prefix:     "/api/v1/u",
+			wantHas:    true,
+			wantWithin: []string{"/api/v1/users"},
+		},
+		{
+			name:       "prefix equal to element",
+			s:          s,
+			prefix:     "/static",
+			wantHas:    true,
+			wantWithin: []string{"/static"},
+		},
+		{
+			name:       "diverging mid edge",
+			s:          s,
+			prefix:     "/api/v1/ux",
+			wantHas:    false,
+			wantWithin: nil,
+		},
+		{
+			name:       "no such edge",
+			s:          s,
+			prefix:     "/x",
+			wantHas:    false,
+			wantWithin: nil,
+		},
+		{
+			name:       "longer than elements",
+			s:          s,
+			prefix:     "/static/css",
+			wantHas:    false,
+			wantWithin: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.s.HasPrefix(tt.prefix); got != tt.wantHas {
+				t.Errorf("HasPrefix()\nwant: %v\ngot : %v", tt.wantHas, got)
+			}
+			if got := slices.Collect(tt.s.WithPrefix(tt.prefix)); !slices.Equal(got, tt.wantWithin) {
+				t.Errorf("WithPrefix()\nwant: %v\ngot : %v", tt.wantWithin, got)
+			}
+		})
+	}
+}
+
+func TestTrieSetWithPrefixEarlyTermination(t *testing.T) {
+	s := NewTrieSet("a", "ab", "abc", "b", "ba")
+	count := 0
+	for range s.WithPrefix("a") {
+		count++
+		if count == 2 {
+			break
+		}
+	}
+	if count != 2 {
+		t.Errorf("expected to iterate 2 times, got %d", count)
+	}
+}
+
+func TestTrieSetLongestPrefixOf(t *testing.T) {
+	s := NewTrieSet("example.com", "api.example.com", "a", "api")
+	tests := []struct {
+		name   string
+		s      *TrieSet
+		v      string
+		want   string
+		wantOK bool
+	}{
+		{name: "nil set", s: nil, v: "abc", want: "", wantOK: false},
+		{name: "exact", s: s, v: "api", want: "api", wantOK: true},
+		{name: "longest", s: s, v: "api.example.com/path", want: "api.example.com", wantOK: true},
+		{name: "shorter match", s: s, v: "api.example.org", want: "api", wantOK: true},
+		{name: "mid edge", s: s, v: "ap", want: "a", wantOK: true},
+		{name: "none", s: s, v: "www.example.com", want: "", wantOK: false},
+		{name: "empty element", s: NewTrieSet("", "x"), v: "abc", want: "", wantOK: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.s.LongestPrefixOf(tt.v)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("\nwant: %q, %v\ngot : %q, %v", tt.want, tt.wantOK, got, ok)
+			}
+		})
+	}
+}
+
+func TestTrieSetOperations(t *testing.T) {
+	a := NewTrieSet("apple", "apricot", "banana")
+	b := NewTrieSet("apricot", "banana", "cherry")
+	c := NewTrieSet("banana", "date")
+	tests := []struct {
+		name string
+		got  *TrieSet
+		want string
+	}{
+		{name: "union", got: a.Union(b, c), want: "{apple, apricot, banana, cherry, date}"},
+		{name: "union with nil", got: (*TrieSet)(nil).Union(nil, c), want: "{banana, date}"},
+		{name: "intersection", got: a.Intersection(b), want: "{apricot, banana}"},
+		{name: "intersection of three", got: a.Intersection(b, c), want: "{banana}"},
+		{name: "intersection of none", got: a.Intersection(), want: "{apple, apricot, banana}"},
+		{name: "difference", got: a.Difference(b), want: "{apple}"},
+		{name: "difference of two", got: b.Difference(a, c), want: "{cherry}"},
+		{name: "difference of none", got: a.Difference(), want: "{apple, apricot, banana}"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.got.String(); got != tt.want {
+				t.Errorf("\nwant: %v\ngot : %v", tt.want, got)
+			}
+		})
+	}
+	if got := a.String(); got != "{apple, apricot, banana}" {
+		t.Errorf("operations modified the receiver: %v", got)
+	}
+}
+
+func TestTrieSetEqualClone(t *testing.T) {
+	s := NewTrieSet("a", "ab", "b")
+	clone := s.Clone()
+	if !clone.Equal(s) {
+		t.Errorf("Clone() is not equal to the original")
+	}
+	clone.Delete("ab")
+	clone.Insert("ac")
+	if clone.Equal(s) || s.Contains("ac") || !s.Contains("ab") {
+		t.Errorf("Clone() shares storage with the original")
+	}
+	if !(*TrieSet)(nil).Equal(NewTrieSet()) {
+		t.Errorf("nil set is not equal to an empty set")
+	}
+	if NewTrieSet("a").Equal(NewTrieSet("b")) || NewTrieSet("a").Equal(NewTrieSet("a", "b")) {
+		t.Errorf("different sets reported as equal")
+	}
+}
+
+func TestTrieSetConversion(t *testing.T) {
+	s := From("b", "a", "ab", "")
+	trie := ToTrieSet(s)
+	if got, want := trie.String(), "{, a, ab, b}"; got != want {
+		t.Errorf("\nwant: %v\ngot : %v", want, got)
+	}
+	if got := FromTrieSet(trie); !Equal(got, s) {
+		t.Errorf("round trip\nwant: %v\ngot : %v", s, got)
+	}
+	if got := FromTrieSet(nil); len(got) != 0 {
+		t.Errorf("FromTrieSet(nil) returned %v", got)
+	}
+}
+
+func TestTrieSetAgainstSet(t *testing.T) {
+	r := rand.New(rand.NewPCG(5, 6))
+	word := func() string {
+		b := make([]byte, r.IntN(5))
+		for i := range b {
+			b[i] = "abc"[r.IntN(3)]
+		}
+		return string(b)
+	}
+	trie := NewTrieSet()
+	ref := New[string](0)
+	for range 2000 {
+		w := word()
+		if r.IntN(3) == 0 {
+			trie.Delete(w)
+			Delete(ref, w)
+		} else {
+			trie.Insert(w)
+			Insert(ref, w)
+		}
+
+		if trie.Len() != len(ref) {
+			t.Fatalf("Len()\nwant: %v\ngot : %v", len(ref), trie.Len())
+		}
+		p := word()
+		var want []string
+		for e := range ref {
+			if len(e) >= len(p) && e[:len(p)] == p {
+				want = append(want, e)
+			}
+		}
+		slices.Sort(want)
+		if got := slices.Collect(trie.WithPrefix(p)); !slices.Equal(got, want) {
+			t.Fatalf("WithPrefix(%q)\nwant: %v\ngot : %v", p, want, got)
+		}
+		if got := trie.HasPrefix(p); got != (len(want) > 0) {
+			t.Fatalf("HasPrefix(%q)\nwant: %v\ngot : %v", p, len(want) > 0, got)
+		}
+	}
+	if got := FromTrieSet(trie); !Equal(got, ref) {
+		t.Fatalf("\nwant: %v\ngot : %v", ref, got)
+	}
+}
@@ -0,0 +1,272 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sets
+
+import (
+	"errors"
+	"iter"
+	"sync"
+)
+
+// ErrVersionUnavailable is returned for versions of a Versioned set that have been
+// compacted or have not been created yet.
+var ErrVersionUnavailable = errors.New("sets: version is not available")
+
+// Versioned is a set that records every mutation as a new version, so that its elements at
+// any retained version and the changes between versions can be queried later, e.g. for auditing.
+// Version 0 is the set created by NewVersioned; every call that modifies the set creates the next
+// version, and calls that do not modify it create none.
+//
+// The history is stored as one Change per version on top of the elements at the oldest retained
+// version. It can be dropped explicitly with Compact or automatically by a retention limit.
+//
+// Versioned is safe for concurrent use.
+type Versioned[E comparable] struct {
+	mu        sync.RWMutex
+	s         Set[E]
+	base      Set[E]      // elements at the oldest retained version
+	oldest    uint64      // oldest retained version
+	history   []Change[E] // history[i] leads from version oldest+i to oldest+i+1
+	retention int
+}
+
+// NewVersioned creates a new Versioned set containing vals at version 0 that retains at most
+// retention versions before the current one. If retention is 0, all versions are retained.
+// If retention is negative, NewVersioned panics.
+//
+// Time complexity: O(len(vals)). Space complexity: O(len(vals)).
+func NewVersioned[E comparable](retention int, vals ...E) *Versioned[E] {
+	if retention < 0 {
+		panic("cannot be negative")
+	}
+	return &Versioned[E]{s: From(vals...), base: From(vals...), retention: retention}
+}
+
+// Insert inserts the given elements into the set as a new version.
+// Elements already present in the set are ignored.
+//
+// Time complexity: O(len(v)). Space complexity: O(len(v)).
+func (s *Versioned[E]) Insert(v ...E) {
+	s.Apply(Change[E]{Added: From(v...)})
+}
+
+// Delete deletes the given elements from the set as a new version.
+// Elements not present in the set are ignored.
+//
+// Time complexity: O(len(v)). Space complexity: O(len(v)).
+func (s *Versioned[E]) Delete(v ...E) {
+	s.Apply(Change[E]{Removed: From(v...)})
+}
+
+// Apply inserts the elements of c.Added and then deletes the elements of c.Removed
+// as a single new version, and returns the current version.
+//
+// Time complexity: O(len(c.Added) + len(c.Removed)). Space complexity: O(len(c.Added) + len(c.Removed)).
+func (s *Versioned[E]) Apply(c Change[E]) uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	change := newChange[E]()
+	for e := range c.Added {
+		if !Contains(s.s, e) && !Contains(c.Removed, e) {
+			change.Added[e] = struct{}{}
+		}
+	}
+	for e := range c.Removed {
+		if Contains(s.s, e) {
+			change.Removed[e] = struct{}{}
+		}
+	}
+	if !change.Empty() {
+		apply(s.s, change.Added, change.Removed)
+		s.history = append(s.history, change)
+		if s.retention > 0 && len(s.history) > s.retention {
+			s.compact(s.version() - uint64(s.retention))
+		}
+	}
+	return s.version()
+}
+
+// Contains reports whether v is present in the set.
+//
+// Time complexity: O(1). Space complexity: O(1).
+func (s *Versioned[E]) Contains(v E) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return Contains(s.s, v)
+}
+
+// Len returns the number of elements in the set.
+//
+// Time complexity: O(1). Space complexity: O(1).
+func (s *Versioned[E]) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.s)
+}
+
+// All returns an iterator over a snapshot of the current elements of the set.
+// The iteration order is not specified. The set may be modified during iteration.
+//
+// Creation: O(1) time, O(1) space.
+// Iteration: O(len(s)) time, O(len(s)) space.
+func (s *Versioned[E]) All() iter.Seq[E] {
+	return func(yield func(E) bool) {
+		s.mu.RLock()
+		elements := ToSlice(s.s)
+		s.mu.RUnlock()
+		for _, e := range elements {
+			if !yield(e) {
+				return
+			}
+		}
+	}
+}
+
+// Version returns the current version.
+//
+// Time complexity: O(1). Space complexity: O(1).
+func (s *Versioned[E]) Version() uint64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.version()
+}
+
+// Oldest returns the oldest version that has not been compacted.
+//
+// Time complexity: O(1). Space complexity: O(1).
+func (s *Versioned[E]) Oldest() uint64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.oldest
+}
+
+// AsOf returns a new set containing the elements at the given version.
+// If the version has been compacted or is newer than the current version,
+// the error is ErrVersionUnavailable.
+//
+// Time complexity: O(len(s) + N). Space complexity: O(len(s)).
+// N is the number of elements changed between the version and the nearer of the oldest
+// retained and the current version.
+func (s *Versioned[E]) AsOf(version uint64) (Set[E], error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if version < s.oldest || version > s.version() {
+		return nil, ErrVersionUnavailable
+	}
+	return s.asOf(version), nil
+}
+
+// Changes returns the elements added and removed between the versions from and to,
+// i.e. Difference(AsOf(to), AsOf(from)) and Difference(AsOf(from), AsOf(to)).
+// from may be greater than to. If either version is unavailable, the error is ErrVersionUnavailable.
+//
+// Time complexity: O(N). Space complexity: O(N). N is the number of elements changed by the versions between from and to.
+func (s *Versioned[E]) Changes(from, to uint64) (Change[E], error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if min(from, to) < s.oldest || max(from, to) > s.version() {
+		return Change[E]{}, ErrVersionUnavailable
+	}
+	if from > to {
+		c := s.changes(to, from)
+		return Change[E]{Added: c.Removed, Removed: c.Added}, nil
+	}
+	return s.changes(from, to), nil
+}
+
+// Compact drops the history before version, which becomes the oldest available version.
+// If version is older than the oldest available version, Compact is a no-op.
+// If version is newer than the current version, the error is ErrVersionUnavailable.
+//
+// Time complexity: O(len(s) + N). Space complexity: O(len(s)).
+// N is the number of elements changed by the dropped versions.
+func (s *Versioned[E]) Compact(version uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if version > s.version() {
+		return ErrVersionUnavailable
+	}
+	if version > s.oldest {
+		s.compact(version)
+	}
+	return nil
+}
+
+func (s *Versioned[E]) version() uint64 {
+	return s.oldest + uint64(len(s.history))
+}
+
+// compact makes version, which must be retained, the oldest retained version.
+func (s *Versioned[E]) compact(version uint64) {
+	n := int(version - s.oldest)
+	for _, c := range s.history[:n] {
+		apply(s.base, c.Added, c.Removed)
+	}
+	// Copy the remaining history so that the dropped changes can be garbage collected.
+	s.history = append([]Change[E](nil), s.history[n:]...)
+	s.oldest = version
+}
+
+// asOf returns the elements at version, replaying the history from the nearer end.
+func (s *Versioned[E]) asOf(version uint64) Set[E] {
+	if version-s.oldest < s.version()-version {
+		r := Clone(s.base)
+		for _, c := range s.history[:version-s.oldest] {
+			apply(r, c.Added, c.Removed)
+		}
+		return r
+	}
+	r := Clone(s.s)
+	for i := len(s.history) - 1; i >= int(version-s.oldest); i-- {
+		apply(r, s.history[i].Removed, s.history[i].Added)
+	}
+	return r
+}
+
+// changes returns the net changes from version from to version to, where from <= to.
+func (s *Versioned[E]) changes(from, to uint64) Change[E] {
+	r := newChange[E]()
+	for _, c := range s.history[from-s.oldest : to-s.oldest] {
+		for e := range c.Added {
+			if Contains(r.Removed, e) {
+				delete(r.Removed, e)
+			} else {
+				r.Added[e] = struct{}{}
+			}
+		}
+		for e := range c.Removed {
+			if Contains(r.Added, e) {
+				delete(r.Added, e)
+			} else {
+				r.Removed[e] = struct{}{}
+			}
+		}
+	}
+	return r
+}
+
+// apply inserts added into s and deletes removed from it.
+func apply[E comparable](s, added, removed Set[E]) {
+	Copy(s, added)
+	for e := range removed {
+		delete(s, e)
+	}
+}
@@ -0,0 +1,189 @@
+// Copyright (c) 2026 kkhmel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sets
+
+import (
+	"errors"
+	"math/rand/v2"
+	"sync"
+	"testing"
+)
+
+func TestVersionedMutations(t *testing.T) {
+	s := NewVersioned(0, 1, 2)
+	tests := []struct {
+		name        string
+		mutate      func()
+		wantVersion uint64
+		want        Set[int]
+	}{
+		{name: "insert", mutate: func() { s.Insert(2, 3) }, wantVersion: 1, want: From(1, 2, 3)},
+		{name: "insert present", mutate: func() { s.Insert(1, 3) }, wantVersion: 1, want: From(1, 2, 3)},
+		{name: "delete", mutate: func() { s.Delete(1, 9) }, wantVersion: 2, want: From(2, 3)},
+		{name: "delete absent", mutate: func() { s.Delete(9) }, wantVersion: 2, want: From(2, 3)},
+		{
+			name:        "apply",
+			mutate:      func() { s.Apply(Change[int]{Added: From(4, 5, 3), Removed: From(2, 5)}) },
+			wantVersion: 3,
+			want:        From(3, 4),
+		},
+	}
+	for _, tt := range tests {
+		tt.mutate()
+		if got := s.Version(); got != tt.wantVersion {
+			t.Errorf("%s: version\nwant: %v\ngot : %v", tt.name, tt.wantVersion, got)
+		}
+		if got := Collect(s.All()); !Equal(got, tt.want) {
+			t.Errorf("%s:\nwant: %v\ngot : %v", tt.name, tt.want, got)
+		}
+	}
+	if !s.Contains(4) || s.Contains(2) || s.Len() != 2 {
+		t.Errorf("Contains() = %v, %v; Len() = %v", s.Contains(4), s.Contains(2), s.Len())
+	}
+	for range s.All() {
+		break
+	}
+
+	c, err := s.Changes(2, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !Equal(c.Added, From(4)) || !Equal(c.Removed, From(2)) {
+		t.Errorf("Changes() = %v, %v", c.Added, c.Removed)
+	}
+}
+
+func TestVersionedHistory(t *testing.T) {
+	// AsOf and Changes agree with snapshots taken after every mutation.
+	rng := rand.New(rand.NewPCG(1, 2))
+	s := NewVersioned[int](0)
+	snapshots := []Set[int]{New[int](0)}
+	for range 300 {
+		v := []int{rng.IntN(20), rng.IntN(20)}
+		switch rng.IntN(3) {
+		case 0:
+			s.Insert(v...)
+		case 1:
+			s.Delete(v...)
+		default:
+			s.Apply(Change[int]{Added: From(v[0]), Removed: From(v[1])})
+		}
+		if s.Version() == uint64(len(snapshots)) {
+			snapshots = append(snapshots, Collect(s.All()))
+		}
+	}
+
+	for range 200 {
+		from, to := uint64(rng.IntN(len(snapshots))), uint64(rng.IntN(len(snapshots)))
+		got, err := s.AsOf(from)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := snapshots[from]; !Equal(got, want) {
+			t.Errorf("AsOf(%d)\nwant: %v\ngot : %v", from, want, got)
+		}
+		c, err := s.Changes(from, to)
+		if err != nil {
+			t.Fatal(err)
+		}
+		wantAdded, wantRemoved := Difference(snapshots[to], snapshots[from]), Difference(snapshots[from], snapshots[to])
+		if !Equal(c.Added, wantAdded) || !Equal(c.Removed, wantRemoved) {
+			t.Errorf("Changes(%d, %d)\nwant: %v, %v\ngot : %v, %v", from, to, wantAdded, wantRemoved, c.Added, c.Removed)
+		}
+	}
+
+	// Compaction keeps the later versions.
+	if err := s.Compact(100); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Compact(50); err != nil || s.Oldest() != 100 {
+		t.Errorf("Compact() to an older version = %v; oldest %v", err, s.Oldest())
+	}
+	for v := uint64(100); v < uint64(len(snapshots)); v++ {
+		if got, _ := s.AsOf(v); !Equal(got, snapshots[v]) {
+			t.Errorf("AsOf(%d) after Compact()\nwant: %v\ngot : %v", v, snapshots[v], got)
+		}
+	}
+}
+
+func TestVersionedUnavailable(t *testing.T) {
+	s := NewVersioned(0, "a")
+	s.Insert("b")
+	s.Insert("c")
+	s.Insert("d")
+	if err := s.Compact(2); err != nil {
+		t.Fatal(err)
+	}
+	for _, v := range []uint64{1, 5} {
+		if _, err := s.AsOf(v); !errors.Is(err, ErrVersionUnavailable) {
+			t.Errorf("AsOf(%d)\nwant: %v\ngot : %v", v, ErrVersionUnavailable, err)
+		}
+		if _, err := s.Changes(2, v); !errors.Is(err, ErrVersionUnavailable) {
+			t.Errorf("Changes(2, %d)\nwant: %v\ngot : %v", v, ErrVersionUnavailable, err)
+		}
+	}
+	if err := s.Compact(4); !errors.Is(err, ErrVersionUnavailable) {
+		t.Errorf("\nwant: %v\ngot : %v", ErrVersionUnavailable, err)
+	}
+	if got, _ := s.AsOf(2); !Equal(got, From("a", "b", "c")) {
+		t.Errorf("\nwant: %v\ngot : %v", From("a", "b", "c"), got)
+	}
+}
+
+func TestVersionedRetention(t *testing.T) {
+	s := NewVersioned[int](3)
+	for i := range 10 {
+		s.Insert(i)
+	}
+	if s.Oldest() != 7 || s.Version() != 10 || len(s.history) != 3 {
+		t.Errorf("oldest %v, version %v, %d changes", s.Oldest(), s.Version(), len(s.history))
+	}
+	if got, _ := s.AsOf(7); !Equal(got, From(0, 1, 2, 3, 4, 5, 6)) {
+		t.Errorf("\nwant: %v\ngot : %v", From(0, 1, 2, 3, 4, 5, 6), got)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("NewVersioned() did not panic")
+		}
+	}()
+	NewVersioned[int](-1)
+}
+
+func TestVersionedConcurrent(t *testing.T) {
+	s := NewVersioned[int](50)
+	var wg sync.WaitGroup
+	for w := range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 100 {
+				s.Insert(w*100 + i)
+				// The version may be compacted concurrently; the race detector checks the access.
+				_, _ = s.AsOf(s.Oldest())
+			}
+		}()
+	}
+	wg.Wait()
+	if s.Len() != 400 || s.Version() != 400 {
+		t.Errorf("Len() = %v, Version() = %v", s.Len(), s.Version())
+	}
+}